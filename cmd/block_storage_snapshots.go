@@ -6,6 +6,7 @@ import (
 	"os"
 	"text/tabwriter"
 
+	"github.com/haung921209/nhn-cloud-cli/internal/retention"
 	"github.com/haung921209/nhn-cloud-sdk-go/nhncloud/storage/block"
 	"github.com/spf13/cobra"
 )
@@ -14,6 +15,7 @@ func init() {
 	blockStorageCmd.AddCommand(bsDescribeSnapshotsCmd)
 	blockStorageCmd.AddCommand(bsCreateSnapshotCmd)
 	blockStorageCmd.AddCommand(bsDeleteSnapshotCmd)
+	blockStorageCmd.AddCommand(bsPruneSnapshotsCmd)
 
 	bsDescribeSnapshotsCmd.Flags().String("snapshot-id", "", "Snapshot ID")
 
@@ -25,6 +27,9 @@ func init() {
 
 	bsDeleteSnapshotCmd.Flags().String("snapshot-id", "", "Snapshot ID (required)")
 	bsDeleteSnapshotCmd.MarkFlagRequired("snapshot-id")
+
+	bsPruneSnapshotsCmd.Flags().String("volume-id", "", "Volume ID; all volumes when omitted")
+	addPruneFlags(bsPruneSnapshotsCmd)
 }
 
 var bsDescribeSnapshotsCmd = &cobra.Command{
@@ -123,3 +128,47 @@ var bsDeleteSnapshotCmd = &cobra.Command{
 		fmt.Printf("Snapshot %s deleted successfully\n", id)
 	},
 }
+
+var bsPruneSnapshotsCmd = &cobra.Command{
+	Use:   "prune-snapshots",
+	Short: "Delete snapshots outside a retention policy",
+	Long: `Deletes available snapshots that fall outside a retention policy, per
+volume. Rule tags match snapshot metadata.
+` + pruneLongHelp + `
+
+Examples:
+  nhncloud block-storage prune-snapshots --policy-file retention.yaml --dry-run
+  nhncloud block-storage prune-snapshots --volume-id <id> --policy-file retention.yaml --yes`,
+	Run: func(cmd *cobra.Command, args []string) {
+		policy := loadPrunePolicy(cmd)
+		client := getBlockStorageClient()
+		ctx := context.Background()
+		volumeID, _ := cmd.Flags().GetString("volume-id")
+
+		result, err := client.ListSnapshots(ctx)
+		if err != nil {
+			exitWithError("Failed to list snapshots", err)
+		}
+		var snapshots []retention.Snapshot
+		for _, s := range result.Snapshots {
+			if s.Status != "available" || (volumeID != "" && s.VolumeID != volumeID) {
+				continue
+			}
+			created, ok := parseFilterDate(s.CreatedAt)
+			if !ok {
+				continue
+			}
+			snapshots = append(snapshots, retention.Snapshot{
+				ID:        s.ID,
+				Name:      s.Name,
+				Resource:  s.VolumeID,
+				CreatedAt: created,
+				Tags:      s.Metadata,
+			})
+		}
+
+		runPrune(cmd, policy, snapshots, func(s retention.Snapshot) error {
+			return client.DeleteSnapshot(ctx, s.ID)
+		})
+	},
+}
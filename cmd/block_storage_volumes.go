@@ -54,10 +54,27 @@ func init() {
 	bsDetachVolumeCmd.MarkFlagRequired("volume-id")
 }
 
+// bsVolumeFilterAliases are the short --filter fields of describe-volumes
+var bsVolumeFilterAliases = filterAliases{
+	"type":      "volume_type",
+	"az":        "availability_zone",
+	"server-id": "attachments.server_id",
+	"created":   "created_at",
+}
+
 var bsDescribeVolumesCmd = &cobra.Command{
 	Use:     "describe-volumes",
 	Aliases: []string{"list-volumes", "list", "ls"},
 	Short:   "Describe volumes",
+	Long: `Describes a volume, or lists all volumes.
+
+Filters (--filter, repeatable):
+  id, name, status, size (GB), type, bootable, az, server-id, created,
+  metadata.<key> and any other JSON field of the volume
+
+Examples:
+  nhncloud block-storage describe-volumes --filter status=available
+  nhncloud block-storage describe-volumes --filter size>=100 --filter 'type~SSD'`,
 	Run: func(cmd *cobra.Command, args []string) {
 		client := getBlockStorageClient()
 		ctx := context.Background()
@@ -95,6 +112,7 @@ var bsDescribeVolumesCmd = &cobra.Command{
 			if err != nil {
 				exitWithError("Failed to list volumes", err)
 			}
+			result.Volumes = filterItems(result.Volumes, bsVolumeFilterAliases)
 			if output == "json" {
 				printJSON(result)
 				return
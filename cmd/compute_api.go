@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// ============================================================================
+// Raw Compute API
+//
+// A few Nova endpoints (revertResize, os-server-password) are not wrapped by
+// the SDK compute client. computeAPI authenticates against Identity the same
+// way the SDK does and calls them directly.
+// ============================================================================
+
+const identityTokenURL = "https://api-identity-infrastructure.nhncloudservice.com/v2.0/tokens"
+
+type computeAPI struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+type identityTokenResponse struct {
+	Access struct {
+		Token struct {
+			ID string `json:"id"`
+		} `json:"token"`
+		ServiceCatalog []struct {
+			Type      string `json:"type"`
+			Endpoints []struct {
+				PublicURL string `json:"publicURL"`
+				Region    string `json:"region"`
+			} `json:"endpoints"`
+		} `json:"serviceCatalog"`
+	} `json:"access"`
+}
+
+// newComputeAPI issues an Identity token and resolves the compute endpoint
+// for the current region
+func newComputeAPI(ctx context.Context) (*computeAPI, error) {
+	api := &computeAPI{httpClient: &http.Client{Timeout: 60 * time.Second}}
+
+	reqBody := map[string]interface{}{
+		"auth": map[string]interface{}{
+			"tenantId": getTenantID(),
+			"passwordCredentials": map[string]string{
+				"username": getUsername(),
+				"password": getPassword(),
+			},
+		},
+	}
+
+	var tokenResp identityTokenResponse
+	if err := api.send(ctx, http.MethodPost, identityTokenURL, reqBody, &tokenResp); err != nil {
+		return nil, fmt.Errorf("authenticate: %w", err)
+	}
+	api.token = tokenResp.Access.Token.ID
+
+	region := getRegion()
+	for _, svc := range tokenResp.Access.ServiceCatalog {
+		if svc.Type != "compute" {
+			continue
+		}
+		for _, ep := range svc.Endpoints {
+			if strings.EqualFold(ep.Region, region) {
+				api.baseURL = strings.TrimSuffix(ep.PublicURL, "/")
+				return api, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("compute endpoint not found for region %s", region)
+}
+
+// Do calls a compute endpoint relative to the tenant's compute URL
+func (a *computeAPI) Do(ctx context.Context, method, path string, body, result interface{}) error {
+	return a.send(ctx, method, a.baseURL+path, body, result)
+}
+
+func (a *computeAPI) send(ctx context.Context, method, url string, body, result interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("marshaling request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if a.token != "" {
+		req.Header.Set("X-Auth-Token", a.token)
+	}
+
+	if debug {
+		fmt.Fprintf(os.Stderr, "[DEBUG] %s %s\n", method, url)
+	}
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("reading response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s %s failed with status %d: %s", method, url, resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	if result != nil && len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, result); err != nil {
+			return fmt.Errorf("parsing response: %w", err)
+		}
+	}
+	return nil
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/haung921209/nhn-cloud-cli/internal/sshkeys"
+	"github.com/haung921209/nhn-cloud-sdk-go/nhncloud/compute"
 	"github.com/haung921209/nhn-cloud-sdk-go/nhncloud/network/floatingip"
 	"github.com/haung921209/nhn-cloud-sdk-go/nhncloud/network/port"
 	"github.com/haung921209/nhn-cloud-sdk-go/nhncloud/network/securitygroup"
@@ -55,7 +56,7 @@ Automatically handles:
 
 		// 1.1 Auto-detect Username from Metadata
 		if !cmd.Flags().Changed("username") {
-			if loginUser, ok := server.Metadata["login_username"]; ok && loginUser != "" {
+			if loginUser := metadataLoginUsername(&server); loginUser != "" {
 				username = loginUser
 				fmt.Printf("Auto-detected username: %s\n", username)
 			}
@@ -79,18 +80,7 @@ Automatically handles:
 			targetPort = &portsOutput.Ports[0]
 		}
 
-		publicIP := ""
-		for _, addrs := range server.Addresses {
-			for _, addr := range addrs {
-				if addr.Type == "floating" {
-					publicIP = addr.Addr
-					break
-				}
-			}
-			if publicIP != "" {
-				break
-			}
-		}
+		publicIP := findFloatingIP(&server)
 
 		// Auto-assign Floating IP if missing
 		if publicIP == "" {
@@ -283,29 +273,12 @@ Automatically handles:
 			if server.KeyName == "" {
 				fmt.Println("Warning: Instance has no Key Pair associated. Trying standard keys...")
 			} else {
-				// Try to find key in ~/.ssh/
-				homeDir, _ := os.UserHomeDir()
-				candidates := []string{
-					filepath.Join(homeDir, ".ssh", server.KeyName+".pem"),
-					filepath.Join(homeDir, ".ssh", server.KeyName),
-					filepath.Join(homeDir, ".ssh", "id_rsa"),
-				}
-
-				for _, c := range candidates {
-					if _, err := os.Stat(c); err == nil {
-						keyPath = c
-						fmt.Printf("Found Identity File: %s\n", keyPath)
-						break
-					}
-				}
-
-				// If not found in .ssh, try to find in NHN Cloud CLI managed keys
-				if keyPath == "" {
-					manager := sshkeys.NewManager()
-					if keyInfo, err := manager.Get(server.KeyName); err == nil {
-						keyPath = keyInfo.Path
-						fmt.Printf("Found Identity File (Managed): %s\n", keyPath)
-					}
+				var managed bool
+				keyPath, managed = resolveIdentityFile(server.KeyName)
+				if keyPath != "" && managed {
+					fmt.Printf("Found Identity File (Managed): %s\n", keyPath)
+				} else if keyPath != "" {
+					fmt.Printf("Found Identity File: %s\n", keyPath)
 				}
 
 				if keyPath == "" {
@@ -345,6 +318,64 @@ Automatically handles:
 	},
 }
 
+// metadataLoginUsername returns the login user published in the image metadata
+func metadataLoginUsername(server *compute.Server) string {
+	return server.Metadata["login_username"]
+}
+
+// findFloatingIP returns the first floating IP attached to the server
+func findFloatingIP(server *compute.Server) string {
+	for _, addrs := range server.Addresses {
+		for _, addr := range addrs {
+			if addr.Type == "floating" {
+				return addr.Addr
+			}
+		}
+	}
+	return ""
+}
+
+// findFixedIP returns the first fixed (private) IPv4 address of the server
+func findFixedIP(server *compute.Server) string {
+	for _, addrs := range server.Addresses {
+		for _, addr := range addrs {
+			if addr.Type == "fixed" && addr.Version == 4 {
+				return addr.Addr
+			}
+		}
+	}
+	return ""
+}
+
+// resolveIdentityFile looks up the private key for a keypair name in ~/.ssh/,
+// then in the CLI managed key store, and finally falls back to ~/.ssh/id_rsa.
+// managed reports whether the key came from the managed store.
+func resolveIdentityFile(keyName string) (path string, managed bool) {
+	homeDir, _ := os.UserHomeDir()
+	candidates := []string{
+		filepath.Join(homeDir, ".ssh", keyName+".pem"),
+		filepath.Join(homeDir, ".ssh", keyName),
+	}
+
+	for _, c := range candidates {
+		if _, err := os.Stat(c); err == nil {
+			return c, false
+		}
+	}
+
+	manager := sshkeys.NewManager()
+	if keyInfo, err := manager.Get(keyName); err == nil {
+		return keyInfo.Path, true
+	}
+
+	fallback := filepath.Join(homeDir, ".ssh", "id_rsa")
+	if _, err := os.Stat(fallback); err == nil {
+		return fallback, false
+	}
+
+	return "", false
+}
+
 func getPublicIP() string {
 	client := http.Client{
 		Timeout: 2 * time.Second, // Short timeout
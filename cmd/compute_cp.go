@@ -0,0 +1,205 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/haung921209/nhn-cloud-cli/internal/sshclient"
+	"github.com/haung921209/nhn-cloud-sdk-go/nhncloud/compute"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	computeCmd.AddCommand(computeCpCmd)
+
+	computeCpCmd.Flags().BoolP("recursive", "r", false, "Copy directories recursively")
+	computeCpCmd.Flags().StringP("username", "l", "centos", "SSH username (default: centos, or auto-detected from metadata)")
+	computeCpCmd.Flags().StringP("identity-file", "i", "", "Identity file (private key) path")
+	computeCpCmd.Flags().Int("port", 22, "SSH port")
+	computeCpCmd.Flags().Int("concurrency", 10, "Maximum number of instances to copy to/from at once")
+	computeCpCmd.Flags().Bool("private-ip", false, "Fall back to the fixed IP when an instance has no floating IP")
+}
+
+var computeCpCmd = &cobra.Command{
+	Use:   "cp <source> <destination>",
+	Short: "Copy files to or from compute instances",
+	Long: `Copies files between the local machine and compute instances over the
+built-in SSH client using the SCP protocol (no local 'scp' binary required).
+
+Exactly one of source or destination must be remote, written as
+<instance>:<path> where <instance> is an instance ID, an instance name,
+or a glob matching several instance names. The floating IP, login user
+and identity file are resolved the same way as 'compute connect'.
+
+When downloading from several instances, the destination must be an
+existing directory; each instance's files are placed in a subdirectory
+named after the instance.
+
+Examples:
+  nhncloud compute cp ./app.conf web-1:/tmp/app.conf
+  nhncloud compute cp -r ./config 'web-*':/opt/app/
+  nhncloud compute cp web-1:/var/log/messages ./
+  nhncloud compute cp -r 'web-*':/var/log/nginx ./logs/`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		srcInstance, srcPath, srcRemote := parseRemoteSpec(args[0])
+		dstInstance, dstPath, dstRemote := parseRemoteSpec(args[1])
+
+		if srcRemote == dstRemote {
+			exitWithError("exactly one of source or destination must be <instance>:<path>", nil)
+		}
+
+		recursive, _ := cmd.Flags().GetBool("recursive")
+		concurrency, _ := cmd.Flags().GetInt("concurrency")
+		if concurrency < 1 {
+			concurrency = 1
+		}
+
+		instanceSpec := srcInstance
+		upload := dstRemote
+		if upload {
+			instanceSpec = dstInstance
+			if _, err := os.Stat(srcPath); err != nil {
+				exitWithError("Failed to read source", err)
+			}
+		}
+
+		ctx := context.Background()
+		servers, err := selectInstancesBySpec(ctx, getComputeClient(), instanceSpec)
+		if err != nil {
+			exitWithError("Failed to select instances", err)
+		}
+
+		if !upload && len(servers) > 1 {
+			if info, err := os.Stat(dstPath); err != nil || !info.IsDir() {
+				exitWithError(fmt.Sprintf("destination %q must be an existing directory when copying from %d instances", dstPath, len(servers)), nil)
+			}
+		}
+
+		opts := sshTargetOptionsFromFlags(cmd)
+		results := make([]computeCpResult, len(servers))
+
+		var wg sync.WaitGroup
+		sem := make(chan struct{}, concurrency)
+		for i := range servers {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+
+				server := &servers[i]
+				if upload {
+					results[i] = runComputeCp(server, opts, func(c *sshclient.Client) error {
+						return c.Upload(srcPath, dstPath, recursive)
+					})
+					results[i].Source = srcPath
+					results[i].Destination = server.Name + ":" + dstPath
+					return
+				}
+
+				localPath := dstPath
+				if len(servers) > 1 {
+					localPath = filepath.Join(dstPath, server.Name)
+					if err := os.MkdirAll(localPath, 0755); err != nil {
+						results[i] = computeCpResult{InstanceID: server.ID, Name: server.Name, Error: err.Error()}
+						return
+					}
+				}
+				results[i] = runComputeCp(server, opts, func(c *sshclient.Client) error {
+					return c.Download(srcPath, localPath, recursive)
+				})
+				results[i].Source = server.Name + ":" + srcPath
+				results[i].Destination = localPath
+			}(i)
+		}
+		wg.Wait()
+
+		failed := 0
+		for _, r := range results {
+			if r.Error != "" {
+				failed++
+			}
+		}
+
+		if output == "json" {
+			printJSON(results)
+		} else {
+			for _, r := range results {
+				if r.Error != "" {
+					fmt.Fprintf(os.Stderr, "[%s] failed: %s\n", r.Name, r.Error)
+				} else {
+					fmt.Printf("[%s] copied %s -> %s\n", r.Name, r.Source, r.Destination)
+				}
+			}
+		}
+
+		if failed > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+// computeCpResult is the per-instance outcome of 'compute cp'
+type computeCpResult struct {
+	InstanceID  string `json:"instanceId"`
+	Name        string `json:"name"`
+	Host        string `json:"host,omitempty"`
+	Source      string `json:"source"`
+	Destination string `json:"destination"`
+	Error       string `json:"error,omitempty"`
+}
+
+func runComputeCp(server *compute.Server, opts sshTargetOptions, copyFn func(*sshclient.Client) error) computeCpResult {
+	result := computeCpResult{InstanceID: server.ID, Name: server.Name}
+
+	target, err := resolveSSHTarget(server, opts)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Host = target.Host
+
+	client, err := sshclient.Dial(target.Config())
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	defer client.Close()
+
+	if err := copyFn(client); err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// parseRemoteSpec splits "<instance>:<path>". Arguments whose prefix contains
+// a path separator or is a single drive letter are treated as local paths.
+func parseRemoteSpec(arg string) (instance, remotePath string, remote bool) {
+	idx := strings.Index(arg, ":")
+	if idx <= 1 {
+		return "", arg, false
+	}
+	prefix := arg[:idx]
+	if strings.ContainsAny(prefix, `/\`) {
+		return "", arg, false
+	}
+
+	remotePath = arg[idx+1:]
+	if remotePath == "" {
+		remotePath = "."
+	}
+	return prefix, remotePath, true
+}
+
+// selectInstancesBySpec resolves an instance ID, exact name or name glob
+func selectInstancesBySpec(ctx context.Context, client *compute.Client, spec string) ([]compute.Server, error) {
+	if len(spec) == 36 && spec[8] == '-' && spec[13] == '-' {
+		return selectInstances(ctx, client, []string{spec}, "")
+	}
+	return selectInstances(ctx, client, nil, spec)
+}
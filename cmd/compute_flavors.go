@@ -17,6 +17,14 @@ var computeDescribeFlavorsCmd = &cobra.Command{
 	Use:     "describe-flavors",
 	Aliases: []string{"flavors"},
 	Short:   "List available compute flavors",
+	Long: `Lists available compute flavors.
+
+Filters (--filter, repeatable):
+  id, name, vcpus, ram (MB), disk (GB)
+
+Examples:
+  nhncloud compute describe-flavors --filter vcpus>=4 --filter ram<=16384
+  nhncloud compute describe-flavors --filter 'name~^m2\.'`,
 	Run: func(cmd *cobra.Command, args []string) {
 		client := getComputeClient()
 		ctx := context.Background()
@@ -25,6 +33,7 @@ var computeDescribeFlavorsCmd = &cobra.Command{
 		if err != nil {
 			exitWithError("Failed to list flavors", err)
 		}
+		result.Flavors = filterItems(result.Flavors, nil)
 
 		if output == "json" {
 			printJSON(result)
@@ -19,16 +19,30 @@ var computeDescribeImagesCmd = &cobra.Command{
 	Use:     "describe-images",
 	Aliases: []string{"images"},
 	Short:   "List available compute images",
-	Long:    "List available images for compute instances. This command uses the Glance Image API.",
+	Long: `List available images for compute instances. This command uses the Glance Image API.
+
+Filters (--filter, repeatable):
+  id, name, status, visibility, os, os-type, size, min_disk, created, tags
+  and any other JSON field of the image. status and visibility "=" filters
+  are also sent to the Image API to reduce the response; all other filters,
+  including name, os and os-type, are applied by the CLI.
+
+Examples:
+  nhncloud compute describe-images --filter visibility=public --filter 'os~(?i)ubuntu'
+  nhncloud compute describe-images --filter created>2026-01-01`,
 	Run: func(cmd *cobra.Command, args []string) {
 		creds := credentials.NewStaticIdentity(getUsername(), getPassword(), getTenantID())
 		client := image.NewClient(getRegion(), creds, nil, debug)
 		ctx := context.Background()
 
-		result, err := client.ListImages(ctx, nil)
+		input := &image.ListImagesInput{}
+		applyImageFilters(input)
+
+		result, err := client.ListImages(ctx, input)
 		if err != nil {
 			exitWithError("Failed to list images", err)
 		}
+		result.Images = filterItems(result.Images, imageFilterAliases)
 
 		if output == "json" {
 			printJSON(result)
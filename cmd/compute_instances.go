@@ -2,10 +2,15 @@ package cmd
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 	"text/tabwriter"
+	"time"
 
+	"github.com/haung921209/nhn-cloud-cli/internal/launchtemplates"
 	"github.com/haung921209/nhn-cloud-sdk-go/nhncloud/compute"
 	"github.com/haung921209/nhn-cloud-sdk-go/nhncloud/network/vpc"
 	"github.com/spf13/cobra"
@@ -22,18 +27,21 @@ func init() {
 	computeDescribeInstancesCmd.Flags().String("instance-id", "", "ID of the instance to describe")
 
 	computeCreateInstanceCmd.Flags().String("name", "", "Instance name (required)")
-	computeCreateInstanceCmd.Flags().String("image-id", "", "Image ID (required)")
-	computeCreateInstanceCmd.Flags().String("flavor-id", "", "Flavor ID (required)")
-	computeCreateInstanceCmd.Flags().String("subnet-id", "", "Network/Subnet ID (required)")
+	computeCreateInstanceCmd.Flags().String("image-id", "", "Image ID (required unless set by --launch-template)")
+	computeCreateInstanceCmd.Flags().String("flavor-id", "", "Flavor ID (required unless set by --launch-template)")
+	computeCreateInstanceCmd.Flags().String("subnet-id", "", "Network/Subnet ID (required unless set by --launch-template)")
 	computeCreateInstanceCmd.Flags().String("key-name", "", "SSH keypair name")
-	computeCreateInstanceCmd.Flags().String("security-group-ids", "", "Security group name (comma separated)") // AWS uses IDs, NHN uses names typically? Old cmd used name.
+	computeCreateInstanceCmd.Flags().StringSlice("security-group-ids", nil, "Security group names or IDs (comma separated)")
 	computeCreateInstanceCmd.Flags().String("availability-zone", "", "Availability zone")
 	computeCreateInstanceCmd.Flags().Int("block-device-mapping-v2-boot-volume-size", 20, "Boot volume size in GB")
-	computeCreateInstanceCmd.Flags().Bool("wait", false, "Wait for instance to be available") // Future
+	computeCreateInstanceCmd.Flags().String("user-data", "", "User data script or cloud-init config (literal, or file://path)")
+	computeCreateInstanceCmd.Flags().StringToString("metadata", nil, "Instance metadata as key=value (repeatable)")
+	computeCreateInstanceCmd.Flags().StringArray("block-device", nil, "Additional volume as size=<GB>[,type=<volume type>][,delete-on-termination=false] (repeatable)")
+	computeCreateInstanceCmd.Flags().Int("count", 1, "Number of instances to create ({n} in --name is replaced by the index)")
+	computeCreateInstanceCmd.Flags().Bool("wait", false, "Wait for the instances to become ACTIVE and print their IPs")
+	computeCreateInstanceCmd.Flags().String("wait-timeout", "15m", "Maximum time to wait with --wait (Go duration)")
+	computeCreateInstanceCmd.Flags().String("launch-template", "", "Launch template to start from (name or name:vN); flags override its values")
 	computeCreateInstanceCmd.MarkFlagRequired("name")
-	computeCreateInstanceCmd.MarkFlagRequired("image-id")
-	computeCreateInstanceCmd.MarkFlagRequired("flavor-id")
-	computeCreateInstanceCmd.MarkFlagRequired("subnet-id")
 
 	computeDeleteInstanceCmd.Flags().String("instance-id", "", "Instance ID (required)")
 	computeDeleteInstanceCmd.MarkFlagRequired("instance-id")
@@ -49,9 +57,29 @@ func init() {
 	computeRebootInstancesCmd.MarkFlagRequired("instance-id")
 }
 
+// computeInstanceFilterAliases are the short --filter fields of describe-instances
+var computeInstanceFilterAliases = filterAliases{
+	"az":             "OS-EXT-AZ:availability_zone",
+	"key":            "key_name",
+	"image":          "image.id",
+	"flavor":         "flavor.id",
+	"ip":             "addresses.*.addr",
+	"security-group": "security_groups.name",
+}
+
 var computeDescribeInstancesCmd = &cobra.Command{
 	Use:   "describe-instances",
 	Short: "Describe compute instances",
+	Long: `Describes a compute instance, or lists all instances.
+
+Filters (--filter, repeatable):
+  id, name, status, key, az, image, flavor, ip, security-group, created,
+  metadata.<key> and any other JSON field of the server
+
+Examples:
+  nhncloud compute describe-instances --filter status=ACTIVE --filter 'name~^web-'
+  nhncloud compute describe-instances --filter metadata.role=db
+  nhncloud compute describe-instances --filter ip~^192\.168\. --filter created<2026-01-01`,
 	Run: func(cmd *cobra.Command, args []string) {
 		client := getComputeClient()
 		ctx := context.Background()
@@ -88,6 +116,7 @@ var computeDescribeInstancesCmd = &cobra.Command{
 			if err != nil {
 				exitWithError("Failed to list instances", err)
 			}
+			result.Servers = filterItems(result.Servers, computeInstanceFilterAliases)
 			if output == "json" {
 				printJSON(result)
 				return
@@ -106,6 +135,29 @@ var computeDescribeInstancesCmd = &cobra.Command{
 var computeCreateInstanceCmd = &cobra.Command{
 	Use:   "create-instance",
 	Short: "Create a new compute instance",
+	Long: `Creates one or more compute instances.
+
+User data is passed to cloud-init; prefix a path with file:// to read it
+from a file. Additional volumes are attached with --block-device, which
+can be repeated. With --count, "{n}" in --name is replaced by the
+instance index (1-based); if the name has no "{n}", "-{n}" is appended.
+
+With --wait, the command polls until every instance is ACTIVE and prints
+their fixed and floating IPs.
+
+With --launch-template, settings come from a stored launch template (see
+'compute create-launch-template'); any flag given explicitly overrides the
+template value, and --metadata entries are merged over the template's.
+
+Examples:
+  nhncloud compute create-instance --name web --image-id <image> --flavor-id <flavor> \
+    --subnet-id <subnet> --key-name my-key --security-group-ids default,web \
+    --user-data file://cloud-init.yaml --metadata role=web --wait
+
+  nhncloud compute create-instance --name web-{n} --count 3 --image-id <image> --flavor-id <flavor> \
+    --subnet-id <subnet> --key-name my-key --block-device "size=100,type=General SSD"
+
+  nhncloud compute create-instance --launch-template web:v3 --name web-1 --flavor-id <flavor>`,
 	Run: func(cmd *cobra.Command, args []string) {
 		client := getComputeClient()
 		ctx := context.Background()
@@ -117,7 +169,84 @@ var computeCreateInstanceCmd = &cobra.Command{
 		keyName, _ := cmd.Flags().GetString("key-name")
 		az, _ := cmd.Flags().GetString("availability-zone")
 		volumeSize, _ := cmd.Flags().GetInt("block-device-mapping-v2-boot-volume-size")
-		// sgIDs, _ := cmd.Flags().GetString("security-group-ids") // TODO: Implement SG support properly (old cmd used name)
+		sgIDs, _ := cmd.Flags().GetStringSlice("security-group-ids")
+		userDataArg, _ := cmd.Flags().GetString("user-data")
+		metadata, _ := cmd.Flags().GetStringToString("metadata")
+		blockDevices, _ := cmd.Flags().GetStringArray("block-device")
+		count, _ := cmd.Flags().GetInt("count")
+		wait, _ := cmd.Flags().GetBool("wait")
+		waitTimeoutStr, _ := cmd.Flags().GetString("wait-timeout")
+
+		if templateRef, _ := cmd.Flags().GetString("launch-template"); templateRef != "" {
+			tmpl, err := launchtemplates.NewStore().Get(templateRef)
+			if err != nil {
+				exitWithError("Failed to load launch template", err)
+			}
+			spec := tmpl.Spec
+			flags := cmd.Flags()
+			if !flags.Changed("image-id") {
+				imageID = spec.ImageID
+			}
+			if !flags.Changed("flavor-id") {
+				flavorID = spec.FlavorID
+			}
+			if !flags.Changed("subnet-id") {
+				subnetID = spec.SubnetID
+			}
+			if !flags.Changed("key-name") {
+				keyName = spec.KeyName
+			}
+			if !flags.Changed("availability-zone") {
+				az = spec.AvailabilityZone
+			}
+			if !flags.Changed("block-device-mapping-v2-boot-volume-size") && spec.BootVolumeSize > 0 {
+				volumeSize = spec.BootVolumeSize
+			}
+			if !flags.Changed("security-group-ids") {
+				sgIDs = spec.SecurityGroupIDs
+			}
+			if !flags.Changed("user-data") {
+				userDataArg = spec.UserData
+			}
+			if !flags.Changed("block-device") {
+				blockDevices = spec.BlockDevices
+			}
+			merged := map[string]string{}
+			for k, v := range spec.Metadata {
+				merged[k] = v
+			}
+			for k, v := range metadata {
+				merged[k] = v
+			}
+			metadata = merged
+
+			if output != "json" {
+				fmt.Printf("Using launch template %s version %d\n", tmpl.Name, tmpl.Version)
+			}
+		}
+
+		for flag, value := range map[string]string{"image-id": imageID, "flavor-id": flavorID, "subnet-id": subnetID} {
+			if value == "" {
+				exitWithError(fmt.Sprintf("--%s is required (or set it in the launch template)", flag), nil)
+			}
+		}
+		if count < 1 {
+			exitWithError("--count must be at least 1", nil)
+		}
+		waitTimeout, err := time.ParseDuration(waitTimeoutStr)
+		if err != nil {
+			exitWithError(fmt.Sprintf("invalid --wait-timeout %q", waitTimeoutStr), err)
+		}
+
+		userData, err := loadUserData(userDataArg)
+		if err != nil {
+			exitWithError("Failed to load user data", err)
+		}
+
+		extraVolumes, err := parseBlockDevices(blockDevices)
+		if err != nil {
+			exitWithError("Invalid --block-device", err)
+		}
 
 		// Resolve VPC ID from subnet ID
 		vpcClient := vpc.NewClient(getRegion(), getIdentityCreds(), nil, debug)
@@ -136,6 +265,13 @@ var computeCreateInstanceCmd = &cobra.Command{
 			Networks: []compute.ServerNetwork{
 				{UUID: vpcID, Subnet: subnetID},
 			},
+			Metadata: metadata,
+			UserData: userData,
+		}
+
+		// Nova resolves security_groups entries by name or ID
+		for _, sg := range sgIDs {
+			input.SecurityGroups = append(input.SecurityGroups, compute.SecurityGroup{Name: strings.TrimSpace(sg)})
 		}
 
 		if volumeSize > 0 {
@@ -152,18 +288,65 @@ var computeCreateInstanceCmd = &cobra.Command{
 		} else {
 			input.ImageRef = imageID
 		}
+		input.BlockDeviceMapping = append(input.BlockDeviceMapping, extraVolumes...)
 
-		result, err := client.CreateServer(ctx, input)
-		if err != nil {
-			exitWithError("Failed to create instance", err)
+		var servers []compute.Server
+		for i := 1; i <= count; i++ {
+			input.Name = instanceNameForIndex(name, i, count)
+
+			result, err := client.CreateServer(ctx, input)
+			if err != nil {
+				for _, s := range servers {
+					fmt.Fprintf(os.Stderr, "Created before failure: %s (%s)\n", s.ID, s.Name)
+				}
+				exitWithError(fmt.Sprintf("Failed to create instance %s", input.Name), err)
+			}
+			// The create response carries no name; keep the requested one
+			if result.Server.Name == "" {
+				result.Server.Name = input.Name
+			}
+			servers = append(servers, result.Server)
+
+			if output != "json" {
+				fmt.Printf("Instance created successfully: %s (%s)\n", result.Server.ID, result.Server.Name)
+			}
+		}
+
+		if wait {
+			if output != "json" {
+				fmt.Printf("Waiting for %d instance(s) to become ACTIVE...\n", len(servers))
+			}
+			waitCtx, cancel := context.WithTimeout(ctx, waitTimeout)
+			defer cancel()
+
+			for i := range servers {
+				server, err := waitForServerStatus(waitCtx, client, servers[i].ID, "ACTIVE", 5*time.Second)
+				if err != nil {
+					exitWithError(fmt.Sprintf("Instance %s did not become ACTIVE", servers[i].Name), err)
+				}
+				servers[i] = *server
+			}
 		}
 
 		if output == "json" {
-			printJSON(result)
+			if len(servers) == 1 {
+				printJSON(compute.CreateServerOutput{Server: servers[0]})
+			} else {
+				printJSON(map[string]interface{}{"servers": servers})
+			}
 			return
 		}
 
-		fmt.Printf("Instance created successfully: %s (%s)\n", result.Server.ID, result.Server.Name)
+		if wait {
+			fmt.Println()
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "ID\tNAME\tSTATUS\tFIXED IP\tFLOATING IP")
+			for i := range servers {
+				s := &servers[i]
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", s.ID, s.Name, s.Status, findFixedIP(s), findFloatingIP(s))
+			}
+			w.Flush()
+		}
 	},
 }
 
@@ -227,3 +410,117 @@ var computeRebootInstancesCmd = &cobra.Command{
 		fmt.Printf("Instance %s rebooted\n", instanceID)
 	},
 }
+
+// ============================================================================
+// Create Instance Helpers
+// ============================================================================
+
+// maxUserDataSize is the Nova limit for user_data before base64 encoding
+const maxUserDataSize = 65535
+
+// loadUserData reads user data from a literal or a file:// path and returns
+// it base64 encoded, as Nova expects
+func loadUserData(arg string) (string, error) {
+	if arg == "" {
+		return "", nil
+	}
+
+	data := []byte(arg)
+	if strings.HasPrefix(arg, "file://") {
+		var err error
+		data, err = os.ReadFile(strings.TrimPrefix(arg, "file://"))
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if len(data) > maxUserDataSize {
+		return "", fmt.Errorf("user data is %d bytes; the limit is %d", len(data), maxUserDataSize)
+	}
+	return base64.StdEncoding.EncodeToString(data), nil
+}
+
+// parseBlockDevices converts --block-device specs into blank volumes
+// attached after the boot volume
+func parseBlockDevices(specs []string) ([]compute.BlockDeviceMapping, error) {
+	var mappings []compute.BlockDeviceMapping
+	for _, spec := range specs {
+		bdm := compute.BlockDeviceMapping{
+			BootIndex:           -1,
+			SourceType:          "blank",
+			DestinationType:     "volume",
+			DeleteOnTermination: true,
+		}
+
+		for _, part := range strings.Split(spec, ",") {
+			key, value, ok := strings.Cut(part, "=")
+			if !ok {
+				return nil, fmt.Errorf("%q: expected key=value, got %q", spec, part)
+			}
+			key = strings.TrimSpace(key)
+			value = strings.TrimSpace(value)
+
+			switch key {
+			case "size":
+				size, err := strconv.Atoi(value)
+				if err != nil || size <= 0 {
+					return nil, fmt.Errorf("%q: invalid size %q", spec, value)
+				}
+				bdm.VolumeSize = size
+			case "type":
+				bdm.VolumeType = value
+			case "delete-on-termination":
+				del, err := strconv.ParseBool(value)
+				if err != nil {
+					return nil, fmt.Errorf("%q: invalid delete-on-termination %q", spec, value)
+				}
+				bdm.DeleteOnTermination = del
+			default:
+				return nil, fmt.Errorf("%q: unknown key %q (expected size, type, delete-on-termination)", spec, key)
+			}
+		}
+
+		if bdm.VolumeSize == 0 {
+			return nil, fmt.Errorf("%q: size is required", spec)
+		}
+		mappings = append(mappings, bdm)
+	}
+	return mappings, nil
+}
+
+// instanceNameForIndex expands "{n}" in name for multi-instance creation
+func instanceNameForIndex(name string, index, count int) string {
+	if strings.Contains(name, "{n}") {
+		return strings.ReplaceAll(name, "{n}", strconv.Itoa(index))
+	}
+	if count > 1 {
+		return fmt.Sprintf("%s-%d", name, index)
+	}
+	return name
+}
+
+// waitForServerStatus polls an instance until it reaches want, failing
+// early if it enters ERROR or ctx expires
+func waitForServerStatus(ctx context.Context, client *compute.Client, instanceID, want string, interval time.Duration) (*compute.Server, error) {
+	for {
+		result, err := client.GetServer(ctx, instanceID)
+		if err == nil {
+			status := strings.ToUpper(result.Server.Status)
+			if status == want {
+				return &result.Server, nil
+			}
+			if status == "ERROR" {
+				return nil, fmt.Errorf("instance %s entered ERROR state", instanceID)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			if err != nil {
+				return nil, fmt.Errorf("%w (last error: %v)", ctx.Err(), err)
+			}
+			return nil, fmt.Errorf("timed out waiting for %s: %w", want, ctx.Err())
+		case <-time.After(interval):
+		}
+	}
+}
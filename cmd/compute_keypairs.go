@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"text/tabwriter"
 
+	"github.com/haung921209/nhn-cloud-cli/internal/sshkeys"
 	"github.com/haung921209/nhn-cloud-sdk-go/nhncloud/compute"
 	"github.com/spf13/cobra"
 )
@@ -18,8 +20,15 @@ func init() {
 
 	computeCreateKeyPairCmd.Flags().String("key-name", "", "Keypair name (required)")
 	computeCreateKeyPairCmd.Flags().String("public-key", "", "Public key content (optional)")
+	computeCreateKeyPairCmd.Flags().Bool("no-store", false, "Print the generated private key instead of saving it to the SSH key store")
 	computeCreateKeyPairCmd.MarkFlagRequired("key-name")
 
+	computeCmd.AddCommand(computeImportKeyPairCmd)
+	computeImportKeyPairCmd.Flags().String("key-name", "", "Keypair name to register (required)")
+	computeImportKeyPairCmd.Flags().String("ssh-key", "", "SSH key store entry to upload (default: --key-name)")
+	computeImportKeyPairCmd.Flags().String("generate", "", "Generate the store key first if it does not exist: ed25519 or rsa")
+	computeImportKeyPairCmd.MarkFlagRequired("key-name")
+
 	computeDeleteKeyPairCmd.Flags().String("key-name", "", "Keypair name (required)")
 	computeDeleteKeyPairCmd.MarkFlagRequired("key-name")
 
@@ -34,6 +43,13 @@ var computeDescribeKeyPairsCmd = &cobra.Command{
 	Use:     "describe-key-pairs",
 	Aliases: []string{"keypairs"},
 	Short:   "List SSH keypairs",
+	Long: `Lists SSH keypairs.
+
+Filters (--filter, repeatable):
+  name, fingerprint
+
+Examples:
+  nhncloud compute describe-key-pairs --filter 'name~^deploy-'`,
 	Run: func(cmd *cobra.Command, args []string) {
 		client := getComputeClient()
 		ctx := context.Background()
@@ -42,6 +58,10 @@ var computeDescribeKeyPairsCmd = &cobra.Command{
 		if err != nil {
 			exitWithError("Failed to list keypairs", err)
 		}
+		result.KeyPairs = filterItems(result.KeyPairs, filterAliases{
+			"name":        "keypair.name",
+			"fingerprint": "keypair.fingerprint",
+		})
 
 		if output == "json" {
 			printJSON(result)
@@ -61,12 +81,21 @@ var computeCreateKeyPairCmd = &cobra.Command{
 	Use:     "create-key-pair",
 	Aliases: []string{"keypair-create"},
 	Short:   "Create a new SSH keypair",
+	Long: `Creates a keypair. When the private key is generated by the service, it is
+saved to the SSH key store (~/.nhncloud/ssh-keys, mode 0600) under the
+keypair name, where 'compute connect', 'compute ssh-exec' and 'compute cp' find
+it automatically. Use --no-store to print it instead.
+
+Examples:
+  nhncloud compute create-key-pair --key-name my-key
+  nhncloud compute create-key-pair --key-name my-key --no-store > my-key.pem`,
 	Run: func(cmd *cobra.Command, args []string) {
 		client := getComputeClient()
 		ctx := context.Background()
 
 		name, _ := cmd.Flags().GetString("key-name")
 		publicKey, _ := cmd.Flags().GetString("public-key")
+		noStore, _ := cmd.Flags().GetBool("no-store")
 
 		input := &compute.CreateKeyPairInput{
 			Name:      name,
@@ -78,6 +107,19 @@ var computeCreateKeyPairCmd = &cobra.Command{
 			exitWithError("Failed to create keypair", err)
 		}
 
+		var stored *sshkeys.KeyInfo
+		if result.KeyPair.PrivateKey != "" && !noStore {
+			manager := sshkeys.NewManager()
+			if _, err := manager.Get(name); err == nil {
+				fmt.Fprintf(os.Stderr, "Warning: replacing SSH key store entry '%s'\n", name)
+			}
+			stored, err = manager.ImportData(name, []byte(result.KeyPair.PrivateKey))
+			if err != nil {
+				// The key cannot be fetched again, so fall back to printing it
+				fmt.Fprintf(os.Stderr, "Warning: failed to save private key to the SSH key store: %v\n", err)
+			}
+		}
+
 		if output == "json" {
 			printJSON(result)
 			return
@@ -85,12 +127,78 @@ var computeCreateKeyPairCmd = &cobra.Command{
 
 		fmt.Printf("Keypair created: %s\n", result.KeyPair.Name)
 		fmt.Printf("Fingerprint: %s\n", result.KeyPair.Fingerprint)
-		if result.KeyPair.PrivateKey != "" {
+		if stored != nil {
+			fmt.Printf("Private key saved to %s\n", stored.Path)
+		} else if result.KeyPair.PrivateKey != "" {
 			fmt.Printf("\nPrivate Key (save this - it won't be shown again):\n%s\n", result.KeyPair.PrivateKey)
 		}
 	},
 }
 
+var computeImportKeyPairCmd = &cobra.Command{
+	Use:   "import-key-pair",
+	Short: "Register a public key from the SSH key store as a keypair",
+	Long: `Registers the public key of an SSH key store entry as a compute keypair.
+The private key never leaves the local machine.
+
+With --generate, a new ed25519 or RSA key is created in the store first if
+the entry does not exist yet.
+
+Examples:
+  nhncloud compute import-key-pair --key-name deploy --generate ed25519
+  nhncloud compute import-key-pair --key-name legacy --ssh-key my-rsa-key`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client := getComputeClient()
+		ctx := context.Background()
+
+		name, _ := cmd.Flags().GetString("key-name")
+		storeKey, _ := cmd.Flags().GetString("ssh-key")
+		generate, _ := cmd.Flags().GetString("generate")
+		if storeKey == "" {
+			storeKey = name
+		}
+
+		manager := sshkeys.NewManager()
+		generated := false
+		if _, err := manager.Get(storeKey); err != nil {
+			if generate == "" {
+				exitWithError(fmt.Sprintf("SSH key '%s' not found in the store (use --generate ed25519|rsa or 'ssh-keys import-key')", storeKey), nil)
+			}
+			if _, err := manager.Generate(storeKey, generate); err != nil {
+				exitWithError("Failed to generate SSH key", err)
+			}
+			generated = true
+		}
+
+		publicKey, err := manager.PublicKey(storeKey)
+		if err != nil {
+			exitWithError("Failed to read public key", err)
+		}
+
+		result, err := client.CreateKeyPair(ctx, &compute.CreateKeyPairInput{
+			Name:      name,
+			PublicKey: publicKey,
+		})
+		if err != nil {
+			if generated {
+				manager.Remove(storeKey)
+			}
+			exitWithError("Failed to import keypair", err)
+		}
+
+		if output == "json" {
+			printJSON(result)
+			return
+		}
+
+		if generated {
+			fmt.Printf("Generated %s key '%s' in the SSH key store\n", strings.ToLower(generate), storeKey)
+		}
+		fmt.Printf("Keypair imported: %s\n", result.KeyPair.Name)
+		fmt.Printf("Fingerprint: %s\n", result.KeyPair.Fingerprint)
+	},
+}
+
 var computeDeleteKeyPairCmd = &cobra.Command{
 	Use:     "delete-key-pair",
 	Aliases: []string{"keypair-delete"},
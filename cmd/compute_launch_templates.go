@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/haung921209/nhn-cloud-cli/internal/launchtemplates"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+func init() {
+	computeCmd.AddCommand(computeCreateLaunchTemplateCmd)
+	computeCmd.AddCommand(computeDescribeLaunchTemplatesCmd)
+	computeCmd.AddCommand(computeDeleteLaunchTemplateCmd)
+
+	computeCreateLaunchTemplateCmd.Flags().String("name", "", "Launch template name (required)")
+	computeCreateLaunchTemplateCmd.Flags().StringP("file", "f", "", "Template spec YAML file (required)")
+	computeCreateLaunchTemplateCmd.Flags().String("description", "", "Description of this version")
+	computeCreateLaunchTemplateCmd.MarkFlagRequired("name")
+	computeCreateLaunchTemplateCmd.MarkFlagRequired("file")
+
+	computeDescribeLaunchTemplatesCmd.Flags().String("name", "", "Show the versions of this template (name or name:vN for a single version)")
+
+	computeDeleteLaunchTemplateCmd.Flags().String("name", "", "Launch template name (required)")
+	computeDeleteLaunchTemplateCmd.Flags().Int("version", 0, "Delete only this version")
+	computeDeleteLaunchTemplateCmd.MarkFlagRequired("name")
+}
+
+var computeCreateLaunchTemplateCmd = &cobra.Command{
+	Use:   "create-launch-template",
+	Short: "Create a launch template or a new version of one",
+	Long: `Stores a reusable set of create-instance settings under
+~/.nhncloud/launch-templates. Creating a template that already exists adds
+a new version; existing versions are never modified, and version numbers
+are never reused, even after a version or the whole template is deleted.
+
+The YAML keys match the create-instance flags:
+
+  image-id: <image-uuid>
+  flavor-id: <flavor-uuid>
+  subnet-id: <subnet-uuid>
+  key-name: my-key
+  security-group-ids: [default, web]
+  availability-zone: kr-pub-a
+  boot-volume-size: 50
+  user-data: file://cloud-init.yaml   # embedded into the template
+  metadata:
+    role: web
+  block-devices:
+    - size=100,type=General SSD
+
+Examples:
+  nhncloud compute create-launch-template --name web -f web.yaml --description "nginx base"
+  nhncloud compute create-instance --launch-template web --name web-1
+  nhncloud compute create-instance --launch-template web:v2 --name web-{n} --count 3`,
+	Run: func(cmd *cobra.Command, args []string) {
+		name, _ := cmd.Flags().GetString("name")
+		file, _ := cmd.Flags().GetString("file")
+		description, _ := cmd.Flags().GetString("description")
+
+		spec, err := launchtemplates.LoadSpecFile(file)
+		if err != nil {
+			exitWithError("Failed to load template", err)
+		}
+		if _, err := parseBlockDevices(spec.BlockDevices); err != nil {
+			exitWithError("Invalid block-devices in template", err)
+		}
+
+		v, err := launchtemplates.NewStore().Create(name, description, *spec)
+		if err != nil {
+			exitWithError("Failed to create launch template", err)
+		}
+
+		if output == "json" {
+			printJSON(v)
+			return
+		}
+		fmt.Printf("Launch template %s version %d created\n", v.Name, v.Version)
+	},
+}
+
+var computeDescribeLaunchTemplatesCmd = &cobra.Command{
+	Use:   "describe-launch-templates",
+	Short: "List launch templates or show template versions",
+	Run: func(cmd *cobra.Command, args []string) {
+		store := launchtemplates.NewStore()
+		name, _ := cmd.Flags().GetString("name")
+
+		if name == "" {
+			summaries, err := store.List()
+			if err != nil {
+				exitWithError("Failed to list launch templates", err)
+			}
+			if output == "json" {
+				printJSON(summaries)
+				return
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "NAME\tLATEST\tVERSIONS\tUPDATED")
+			for _, s := range summaries {
+				fmt.Fprintf(w, "%s\tv%d\t%d\t%s\n", s.Name, s.LatestVersion, s.Versions, s.UpdatedAt.Format("2006-01-02 15:04:05"))
+			}
+			w.Flush()
+			return
+		}
+
+		// A single version is printed as the stored YAML
+		if strings.Contains(name, ":") {
+			v, err := store.Get(name)
+			if err != nil {
+				exitWithError("Failed to get launch template", err)
+			}
+			if output == "json" {
+				printJSON(v)
+				return
+			}
+			data, _ := yaml.Marshal(v)
+			fmt.Print(string(data))
+			return
+		}
+
+		versions, err := store.Versions(name)
+		if err != nil {
+			exitWithError("Failed to get launch template", err)
+		}
+		if output == "json" {
+			printJSON(versions)
+			return
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tCREATED\tIMAGE\tFLAVOR\tSUBNET\tDESCRIPTION")
+		for _, v := range versions {
+			fmt.Fprintf(w, "v%d\t%s\t%s\t%s\t%s\t%s\n", v.Version, v.CreatedAt.Format("2006-01-02 15:04:05"),
+				v.Spec.ImageID, v.Spec.FlavorID, v.Spec.SubnetID, v.Description)
+		}
+		w.Flush()
+	},
+}
+
+var computeDeleteLaunchTemplateCmd = &cobra.Command{
+	Use:   "delete-launch-template",
+	Short: "Delete a launch template or one of its versions",
+	Run: func(cmd *cobra.Command, args []string) {
+		name, _ := cmd.Flags().GetString("name")
+		version, _ := cmd.Flags().GetInt("version")
+
+		if err := launchtemplates.NewStore().Delete(name, version); err != nil {
+			exitWithError("Failed to delete launch template", err)
+		}
+		if version > 0 {
+			fmt.Printf("Launch template %s version %d deleted\n", name, version)
+		} else {
+			fmt.Printf("Launch template %s deleted\n", name)
+		}
+	},
+}
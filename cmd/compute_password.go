@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh"
+)
+
+func init() {
+	computeCmd.AddCommand(computeGetPasswordCmd)
+
+	computeGetPasswordCmd.Flags().String("instance-id", "", "Windows instance ID (required)")
+	computeGetPasswordCmd.Flags().StringP("identity-file", "i", "", "RSA private key of the instance's keypair (default: resolved from ~/.ssh or the SSH key store)")
+	computeGetPasswordCmd.MarkFlagRequired("instance-id")
+}
+
+var computeGetPasswordCmd = &cobra.Command{
+	Use:   "get-password",
+	Short: "Decrypt the administrator password of a Windows instance",
+	Long: `Fetches the encrypted administrator password that a Windows instance
+publishes on first boot and decrypts it locally with the RSA private key of
+the instance's keypair. The private key is never sent anywhere.
+
+The key is taken from -i, or looked up by keypair name in ~/.ssh and the
+SSH key store (see 'ssh-keys describe-keys').
+
+Examples:
+  nhncloud compute get-password --instance-id <id>
+  nhncloud compute get-password --instance-id <id> -i ~/Downloads/win-key.pem`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client := getComputeClient()
+		ctx := context.Background()
+
+		instanceID, _ := cmd.Flags().GetString("instance-id")
+		identityFile, _ := cmd.Flags().GetString("identity-file")
+
+		serverResult, err := client.GetServer(ctx, instanceID)
+		if err != nil {
+			exitWithError("Failed to get instance", err)
+		}
+		server := serverResult.Server
+
+		if identityFile == "" {
+			if server.KeyName == "" {
+				exitWithError(fmt.Sprintf("instance %s has no keypair; specify the key with -i", server.Name), nil)
+			}
+			identityFile, _ = resolveIdentityFile(server.KeyName)
+			if identityFile == "" {
+				exitWithError(fmt.Sprintf("private key for keypair '%s' not found in ~/.ssh or the SSH key store; specify it with -i", server.KeyName), nil)
+			}
+		}
+
+		encrypted, err := getServerPassword(ctx, server.ID)
+		if err != nil {
+			exitWithError("Failed to get encrypted password", err)
+		}
+		if encrypted == "" {
+			exitWithError(fmt.Sprintf("password for %s is not available yet; Windows publishes it a few minutes after the first boot", server.Name), nil)
+		}
+
+		password, err := decryptServerPassword(encrypted, identityFile)
+		if err != nil {
+			exitWithError("Failed to decrypt password", err)
+		}
+
+		if output == "json" {
+			printJSON(map[string]string{
+				"instanceId": server.ID,
+				"name":       server.Name,
+				"password":   password,
+			})
+			return
+		}
+		fmt.Printf("Instance: %s (%s)\n", server.Name, server.ID)
+		fmt.Printf("Password: %s\n", password)
+	},
+}
+
+// getServerPassword returns the base64 encoded, RSA encrypted password from
+// os-server-password, which the SDK does not wrap
+func getServerPassword(ctx context.Context, instanceID string) (string, error) {
+	api, err := newComputeAPI(ctx)
+	if err != nil {
+		return "", err
+	}
+	var resp struct {
+		Password string `json:"password"`
+	}
+	if err := api.Do(ctx, http.MethodGet, "/servers/"+instanceID+"/os-server-password", nil, &resp); err != nil {
+		return "", err
+	}
+	return resp.Password, nil
+}
+
+// decryptServerPassword decrypts an os-server-password value (RSA PKCS#1 v1.5)
+// with the private key in keyPath
+func decryptServerPassword(encrypted, keyPath string) (string, error) {
+	keyData, err := os.ReadFile(keyPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read private key: %w", err)
+	}
+
+	rawKey, err := ssh.ParseRawPrivateKey(keyData)
+	if err != nil {
+		var missing *ssh.PassphraseMissingError
+		if errors.As(err, &missing) {
+			return "", fmt.Errorf("private key %s is passphrase protected; decrypt it first", keyPath)
+		}
+		return "", fmt.Errorf("failed to parse private key %s: %w", keyPath, err)
+	}
+	rsaKey, ok := rawKey.(*rsa.PrivateKey)
+	if !ok {
+		return "", fmt.Errorf("private key %s is not an RSA key; Windows passwords can only be decrypted with RSA keypairs", keyPath)
+	}
+
+	ciphertext, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(encrypted), ""))
+	if err != nil {
+		return "", fmt.Errorf("invalid encrypted password: %w", err)
+	}
+	plaintext, err := rsa.DecryptPKCS1v15(rand.Reader, rsaKey, ciphertext)
+	if err != nil {
+		return "", fmt.Errorf("decryption failed (is %s the instance's keypair?): %w", keyPath, err)
+	}
+	return string(plaintext), nil
+}
@@ -0,0 +1,336 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/haung921209/nhn-cloud-cli/internal/sshclient"
+	"github.com/haung921209/nhn-cloud-sdk-go/nhncloud/compute"
+	"github.com/haung921209/nhn-cloud-sdk-go/nhncloud/core"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	computeCmd.AddCommand(computeResizeInstanceCmd)
+
+	computeResizeInstanceCmd.Flags().String("instance-id", "", "Instance ID (required)")
+	computeResizeInstanceCmd.Flags().String("flavor", "", "Target flavor name or ID (required)")
+	computeResizeInstanceCmd.Flags().Bool("auto-confirm", false, "Confirm the resize without prompting")
+	computeResizeInstanceCmd.Flags().Bool("stop-first", false, "Stop the instance before resizing and start it again afterwards (otherwise only when the resize requires it)")
+	computeResizeInstanceCmd.Flags().String("health-check", "", "Command run over SSH in VERIFY_RESIZE; exit 0 confirms, anything else reverts")
+	computeResizeInstanceCmd.Flags().String("health-check-timeout", "5m", "Time allowed for SSH to come up and the health check to finish")
+	computeResizeInstanceCmd.Flags().String("timeout", "30m", "Maximum time to wait for each state transition (Go duration)")
+	computeResizeInstanceCmd.Flags().Bool("dry-run", false, "Show current and target flavor specs without resizing")
+	computeResizeInstanceCmd.Flags().StringP("username", "l", "centos", "SSH username for --health-check (default: centos, or auto-detected from metadata)")
+	computeResizeInstanceCmd.Flags().StringP("identity-file", "i", "", "Identity file (private key) for --health-check")
+	computeResizeInstanceCmd.Flags().Int("port", 22, "SSH port for --health-check")
+	computeResizeInstanceCmd.Flags().Bool("private-ip", false, "Use the fixed IP for --health-check when there is no floating IP")
+
+	computeResizeInstanceCmd.MarkFlagRequired("instance-id")
+	computeResizeInstanceCmd.MarkFlagRequired("flavor")
+}
+
+var computeResizeInstanceCmd = &cobra.Command{
+	Use:   "resize-instance",
+	Short: "Change the flavor of a compute instance",
+	Long: `Changes the flavor (instance type) of a compute instance.
+
+The instance is resized and the command waits for VERIFY_RESIZE. The
+resize is then confirmed or reverted:
+
+  --health-check <cmd>  run <cmd> over SSH; exit 0 confirms, otherwise reverts
+  --auto-confirm        confirm without asking
+  (neither)             prompt for confirmation
+
+Some flavor changes require a stopped instance. When the resize of a
+running instance is refused for that reason, the command offers to stop the
+instance and retry (--auto-confirm stops it without asking). --stop-first
+stops the instance up front. A stopped instance is started again once the
+resize is confirmed or reverted. --health-check cannot be combined with a
+stopped instance.
+
+Examples:
+  nhncloud compute resize-instance --instance-id <id> --flavor c2m4 --dry-run
+  nhncloud compute resize-instance --instance-id <id> --flavor c2m4 --auto-confirm
+  nhncloud compute resize-instance --instance-id <id> --flavor c2m4 --health-check 'systemctl is-active nginx'`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client := getComputeClient()
+		ctx := context.Background()
+
+		instanceID, _ := cmd.Flags().GetString("instance-id")
+		flavorArg, _ := cmd.Flags().GetString("flavor")
+		autoConfirm, _ := cmd.Flags().GetBool("auto-confirm")
+		stopFirst, _ := cmd.Flags().GetBool("stop-first")
+		healthCheck, _ := cmd.Flags().GetString("health-check")
+		healthCheckTimeoutStr, _ := cmd.Flags().GetString("health-check-timeout")
+		timeoutStr, _ := cmd.Flags().GetString("timeout")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		timeout, err := time.ParseDuration(timeoutStr)
+		if err != nil {
+			exitWithError(fmt.Sprintf("invalid --timeout %q", timeoutStr), err)
+		}
+		healthCheckTimeout, err := time.ParseDuration(healthCheckTimeoutStr)
+		if err != nil {
+			exitWithError(fmt.Sprintf("invalid --health-check-timeout %q", healthCheckTimeoutStr), err)
+		}
+		if healthCheck != "" && stopFirst {
+			exitWithError("--health-check cannot be combined with --stop-first (the instance is not running in VERIFY_RESIZE)", nil)
+		}
+
+		serverResult, err := client.GetServer(ctx, instanceID)
+		if err != nil {
+			exitWithError("Failed to get instance", err)
+		}
+		server := serverResult.Server
+
+		flavors, err := client.ListFlavors(ctx)
+		if err != nil {
+			exitWithError("Failed to list flavors", err)
+		}
+		current := findFlavor(flavors.Flavors, server.Flavor.ID)
+		if current == nil {
+			current = &compute.Flavor{ID: server.Flavor.ID, Name: server.Flavor.ID}
+		}
+		target := findFlavor(flavors.Flavors, flavorArg)
+		if target == nil {
+			exitWithError(fmt.Sprintf("flavor %q not found (see 'compute describe-flavors')", flavorArg), nil)
+		}
+		if target.ID == current.ID {
+			exitWithError(fmt.Sprintf("instance %s already uses flavor %s", server.Name, target.Name), nil)
+		}
+
+		if dryRun {
+			if output == "json" {
+				printJSON(map[string]interface{}{
+					"instanceId": server.ID,
+					"name":       server.Name,
+					"status":     server.Status,
+					"current":    current,
+					"target":     target,
+				})
+				return
+			}
+			fmt.Printf("Instance: %s (%s), status %s\n\n", server.Name, server.ID, server.Status)
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "\tCURRENT\tTARGET")
+			fmt.Fprintf(w, "Flavor\t%s\t%s\n", current.Name, target.Name)
+			fmt.Fprintf(w, "ID\t%s\t%s\n", current.ID, target.ID)
+			fmt.Fprintf(w, "vCPUs\t%d\t%d\n", current.VCPUs, target.VCPUs)
+			fmt.Fprintf(w, "RAM (MB)\t%d\t%d\n", current.RAM, target.RAM)
+			fmt.Fprintf(w, "Disk (GB)\t%d\t%d\n", current.Disk, target.Disk)
+			w.Flush()
+			fmt.Println("\nDry run: no changes made.")
+			return
+		}
+
+		// Keep stdout clean for the JSON result
+		progress := os.Stdout
+		if output == "json" {
+			progress = os.Stderr
+		}
+
+		originalStatus := strings.ToUpper(server.Status)
+		if originalStatus != "ACTIVE" && originalStatus != "SHUTOFF" {
+			exitWithError(fmt.Sprintf("instance %s is %s; it must be ACTIVE or SHUTOFF to resize", server.Name, server.Status), nil)
+		}
+		if healthCheck != "" && originalStatus != "ACTIVE" {
+			exitWithError(fmt.Sprintf("--health-check requires a running instance; %s is %s", server.Name, server.Status), nil)
+		}
+
+		wait := func(want string) *compute.Server {
+			waitCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			s, err := waitForServerStatus(waitCtx, client, server.ID, want, 5*time.Second)
+			if err != nil {
+				exitWithError(fmt.Sprintf("Instance %s did not reach %s", server.Name, want), err)
+			}
+			return s
+		}
+
+		stoppedByUs := false
+		if stopFirst && originalStatus == "ACTIVE" {
+			fmt.Fprintf(progress, "Stopping %s...\n", server.Name)
+			if err := client.StopServer(ctx, server.ID); err != nil {
+				exitWithError("Failed to stop instance", err)
+			}
+			wait("SHUTOFF")
+			stoppedByUs = true
+		}
+
+		fmt.Fprintf(progress, "Resizing %s: %s -> %s...\n", server.Name, current.Name, target.Name)
+		err = client.ResizeServer(ctx, server.ID, target.ID)
+		if err != nil && originalStatus == "ACTIVE" && !stoppedByUs && resizeRequiresStop(err) {
+			if healthCheck != "" {
+				exitWithError(fmt.Sprintf("resizing %s to %s requires a stopped instance; rerun with --stop-first and without --health-check", server.Name, target.Name), err)
+			}
+			if !autoConfirm && !promptYesNo(fmt.Sprintf("Resizing to %s requires a stopped instance. Stop %s and retry?", target.Name, server.Name)) {
+				exitWithError("resize requires a stopped instance", err)
+			}
+			fmt.Fprintf(progress, "Stopping %s...\n", server.Name)
+			if err := client.StopServer(ctx, server.ID); err != nil {
+				exitWithError("Failed to stop instance", err)
+			}
+			wait("SHUTOFF")
+			stoppedByUs = true
+			err = client.ResizeServer(ctx, server.ID, target.ID)
+		}
+		if err != nil {
+			exitWithError("Failed to resize instance", err)
+		}
+		resized := wait("VERIFY_RESIZE")
+		fmt.Fprintf(progress, "Instance %s is in VERIFY_RESIZE\n", server.Name)
+
+		confirm := autoConfirm
+		switch {
+		case healthCheck != "":
+			err := runResizeHealthCheck(resized, sshTargetOptionsFromFlags(cmd), healthCheck, healthCheckTimeout, progress)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Health check failed: %v\n", err)
+			} else {
+				fmt.Fprintln(progress, "Health check passed")
+			}
+			confirm = err == nil
+		case !autoConfirm:
+			confirm = promptYesNo(fmt.Sprintf("Confirm resize of %s to %s?", server.Name, target.Name))
+		}
+
+		// The instance returns to the power state it had before the resize
+		settledStatus := "ACTIVE"
+		if originalStatus == "SHUTOFF" || stoppedByUs {
+			settledStatus = "SHUTOFF"
+		}
+
+		result := "confirmed"
+		if confirm {
+			fmt.Fprintln(progress, "Confirming resize...")
+			if err := client.ConfirmResize(ctx, server.ID); err != nil {
+				exitWithError("Failed to confirm resize", err)
+			}
+		} else {
+			result = "reverted"
+			fmt.Fprintln(progress, "Reverting resize...")
+			if err := revertResize(ctx, server.ID); err != nil {
+				exitWithError("Failed to revert resize", err)
+			}
+		}
+		final := wait(settledStatus)
+
+		if stoppedByUs {
+			fmt.Fprintf(progress, "Starting %s...\n", server.Name)
+			if err := client.StartServer(ctx, server.ID); err != nil {
+				exitWithError("Failed to start instance", err)
+			}
+			final = wait("ACTIVE")
+		}
+
+		finalFlavor := target
+		if !confirm {
+			finalFlavor = current
+		}
+
+		if output == "json" {
+			printJSON(map[string]interface{}{
+				"instanceId": server.ID,
+				"name":       server.Name,
+				"result":     result,
+				"status":     final.Status,
+				"flavor":     finalFlavor,
+			})
+		} else {
+			fmt.Printf("Resize %s: %s is now %s (%s)\n", result, server.Name, finalFlavor.Name, final.Status)
+		}
+
+		if !confirm {
+			os.Exit(1)
+		}
+	},
+}
+
+// findFlavor looks up a flavor by ID or name
+func findFlavor(flavors []compute.Flavor, idOrName string) *compute.Flavor {
+	for i := range flavors {
+		if flavors[i].ID == idOrName {
+			return &flavors[i]
+		}
+	}
+	for i := range flavors {
+		if flavors[i].Name == idOrName {
+			return &flavors[i]
+		}
+	}
+	return nil
+}
+
+// resizeRequiresStop reports whether a resize was refused because the
+// instance is running (409 Conflict on its vm_state)
+func resizeRequiresStop(err error) bool {
+	var httpErr *core.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusConflict {
+		return false
+	}
+	body := strings.ToLower(httpErr.Body)
+	return strings.Contains(body, "vm_state") || strings.Contains(body, "stop") || strings.Contains(body, "shutoff")
+}
+
+// revertResize reverts a resize in VERIFY_RESIZE; the SDK has no wrapper for
+// the revertResize action
+func revertResize(ctx context.Context, instanceID string) error {
+	api, err := newComputeAPI(ctx)
+	if err != nil {
+		return err
+	}
+	body := map[string]interface{}{"revertResize": nil}
+	return api.Do(ctx, http.MethodPost, "/servers/"+instanceID+"/action", body, nil)
+}
+
+// runResizeHealthCheck runs command on a resized instance, retrying the SSH
+// connection until the instance has finished booting or timeout expires
+func runResizeHealthCheck(server *compute.Server, opts sshTargetOptions, command string, timeout time.Duration, out io.Writer) error {
+	target, err := resolveSSHTarget(server, opts)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	fmt.Fprintf(out, "Running health check on %s: %s\n", target.Host, command)
+	for {
+		client, err := sshclient.Dial(target.Config())
+		if err == nil {
+			exitCode, runErr := client.Run(ctx, command, out, os.Stderr)
+			client.Close()
+			if runErr != nil {
+				return runErr
+			}
+			if exitCode != 0 {
+				return fmt.Errorf("health check exited with status %d", exitCode)
+			}
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("SSH did not become available: %w", err)
+		case <-time.After(10 * time.Second):
+		}
+	}
+}
+
+// promptYesNo asks a yes/no question on the terminal, defaulting to no
+func promptYesNo(question string) bool {
+	fmt.Fprintf(os.Stderr, "%s [y/N]: ", question)
+	reader := bufio.NewReader(os.Stdin)
+	answer, _ := reader.ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
@@ -0,0 +1,361 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/haung921209/nhn-cloud-cli/internal/sshclient"
+	"github.com/haung921209/nhn-cloud-sdk-go/nhncloud/compute"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	computeCmd.AddCommand(computeSSHExecCmd)
+
+	computeSSHExecCmd.Flags().StringSlice("instance-ids", nil, "Instance IDs to run the command on (comma separated)")
+	computeSSHExecCmd.Flags().String("name-pattern", "", "Glob pattern matched against instance names (e.g. 'web-*')")
+	computeSSHExecCmd.Flags().StringP("username", "l", "centos", "SSH username (default: centos, or auto-detected from metadata)")
+	computeSSHExecCmd.Flags().StringP("identity-file", "i", "", "Identity file (private key) path")
+	computeSSHExecCmd.Flags().Int("port", 22, "SSH port")
+	computeSSHExecCmd.Flags().Int("concurrency", 10, "Maximum number of hosts to run on at once")
+	computeSSHExecCmd.Flags().String("timeout", "10m", "Per-host command timeout (Go duration)")
+	computeSSHExecCmd.Flags().Bool("private-ip", false, "Fall back to the fixed IP when an instance has no floating IP")
+}
+
+var computeSSHExecCmd = &cobra.Command{
+	Use:   "ssh-exec [flags] -- <command>",
+	Short: "Run a command on one or more instances over SSH",
+	Long: `Runs a command on one or more compute instances in parallel using the
+built-in SSH client (no local 'ssh' binary required).
+
+Targets are selected with --instance-ids or --name-pattern. For each
+instance the floating IP, login user and identity file are resolved the
+same way as 'compute connect'. Output lines are prefixed with the
+instance name while streaming; a summary of exit codes is printed at the
+end (or emitted as JSON with -o json).
+
+The command exits with status 1 if any host fails or returns non-zero.
+
+Examples:
+  nhncloud compute ssh-exec --name-pattern 'web-*' -- uptime
+  nhncloud compute ssh-exec --instance-ids <id1>,<id2> --concurrency 2 -- sudo systemctl restart nginx
+  nhncloud compute ssh-exec --name-pattern 'db-*' -o json -- df -h /`,
+	Args: cobra.ArbitraryArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if dash := cmd.ArgsLenAtDash(); dash >= 0 {
+			args = args[dash:]
+		}
+		if len(args) == 0 {
+			exitWithError("a command to run is required after '--'", nil)
+		}
+		command := joinRemoteCommand(args)
+
+		instanceIDs, _ := cmd.Flags().GetStringSlice("instance-ids")
+		namePattern, _ := cmd.Flags().GetString("name-pattern")
+		concurrency, _ := cmd.Flags().GetInt("concurrency")
+		timeoutStr, _ := cmd.Flags().GetString("timeout")
+
+		timeout, err := time.ParseDuration(timeoutStr)
+		if err != nil {
+			exitWithError(fmt.Sprintf("invalid --timeout %q", timeoutStr), err)
+		}
+		if concurrency < 1 {
+			concurrency = 1
+		}
+
+		ctx := context.Background()
+		servers, err := selectInstances(ctx, getComputeClient(), instanceIDs, namePattern)
+		if err != nil {
+			exitWithError("Failed to select instances", err)
+		}
+
+		opts := sshTargetOptionsFromFlags(cmd)
+		results := make([]sshExecResult, len(servers))
+		stream := output != "json"
+
+		var outMu sync.Mutex
+		var wg sync.WaitGroup
+		sem := make(chan struct{}, concurrency)
+
+		for i := range servers {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+
+				results[i] = runSSHExec(ctx, &servers[i], opts, command, timeout, stream, &outMu)
+			}(i)
+		}
+		wg.Wait()
+
+		failed := 0
+		for _, r := range results {
+			if r.Error != "" || r.ExitCode != 0 {
+				failed++
+			}
+		}
+
+		if output == "json" {
+			printJSON(map[string]interface{}{
+				"command": command,
+				"total":   len(results),
+				"failed":  failed,
+				"results": results,
+			})
+		} else {
+			fmt.Println()
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "INSTANCE\tHOST\tEXIT\tDURATION\tERROR")
+			for _, r := range results {
+				fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\n",
+					r.Name, r.Host, r.ExitCode, time.Duration(r.DurationMs)*time.Millisecond, r.Error)
+			}
+			w.Flush()
+			fmt.Printf("\n%d of %d host(s) succeeded\n", len(results)-failed, len(results))
+		}
+
+		if failed > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+// sshExecResult is the per-host outcome of 'compute ssh-exec'
+type sshExecResult struct {
+	InstanceID string `json:"instanceId"`
+	Name       string `json:"name"`
+	Host       string `json:"host"`
+	ExitCode   int    `json:"exitCode"`
+	DurationMs int64  `json:"durationMs"`
+	Stdout     string `json:"stdout,omitempty"`
+	Stderr     string `json:"stderr,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+func runSSHExec(ctx context.Context, server *compute.Server, opts sshTargetOptions, command string, timeout time.Duration, stream bool, outMu *sync.Mutex) sshExecResult {
+	result := sshExecResult{InstanceID: server.ID, Name: server.Name, ExitCode: -1}
+	start := time.Now()
+	defer func() {
+		result.DurationMs = time.Since(start).Milliseconds()
+	}()
+
+	target, err := resolveSSHTarget(server, opts)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Host = target.Host
+
+	client, err := sshclient.Dial(target.Config())
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	defer client.Close()
+
+	runCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var stdout, stderr io.Writer
+	var stdoutBuf, stderrBuf bytes.Buffer
+	var outPW, errPW *prefixWriter
+	if stream {
+		prefix := fmt.Sprintf("[%s] ", server.Name)
+		outPW = &prefixWriter{mu: outMu, out: os.Stdout, prefix: prefix}
+		errPW = &prefixWriter{mu: outMu, out: os.Stderr, prefix: prefix}
+		stdout, stderr = outPW, errPW
+	} else {
+		stdout, stderr = &stdoutBuf, &stderrBuf
+	}
+
+	exitCode, err := client.Run(runCtx, command, stdout, stderr)
+	if stream {
+		outPW.Flush()
+		errPW.Flush()
+	} else {
+		result.Stdout = stdoutBuf.String()
+		result.Stderr = stderrBuf.String()
+	}
+
+	result.ExitCode = exitCode
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// ============================================================================
+// Target Resolution (shared by ssh-exec, cp and tunnel)
+// ============================================================================
+
+// sshTargetOptions carries the connection flags common to the SSH commands
+type sshTargetOptions struct {
+	Username     string
+	UsernameSet  bool
+	IdentityFile string
+	Port         int
+	PrivateIP    bool
+}
+
+func sshTargetOptionsFromFlags(cmd *cobra.Command) sshTargetOptions {
+	opts := sshTargetOptions{}
+	opts.Username, _ = cmd.Flags().GetString("username")
+	opts.UsernameSet = cmd.Flags().Changed("username")
+	opts.IdentityFile, _ = cmd.Flags().GetString("identity-file")
+	opts.Port, _ = cmd.Flags().GetInt("port")
+	opts.PrivateIP, _ = cmd.Flags().GetBool("private-ip")
+	return opts
+}
+
+// sshTarget is an instance resolved to a reachable SSH endpoint
+type sshTarget struct {
+	InstanceID   string
+	Name         string
+	Host         string
+	Port         int
+	User         string
+	IdentityFile string
+}
+
+func (t *sshTarget) Config() sshclient.Config {
+	return sshclient.Config{
+		Host:         t.Host,
+		Port:         t.Port,
+		User:         t.User,
+		IdentityFile: t.IdentityFile,
+	}
+}
+
+// resolveSSHTarget resolves the address, login user and identity file of an
+// instance following the same rules as 'compute connect', without modifying
+// any network resources.
+func resolveSSHTarget(server *compute.Server, opts sshTargetOptions) (*sshTarget, error) {
+	target := &sshTarget{
+		InstanceID: server.ID,
+		Name:       server.Name,
+		Port:       opts.Port,
+		User:       opts.Username,
+	}
+
+	target.Host = findFloatingIP(server)
+	if target.Host == "" && opts.PrivateIP {
+		target.Host = findFixedIP(server)
+	}
+	if target.Host == "" {
+		return nil, fmt.Errorf("instance %s has no floating IP (run 'compute connect' once to assign one, or use --private-ip)", server.Name)
+	}
+
+	if !opts.UsernameSet {
+		if loginUser := metadataLoginUsername(server); loginUser != "" {
+			target.User = loginUser
+		}
+	}
+
+	target.IdentityFile = opts.IdentityFile
+	if target.IdentityFile == "" && server.KeyName != "" {
+		target.IdentityFile, _ = resolveIdentityFile(server.KeyName)
+	}
+	if target.IdentityFile == "" {
+		return nil, fmt.Errorf("no identity file found for key pair '%s' of instance %s (specify -i)", server.KeyName, server.Name)
+	}
+
+	return target, nil
+}
+
+// selectInstances returns the servers named by ID or matching a name glob
+func selectInstances(ctx context.Context, client *compute.Client, instanceIDs []string, namePattern string) ([]compute.Server, error) {
+	if len(instanceIDs) == 0 && namePattern == "" {
+		return nil, fmt.Errorf("--instance-ids or --name-pattern is required")
+	}
+
+	var servers []compute.Server
+	if len(instanceIDs) > 0 {
+		for _, id := range instanceIDs {
+			result, err := client.GetServer(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			servers = append(servers, result.Server)
+		}
+		return servers, nil
+	}
+
+	if _, err := path.Match(namePattern, ""); err != nil {
+		return nil, fmt.Errorf("invalid --name-pattern %q: %w", namePattern, err)
+	}
+
+	result, err := client.ListServers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range result.Servers {
+		if ok, _ := path.Match(namePattern, s.Name); ok {
+			servers = append(servers, s)
+		}
+	}
+	if len(servers) == 0 {
+		return nil, fmt.Errorf("no instances match name pattern %q", namePattern)
+	}
+	return servers, nil
+}
+
+// joinRemoteCommand joins command arguments into a single shell command,
+// quoting arguments that contain shell metacharacters
+func joinRemoteCommand(args []string) string {
+	if len(args) == 1 {
+		return args[0]
+	}
+	quoted := make([]string, len(args))
+	for i, a := range args {
+		if a == "" || strings.ContainsAny(a, " \t\n'\"\\$`|&;<>()*?[]{}~#") {
+			quoted[i] = "'" + strings.ReplaceAll(a, "'", `'\''`) + "'"
+		} else {
+			quoted[i] = a
+		}
+	}
+	return strings.Join(quoted, " ")
+}
+
+// prefixWriter prefixes every complete line with a host label before writing
+// it to a shared output, so concurrent hosts do not interleave mid-line
+type prefixWriter struct {
+	mu     *sync.Mutex
+	out    io.Writer
+	prefix string
+	buf    []byte
+}
+
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		w.mu.Lock()
+		fmt.Fprintf(w.out, "%s%s", w.prefix, w.buf[:i+1])
+		w.mu.Unlock()
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+// Flush writes any trailing partial line
+func (w *prefixWriter) Flush() {
+	if len(w.buf) == 0 {
+		return
+	}
+	w.mu.Lock()
+	fmt.Fprintf(w.out, "%s%s\n", w.prefix, w.buf)
+	w.mu.Unlock()
+	w.buf = nil
+}
@@ -0,0 +1,218 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/haung921209/nhn-cloud-cli/internal/sshclient"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	computeCmd.AddCommand(computeTunnelCmd)
+
+	computeTunnelCmd.Flags().String("via", "", "Bastion instance (ID or name) to tunnel through (required)")
+	computeTunnelCmd.Flags().String("to", "", "Destination <host:port> reachable from the bastion (required)")
+	computeTunnelCmd.Flags().Int("local-port", 0, "Local port to listen on (default: random free port)")
+	computeTunnelCmd.Flags().String("bind-address", "127.0.0.1", "Local address to listen on")
+	computeTunnelCmd.Flags().StringP("username", "l", "centos", "SSH username (default: centos, or auto-detected from metadata)")
+	computeTunnelCmd.Flags().StringP("identity-file", "i", "", "Identity file (private key) path")
+	computeTunnelCmd.Flags().Int("port", 22, "SSH port of the bastion")
+	computeTunnelCmd.Flags().Bool("private-ip", false, "Fall back to the fixed IP when the bastion has no floating IP")
+
+	computeTunnelCmd.MarkFlagRequired("via")
+	computeTunnelCmd.MarkFlagRequired("to")
+}
+
+var computeTunnelCmd = &cobra.Command{
+	Use:   "tunnel",
+	Short: "Forward a local port to a private endpoint through a bastion instance",
+	Long: `Opens an SSH local port forward (like 'ssh -L') through a bastion
+instance using the built-in SSH client, so that endpoints in private
+subnets (RDS instances, NKS API servers, internal load balancers) can be
+reached from this machine.
+
+The bastion's floating IP, login user and identity file are resolved the
+same way as 'compute connect'. The tunnel stays open until interrupted
+with Ctrl+C.
+
+Examples:
+  nhncloud compute tunnel --via bastion --to 192.168.0.15:3306 --local-port 13306
+  nhncloud compute tunnel --via bastion --to 10.0.1.20:6443 --local-port 6443`,
+	Run: func(cmd *cobra.Command, args []string) {
+		via, _ := cmd.Flags().GetString("via")
+		to, _ := cmd.Flags().GetString("to")
+		localPort, _ := cmd.Flags().GetInt("local-port")
+		bindAddress, _ := cmd.Flags().GetString("bind-address")
+
+		if _, _, err := net.SplitHostPort(to); err != nil {
+			exitWithError(fmt.Sprintf("invalid --to %q (expected host:port)", to), err)
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		client, target, err := dialBastion(ctx, via, sshTargetOptionsFromFlags(cmd))
+		if err != nil {
+			exitWithError("Failed to connect to bastion", err)
+		}
+		defer client.Close()
+
+		listener, err := net.Listen("tcp", net.JoinHostPort(bindAddress, strconv.Itoa(localPort)))
+		if err != nil {
+			exitWithError("Failed to listen on local port", err)
+		}
+
+		fmt.Printf("Forwarding %s -> %s via %s (%s)\n", listener.Addr(), to, target.Name, target.Host)
+		fmt.Println("Press Ctrl+C to stop.")
+
+		err = client.Forward(ctx, listener, to, func(err error) {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		})
+		if err != nil {
+			exitWithError("Tunnel failed", err)
+		}
+		fmt.Println("\nTunnel closed.")
+	},
+}
+
+// ============================================================================
+// Bastion Tunnels (shared by compute tunnel and rds-* --via-bastion)
+// ============================================================================
+
+// dialBastion resolves a single bastion instance by ID or name and opens an
+// SSH connection to it
+func dialBastion(ctx context.Context, spec string, opts sshTargetOptions) (*sshclient.Client, *sshTarget, error) {
+	servers, err := selectInstancesBySpec(ctx, getComputeClient(), spec)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(servers) != 1 {
+		return nil, nil, fmt.Errorf("bastion %q matches %d instances; specify a single instance", spec, len(servers))
+	}
+
+	target, err := resolveSSHTarget(&servers[0], opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	client, err := sshclient.Dial(target.Config())
+	if err != nil {
+		return nil, nil, err
+	}
+	return client, target, nil
+}
+
+// bastionTunnel is a background port forward on a random loopback port
+type bastionTunnel struct {
+	client   *sshclient.Client
+	listener net.Listener
+	cancel   context.CancelFunc
+}
+
+// LocalHost returns the loopback address clients should connect to
+func (t *bastionTunnel) LocalHost() string {
+	return "127.0.0.1"
+}
+
+// LocalPort returns the port the tunnel listens on
+func (t *bastionTunnel) LocalPort() int {
+	return t.listener.Addr().(*net.TCPAddr).Port
+}
+
+// Close stops forwarding and closes the bastion connection
+func (t *bastionTunnel) Close() {
+	t.cancel()
+	t.client.Close()
+}
+
+// addBastionFlags registers the --via-bastion flags used by the rds-*
+// connect commands
+func addBastionFlags(cmd *cobra.Command) {
+	cmd.Flags().String("via-bastion", "", "Reach a private endpoint through this compute instance (ID or name) over SSH")
+	cmd.Flags().String("bastion-username", "centos", "SSH username for the bastion (default: centos, or auto-detected from metadata)")
+	cmd.Flags().String("bastion-identity-file", "", "Identity file (private key) for the bastion")
+}
+
+// openBastionTunnelFromFlags opens a tunnel to remoteHost:remotePort when
+// --via-bastion is set. It returns nil when no bastion was requested.
+func openBastionTunnelFromFlags(cmd *cobra.Command, remoteHost string, remotePort int) (*bastionTunnel, error) {
+	spec, _ := cmd.Flags().GetString("via-bastion")
+	if spec == "" {
+		return nil, nil
+	}
+
+	opts := sshTargetOptions{Port: 22}
+	opts.Username, _ = cmd.Flags().GetString("bastion-username")
+	opts.UsernameSet = cmd.Flags().Changed("bastion-username")
+	opts.IdentityFile, _ = cmd.Flags().GetString("bastion-identity-file")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	client, target, err := dialBastion(ctx, spec, opts)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		cancel()
+		client.Close()
+		return nil, err
+	}
+
+	remoteAddr := net.JoinHostPort(remoteHost, strconv.Itoa(remotePort))
+	go client.Forward(ctx, listener, remoteAddr, func(err error) {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	})
+
+	tunnel := &bastionTunnel{client: client, listener: listener, cancel: cancel}
+	fmt.Fprintf(os.Stderr, "Tunneling %s:%d -> %s via %s (%s)\n", tunnel.LocalHost(), tunnel.LocalPort(), remoteAddr, target.Name, target.Host)
+	return tunnel, nil
+}
+
+// rdsEndpoint is the engine-independent subset of an RDS network endpoint
+type rdsEndpoint struct {
+	Type      string
+	Domain    string
+	IPAddress string
+}
+
+// privateRDSHost picks the in-VPC address of an RDS instance for use behind a
+// bastion: the first non-EXTERNAL endpoint, then the instance's own network
+// domain or IP address.
+func privateRDSHost(endpoints []rdsEndpoint, fallbacks ...string) string {
+	for _, ep := range endpoints {
+		if ep.Type == "EXTERNAL" {
+			continue
+		}
+		if ep.Domain != "" {
+			return ep.Domain
+		}
+		if ep.IPAddress != "" {
+			return ep.IPAddress
+		}
+	}
+	for _, host := range fallbacks {
+		if host != "" {
+			return host
+		}
+	}
+	return ""
+}
+
+// tunnelSafeConnectionArgs relaxes hostname verification in a generated
+// client command, since the server certificate names the RDS endpoint rather
+// than the local tunnel address. The CA is still verified.
+func tunnelSafeConnectionArgs(args []string) []string {
+	for i, arg := range args {
+		args[i] = strings.Replace(arg, "sslmode=verify-full", "sslmode=verify-ca", 1)
+	}
+	return args
+}
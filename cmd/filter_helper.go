@@ -0,0 +1,310 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ============================================================================
+// List Filters (--filter)
+//
+// Each --filter is "<field><op><value>". Filters are ANDed.
+//
+//	=  !=   case-insensitive equality
+//	~  !~   regular expression match
+//	>  >=  <  <=   numeric, date (RFC3339 or YYYY-MM-DD) or string comparison
+//
+// Fields are JSON field names of the listed items, matched case-insensitively.
+// Commands may declare short aliases (status -> dbInstanceStatus); otherwise a
+// unique suffix or prefix of a field name also matches. Dotted paths reach
+// nested fields (metadata.role) and "*" matches every key of a map
+// (addresses.*.addr). When a field holds a list, the filter matches if any
+// element matches.
+// ============================================================================
+
+var filterExpr = regexp.MustCompile(`^([A-Za-z0-9_.:-]+?)(!=|!~|>=|<=|=|~|>|<)(.*)$`)
+
+var filterDateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// filterAliases maps short filter field names to JSON paths of list items
+type filterAliases map[string]string
+
+type listFilter struct {
+	Field string
+	Op    string
+	Value string
+	re    *regexp.Regexp
+}
+
+func parseFilters(exprs []string) ([]listFilter, error) {
+	var parsed []listFilter
+	for _, expr := range exprs {
+		m := filterExpr.FindStringSubmatch(strings.TrimSpace(expr))
+		if m == nil {
+			return nil, fmt.Errorf("invalid filter %q (expected <field><op><value>, op one of = != ~ !~ > >= < <=)", expr)
+		}
+		f := listFilter{Field: m[1], Op: m[2], Value: m[3]}
+		if f.Op == "~" || f.Op == "!~" {
+			re, err := regexp.Compile(f.Value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid regular expression in filter %q: %w", expr, err)
+			}
+			f.re = re
+		}
+		parsed = append(parsed, f)
+	}
+	return parsed, nil
+}
+
+// activeFilters parses the global --filter flags, exiting on invalid input
+func activeFilters() []listFilter {
+	parsed, err := parseFilters(filters)
+	if err != nil {
+		exitWithError("invalid --filter", err)
+	}
+	return parsed
+}
+
+// filterItems returns the items matching every --filter
+func filterItems[T any](items []T, aliases filterAliases) []T {
+	active := activeFilters()
+	if len(active) == 0 {
+		return items
+	}
+	active = resolveFilterAliases(active, aliases)
+
+	matched := []T{}
+	for _, item := range items {
+		ok, err := matchesFilters(item, active)
+		if err != nil {
+			exitWithError("invalid --filter", err)
+		}
+		if ok {
+			matched = append(matched, item)
+		}
+	}
+	return matched
+}
+
+func resolveFilterAliases(active []listFilter, aliases filterAliases) []listFilter {
+	resolved := make([]listFilter, len(active))
+	for i, f := range active {
+		if path, ok := aliases[strings.ToLower(f.Field)]; ok {
+			f.Field = path
+		}
+		resolved[i] = f
+	}
+	return resolved
+}
+
+// serverSideFilter returns the value of an "=" filter on field so that
+// commands can pass it to the API as a query parameter. The filter is still
+// applied client-side, so pushing it down only reduces the response size.
+func serverSideFilter(field string, aliases filterAliases) (string, bool) {
+	for _, f := range resolveFilterAliases(activeFilters(), aliases) {
+		if f.Op == "=" && strings.EqualFold(f.Field, field) {
+			return f.Value, true
+		}
+	}
+	return "", false
+}
+
+func matchesFilters(item interface{}, active []listFilter) (bool, error) {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return false, err
+	}
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return false, err
+	}
+
+	for _, f := range active {
+		values, found, err := lookupFilterField(doc, strings.Split(f.Field, "."))
+		if err != nil {
+			return false, err
+		}
+		if !found {
+			// A missing field only satisfies negative filters
+			if f.Op != "!=" && f.Op != "!~" {
+				return false, nil
+			}
+			continue
+		}
+		if !f.matchesAny(values) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// lookupFilterField resolves a dotted path, flattening lists along the way
+func lookupFilterField(doc interface{}, path []string) ([]interface{}, bool, error) {
+	if len(path) == 0 {
+		if list, ok := doc.([]interface{}); ok {
+			return list, true, nil
+		}
+		return []interface{}{doc}, true, nil
+	}
+
+	switch node := doc.(type) {
+	case map[string]interface{}:
+		if path[0] == "*" {
+			values := make([]interface{}, 0, len(node))
+			for _, v := range node {
+				values = append(values, v)
+			}
+			return lookupFilterField(values, path[1:])
+		}
+		key, ok, err := matchFilterKey(node, path[0])
+		if !ok || err != nil {
+			return nil, false, err
+		}
+		return lookupFilterField(node[key], path[1:])
+	case []interface{}:
+		var values []interface{}
+		found := false
+		for _, elem := range node {
+			v, ok, err := lookupFilterField(elem, path)
+			if err != nil {
+				return nil, false, err
+			}
+			if ok {
+				values = append(values, v...)
+				found = true
+			}
+		}
+		return values, found, nil
+	}
+	return nil, false, nil
+}
+
+// matchFilterKey finds the map key for a filter field: exact (case-insensitive)
+// first, then a unique suffix, then a unique prefix
+func matchFilterKey(node map[string]interface{}, field string) (string, bool, error) {
+	lower := strings.ToLower(field)
+	for key := range node {
+		if strings.ToLower(key) == lower {
+			return key, true, nil
+		}
+	}
+
+	for _, match := range []func(string, string) bool{strings.HasSuffix, strings.HasPrefix} {
+		var candidates []string
+		for key := range node {
+			if match(strings.ToLower(key), lower) {
+				candidates = append(candidates, key)
+			}
+		}
+		if len(candidates) == 1 {
+			return candidates[0], true, nil
+		}
+		if len(candidates) > 1 {
+			sort.Strings(candidates)
+			return "", false, fmt.Errorf("filter field %q is ambiguous (%s)", field, strings.Join(candidates, ", "))
+		}
+	}
+	return "", false, nil
+}
+
+// matchesAny reports whether any value matches; a negated filter holds
+// when no value matches its positive form
+func (f listFilter) matchesAny(values []interface{}) bool {
+	negated := f.Op == "!=" || f.Op == "!~"
+	for _, v := range values {
+		if f.matches(filterValueString(v)) {
+			return !negated
+		}
+	}
+	return negated
+}
+
+// matches evaluates the positive form of the operator (!= and !~ are
+// handled by matchesAny)
+func (f listFilter) matches(actual string) bool {
+	switch f.Op {
+	case "=", "!=":
+		return strings.EqualFold(actual, f.Value)
+	case "~", "!~":
+		return f.re.MatchString(actual)
+	}
+
+	cmp, ok := compareFilterValues(actual, f.Value)
+	if !ok {
+		return false
+	}
+	switch f.Op {
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	}
+	return false
+}
+
+func compareFilterValues(a, b string) (int, bool) {
+	if af, err := strconv.ParseFloat(a, 64); err == nil {
+		if bf, err := strconv.ParseFloat(b, 64); err == nil {
+			switch {
+			case af < bf:
+				return -1, true
+			case af > bf:
+				return 1, true
+			}
+			return 0, true
+		}
+	}
+
+	if at, ok := parseFilterDate(a); ok {
+		if bt, ok := parseFilterDate(b); ok {
+			return at.Compare(bt), true
+		}
+	}
+
+	if a == "" {
+		return 0, false
+	}
+	return strings.Compare(a, b), true
+}
+
+func parseFilterDate(s string) (time.Time, bool) {
+	for _, layout := range filterDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	// Some APIs return offsets without a colon (2026-01-02T15:04:05+0900)
+	if t, err := time.Parse("2006-01-02T15:04:05-0700", s); err == nil {
+		return t, true
+	}
+	return time.Time{}, false
+}
+
+func filterValueString(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(val)
+	}
+	data, _ := json.Marshal(v)
+	return string(data)
+}
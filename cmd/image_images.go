@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 	"text/tabwriter"
 
 	"github.com/haung921209/nhn-cloud-sdk-go/nhncloud/image"
@@ -50,6 +51,17 @@ var imgDescribeCmd = &cobra.Command{
 	Use:     "describe-images",
 	Aliases: []string{"list-images", "list"},
 	Short:   "List all images",
+	Long: `Lists images in the Glance Image API.
+
+Filters (--filter, repeatable):
+  id, name, status, visibility, os, os-type, size, min_disk, created, tags
+  and any other JSON field of the image. status and visibility "=" filters
+  are also sent to the Image API to reduce the response; all other filters,
+  including name, os and os-type, are applied by the CLI.
+
+Examples:
+  nhncloud image describe-images --filter visibility=public --filter 'os~(?i)ubuntu'
+  nhncloud image describe-images --filter created>2026-01-01`,
 	Run: func(cmd *cobra.Command, args []string) {
 		client := getImageClient()
 		ctx := context.Background()
@@ -69,11 +81,13 @@ var imgDescribeCmd = &cobra.Command{
 			OSDistro:   osDistro,
 			Limit:      limit,
 		}
+		applyImageFilters(input)
 
 		result, err := client.ListImages(ctx, input)
 		if err != nil {
 			exitWithError("Failed to list images", err)
 		}
+		result.Images = filterItems(result.Images, imageFilterAliases)
 
 		if output == "json" {
 			data, _ := json.MarshalIndent(result, "", "  ")
@@ -210,3 +224,25 @@ var imgDeleteCmd = &cobra.Command{
 		fmt.Printf("Image %s deleted successfully\n", id)
 	},
 }
+
+// imageFilterAliases are the short --filter fields of describe-images
+var imageFilterAliases = filterAliases{
+	"os":      "os_distro",
+	"os-type": "os_type",
+	"created": "created_at",
+}
+
+// applyImageFilters sends "=" filters the Image API supports as query
+// parameters; flags given explicitly take precedence. Only status and
+// visibility are pushed down: Glance stores them in lowercase, so the
+// query matches what the case-insensitive client-side filter keeps. Names
+// and OS properties are compared case-sensitively by Glance and are left
+// to the client-side filter.
+func applyImageFilters(input *image.ListImagesInput) {
+	if v, ok := serverSideFilter("status", imageFilterAliases); ok && input.Status == "" {
+		input.Status = strings.ToLower(v)
+	}
+	if v, ok := serverSideFilter("visibility", imageFilterAliases); ok && input.Visibility == "" {
+		input.Visibility = strings.ToLower(v)
+	}
+}
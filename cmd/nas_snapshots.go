@@ -6,6 +6,7 @@ import (
 	"os"
 	"text/tabwriter"
 
+	"github.com/haung921209/nhn-cloud-cli/internal/retention"
 	"github.com/haung921209/nhn-cloud-sdk-go/nhncloud/storage/nas"
 	"github.com/spf13/cobra"
 )
@@ -15,6 +16,7 @@ func init() {
 	nasCmd.AddCommand(nasCreateSnapshotCmd)
 	nasCmd.AddCommand(nasDeleteSnapshotCmd)
 	nasCmd.AddCommand(nasRestoreSnapshotCmd)
+	nasCmd.AddCommand(nasPruneSnapshotsCmd)
 
 	nasDescribeSnapshotsCmd.Flags().String("volume-id", "", "Volume ID (required)")
 	nasDescribeSnapshotsCmd.MarkFlagRequired("volume-id")
@@ -33,6 +35,9 @@ func init() {
 	nasRestoreSnapshotCmd.Flags().String("snapshot-id", "", "Snapshot ID (required)")
 	nasRestoreSnapshotCmd.MarkFlagRequired("volume-id")
 	nasRestoreSnapshotCmd.MarkFlagRequired("snapshot-id")
+
+	nasPruneSnapshotsCmd.Flags().String("volume-id", "", "Volume ID; all volumes when omitted")
+	addPruneFlags(nasPruneSnapshotsCmd)
 }
 
 var nasDescribeSnapshotsCmd = &cobra.Command{
@@ -124,3 +129,51 @@ var nasRestoreSnapshotCmd = &cobra.Command{
 		fmt.Printf("Volume restored from snapshot %s\n", snapID)
 	},
 }
+
+var nasPruneSnapshotsCmd = &cobra.Command{
+	Use:   "prune-snapshots",
+	Short: "Delete snapshots outside a retention policy",
+	Long: `Deletes snapshots that fall outside a retention policy, per volume.
+Preserved snapshots are never deleted.
+` + pruneLongHelp + `
+
+Examples:
+  nhncloud nas prune-snapshots --policy-file retention.yaml --dry-run
+  nhncloud nas prune-snapshots --volume-id <id> --policy-file retention.yaml --yes`,
+	Run: func(cmd *cobra.Command, args []string) {
+		policy := loadPrunePolicy(cmd)
+		client := newNASClient()
+		ctx := context.Background()
+		volumeID, _ := cmd.Flags().GetString("volume-id")
+
+		volumes, err := client.ListVolumes(ctx, &nas.ListVolumesInput{})
+		if err != nil {
+			exitWithError("Failed to list volumes", err)
+		}
+		var snapshots []retention.Snapshot
+		volumeOf := make(map[string]string)
+		for _, v := range volumes.Volumes {
+			if volumeID != "" && v.ID != volumeID {
+				continue
+			}
+			result, err := client.ListSnapshots(ctx, v.ID)
+			if err != nil {
+				exitWithError(fmt.Sprintf("Failed to list snapshots of %s", v.Name), err)
+			}
+			for _, s := range result.Snapshots {
+				snapshots = append(snapshots, retention.Snapshot{
+					ID:        s.ID,
+					Name:      s.Name,
+					Resource:  v.Name,
+					CreatedAt: s.CreatedAt,
+					Protected: s.Preserved,
+				})
+				volumeOf[s.ID] = v.ID
+			}
+		}
+
+		runPrune(cmd, policy, snapshots, func(s retention.Snapshot) error {
+			return client.DeleteSnapshot(ctx, volumeOf[s.ID], s.ID)
+		})
+	},
+}
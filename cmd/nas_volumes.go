@@ -42,10 +42,29 @@ func init() {
 	nasVolumeUsageCmd.MarkFlagRequired("volume-id")
 }
 
+// nasVolumeFilterAliases are the short --filter fields of describe-volumes
+var nasVolumeFilterAliases = filterAliases{
+	"size":     "sizeGb",
+	"protocol": "mountProtocol.protocol",
+	"subnet":   "interfaces.subnetId",
+	"created":  "createdAt",
+}
+
 var nasDescribeVolumesCmd = &cobra.Command{
 	Use:     "describe-volumes",
 	Aliases: []string{"list-volumes"},
 	Short:   "List or describe NAS volumes",
+	Long: `Lists NAS volumes.
+
+Filters (--filter, repeatable):
+  id, name, status, size (GB), protocol, subnet, created
+  and any other JSON field of the volume. subnet "=" filters are also sent
+  to the NAS API to reduce the response; all other filters, including name,
+  are applied by the CLI.
+
+Examples:
+  nhncloud nas describe-volumes --filter size>=500 --filter protocol=NFS
+  nhncloud nas describe-volumes --filter 'name~^backup-'`,
 	Run: func(cmd *cobra.Command, args []string) {
 		client := newNASClient()
 		ctx := context.Background()
@@ -63,11 +82,17 @@ var nasDescribeVolumesCmd = &cobra.Command{
 		if subnetID != "" {
 			input.SubnetID = subnetID
 		}
+		// A name filter is not pushed down: the API matches names
+		// case-sensitively, the client-side filter does not
+		if v, ok := serverSideFilter("interfaces.subnetId", nasVolumeFilterAliases); ok && input.SubnetID == "" {
+			input.SubnetID = v
+		}
 
 		result, err := client.ListVolumes(ctx, input)
 		if err != nil {
 			exitWithError("Failed to list volumes", err)
 		}
+		result.Volumes = filterItems(result.Volumes, nasVolumeFilterAliases)
 
 		if output == "json" {
 			printJSON(result)
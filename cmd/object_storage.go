@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -455,14 +456,7 @@ func uploadDirectory(ctx context.Context, client *object.Client, localDir, conta
 func uploadMultipartSLO(ctx context.Context, client *object.Client, f *os.File, fileSize int64, container, objectName string, segmentSize int64) error {
 	fmt.Printf("Large file detected (%d bytes). Using SLO Multipart Upload (Segment Size: %d bytes)...\n", fileSize, segmentSize)
 
-	segmentContainer := container + "_segments"
-	// Ensure segment container exists
-	err := client.CreateContainer(ctx, &object.CreateContainerInput{Name: segmentContainer})
-	if err != nil {
-		// Ignore if already exists (409/202) -> SDK ensure logic might be needed or just try
-		// CreateContainer returns error on non-201/202 headers usually.
-		fmt.Printf("Note: Segment container creation attempt: %v\n", err)
-	}
+	segmentContainer := ensureSegmentContainer(ctx, client, container)
 
 	totalSegments := (fileSize + segmentSize - 1) / segmentSize
 	var segments []object.SLOSegment
@@ -485,30 +479,11 @@ func uploadMultipartSLO(ctx context.Context, client *object.Client, f *os.File,
 
 		fmt.Printf("Uploading segment %d/%d (%d bytes) to %s/%s...\n", i+1, totalSegments, remaining, segmentContainer, objectName)
 
-		input := &object.UploadSegmentInput{
-			Container:    segmentContainer,
-			ObjectName:   objectName,
-			SegmentIndex: int(i + 1),
-			Body:         partReader,
-			ContentType:  "application/octet-stream",
-		}
-
-		out, err := client.UploadSegment(ctx, input)
+		segment, err := uploadSLOSegment(ctx, client, segmentContainer, objectName, int(i+1), partReader, remaining)
 		if err != nil {
-			return fmt.Errorf("upload segment %d failed: %w", i+1, err)
+			return err
 		}
-
-		// SDK UploadSegment constructs path as `container/objectName/001`.
-		// SLO Segment path should match that.
-		// `/%s/%s/%03d` -> `container/objectName/001`
-		// The `Path` in SLOSegment must be `/{segment-container}/{object-name}/{index}`.
-		segmentPath := fmt.Sprintf("/%s/%s/%03d", segmentContainer, objectName, i+1)
-
-		segments = append(segments, object.SLOSegment{
-			Path:      segmentPath,
-			ETag:      out.ETag,
-			SizeBytes: remaining,
-		})
+		segments = append(segments, segment)
 	}
 
 	fmt.Printf("All segments uploaded. Creating SLO manifest...\n")
@@ -528,6 +503,125 @@ func uploadMultipartSLO(ctx context.Context, client *object.Client, f *os.File,
 	return nil
 }
 
+// ensureSegmentContainer creates the "<container>_segments" container that
+// holds SLO segments and returns its name
+func ensureSegmentContainer(ctx context.Context, client *object.Client, container string) string {
+	segmentContainer := container + "_segments"
+	// Ensure segment container exists
+	err := client.CreateContainer(ctx, &object.CreateContainerInput{Name: segmentContainer})
+	if err != nil {
+		// Ignore if already exists (409/202) -> SDK ensure logic might be needed or just try
+		// CreateContainer returns error on non-201/202 headers usually.
+		fmt.Fprintf(os.Stderr, "Note: Segment container creation attempt: %v\n", err)
+	}
+	return segmentContainer
+}
+
+// uploadSLOSegment uploads segment index (1-based) of objectName and returns
+// its manifest entry
+func uploadSLOSegment(ctx context.Context, client *object.Client, segmentContainer, objectName string, index int, body io.Reader, size int64) (object.SLOSegment, error) {
+	input := &object.UploadSegmentInput{
+		Container:    segmentContainer,
+		ObjectName:   objectName,
+		SegmentIndex: index,
+		Body:         body,
+		ContentType:  "application/octet-stream",
+	}
+
+	out, err := client.UploadSegment(ctx, input)
+	if err != nil {
+		return object.SLOSegment{}, fmt.Errorf("upload segment %d failed: %w", index, err)
+	}
+
+	// SDK UploadSegment constructs path as `container/objectName/001`.
+	// SLO Segment path should match that.
+	// `/%s/%s/%03d` -> `container/objectName/001`
+	// The `Path` in SLOSegment must be `/{segment-container}/{object-name}/{index}`.
+	return object.SLOSegment{
+		Path:      fmt.Sprintf("/%s/%s/%03d", segmentContainer, objectName, index),
+		ETag:      out.ETag,
+		SizeBytes: size,
+	}, nil
+}
+
+// obsStreamWriter uploads a stream of unknown length: data is buffered up
+// to segmentSize and uploaded as SLO segments as it is written. A stream
+// that fits in one segment is stored as a plain object.
+type obsStreamWriter struct {
+	ctx         context.Context
+	client      *object.Client
+	container   string
+	objectName  string
+	segmentSize int64
+
+	buf              bytes.Buffer
+	segmentContainer string
+	segments         []object.SLOSegment
+	written          int64
+}
+
+func newOBSStreamWriter(ctx context.Context, client *object.Client, container, objectName string, segmentSize int64) *obsStreamWriter {
+	return &obsStreamWriter{ctx: ctx, client: client, container: container, objectName: objectName, segmentSize: segmentSize}
+}
+
+func (w *obsStreamWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		room := int(w.segmentSize) - w.buf.Len()
+		chunk := p[:min(room, len(p))]
+		w.buf.Write(chunk)
+		p = p[len(chunk):]
+		if int64(w.buf.Len()) >= w.segmentSize {
+			if err := w.flushSegment(); err != nil {
+				return n - len(p), err
+			}
+		}
+	}
+	w.written += int64(n)
+	return n, nil
+}
+
+func (w *obsStreamWriter) flushSegment() error {
+	if w.segmentContainer == "" {
+		w.segmentContainer = ensureSegmentContainer(w.ctx, w.client, w.container)
+	}
+	size := int64(w.buf.Len())
+	segment, err := uploadSLOSegment(w.ctx, w.client, w.segmentContainer, w.objectName, len(w.segments)+1, bytes.NewReader(w.buf.Bytes()), size)
+	if err != nil {
+		return err
+	}
+	w.segments = append(w.segments, segment)
+	w.buf.Reset()
+	return nil
+}
+
+// Close uploads the rest of the stream and creates the SLO manifest
+func (w *obsStreamWriter) Close() error {
+	if len(w.segments) == 0 {
+		_, err := w.client.PutObject(w.ctx, &object.PutObjectInput{
+			Container:   w.container,
+			ObjectName:  w.objectName,
+			Body:        bytes.NewReader(w.buf.Bytes()),
+			ContentType: "application/octet-stream",
+		})
+		return err
+	}
+	if w.buf.Len() > 0 {
+		if err := w.flushSegment(); err != nil {
+			return err
+		}
+	}
+	if err := w.client.CreateSLOManifest(w.ctx, &object.CreateSLOManifestInput{
+		Container:   w.container,
+		ObjectName:  w.objectName,
+		Segments:    w.segments,
+		ContentType: "application/octet-stream",
+	}); err != nil {
+		return fmt.Errorf("create manifest failed: %w", err)
+	}
+	return nil
+}
+
 func downloadFromOBS(ctx context.Context, client *object.Client, src *OBSPath, dest *OBSPath, recursive bool) error {
 	destPath := dest.RawPath
 	if destPath == "" {
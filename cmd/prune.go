@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/haung921209/nhn-cloud-cli/internal/rdsengine"
+	"github.com/haung921209/nhn-cloud-cli/internal/retention"
+	"github.com/spf13/cobra"
+)
+
+// ============================================================================
+// Snapshot retention (prune commands)
+// ============================================================================
+
+// pruneEntry is one snapshot of a prune plan in json/yaml output
+type pruneEntry struct {
+	Resource  string `json:"resource" yaml:"resource"`
+	ID        string `json:"id" yaml:"id"`
+	Name      string `json:"name" yaml:"name"`
+	CreatedAt string `json:"createdAt" yaml:"createdAt"`
+	Action    string `json:"action" yaml:"action"`
+	Rule      string `json:"rule,omitempty" yaml:"rule,omitempty"`
+	Reason    string `json:"reason" yaml:"reason"`
+	Error     string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// pruneSummary is the json/yaml output of the prune commands
+type pruneSummary struct {
+	DryRun  bool         `json:"dryRun" yaml:"dryRun"`
+	Kept    int          `json:"kept" yaml:"kept"`
+	Deleted int          `json:"deleted" yaml:"deleted"`
+	Failed  int          `json:"failed" yaml:"failed"`
+	Plan    []pruneEntry `json:"plan" yaml:"plan"`
+}
+
+const pruneLongHelp = `
+The policy file lists rules; each snapshot is governed by the first rule
+whose name-pattern (glob) and tags match it, and snapshots matching no rule
+are never deleted. Keep counts apply per resource:
+
+  rules:
+    - name: nightly
+      name-pattern: "nightly-*"
+      tags: {env: prod}      # snapshot metadata, where supported
+      keep-last: 7           # the 7 newest
+      keep-daily: 14         # the newest of each of the last 14 days
+      keep-weekly: 8         # the newest of each of the last 8 weeks
+    - name: everything-else
+      keep-last: 3
+
+--dry-run prints the plan only. Without --yes the plan is confirmed
+interactively; use --yes from cron. The command exits with 1 if any
+deletion failed.`
+
+func addPruneFlags(c *cobra.Command) {
+	c.Flags().String("policy-file", "", "Retention policy file (YAML) (required)")
+	c.Flags().Bool("dry-run", false, "Print the plan without deleting")
+	c.Flags().Bool("yes", false, "Delete without confirmation")
+}
+
+// loadPrunePolicy reads --policy-file
+func loadPrunePolicy(cmd *cobra.Command) *retention.Policy {
+	file, _ := cmd.Flags().GetString("policy-file")
+	if file == "" {
+		exitWithError("--policy-file is required", nil)
+	}
+	policy, err := retention.Load(file)
+	if err != nil {
+		exitWithError("invalid retention policy", err)
+	}
+	return policy
+}
+
+// runPrune applies policy to snapshots, prints the plan and deletes the
+// snapshots outside retention with del, one at a time
+func runPrune(cmd *cobra.Command, policy *retention.Policy, snapshots []retention.Snapshot, del func(retention.Snapshot) error) {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	yes, _ := cmd.Flags().GetBool("yes")
+
+	decisions := policy.Evaluate(snapshots, time.Now())
+	summary := pruneSummary{DryRun: dryRun, Plan: make([]pruneEntry, len(decisions))}
+	var doomed []int
+	for i, d := range decisions {
+		summary.Plan[i] = pruneEntry{
+			Resource:  d.Snapshot.Resource,
+			ID:        d.Snapshot.ID,
+			Name:      d.Snapshot.Name,
+			CreatedAt: d.Snapshot.CreatedAt.Format(time.RFC3339),
+			Action:    "KEEP",
+			Rule:      d.Rule,
+			Reason:    d.Reason,
+		}
+		if d.Keep {
+			summary.Kept++
+		} else {
+			summary.Plan[i].Action = "DELETE"
+			doomed = append(doomed, i)
+		}
+	}
+
+	structured := output == "json" || output == "yaml"
+	if !structured {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "RESOURCE\tID\tNAME\tCREATED\tACTION\tREASON")
+		for _, e := range summary.Plan {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", e.Resource, e.ID, e.Name, e.CreatedAt, e.Action, e.Reason)
+		}
+		w.Flush()
+		fmt.Println()
+	}
+
+	switch {
+	case dryRun || len(doomed) == 0:
+	case !yes && !promptYesNo(fmt.Sprintf("Delete %d snapshot(s)?", len(doomed))):
+		fmt.Fprintln(os.Stderr, "Aborted.")
+		return
+	default:
+		for _, i := range doomed {
+			if err := del(decisions[i].Snapshot); err != nil {
+				summary.Plan[i].Error = err.Error()
+				summary.Failed++
+				fmt.Fprintf(os.Stderr, "Failed to delete %s (%s): %v\n", summary.Plan[i].Name, summary.Plan[i].ID, err)
+				continue
+			}
+			summary.Deleted++
+			if !structured {
+				fmt.Printf("Deleted %s (%s)\n", summary.Plan[i].Name, summary.Plan[i].ID)
+			}
+		}
+	}
+
+	if structured {
+		if err := printOutput(summary); err != nil {
+			exitWithError("failed to print summary", err)
+		}
+	} else if dryRun {
+		fmt.Printf("Dry run: %d snapshot(s) would be deleted, %d kept.\n", len(doomed), summary.Kept)
+	} else {
+		fmt.Printf("Deleted %d, failed %d, kept %d.\n", summary.Deleted, summary.Failed, summary.Kept)
+	}
+	if summary.Failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// newRDSPruneCmd builds prune-db-snapshots. Only completed manual backups
+// are considered; automatic backups follow the instance's backup period.
+func newRDSPruneCmd(engineFor func() rdsengine.Engine) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "prune-db-snapshots",
+		Short: "Delete DB snapshots outside a retention policy",
+		Long: `Deletes manual backups (snapshots) that fall outside a retention policy,
+for one DB instance or, without --db-instance-identifier, for every instance
+of the engine. Automatic backups are left to the instance's backup period.
+` + pruneLongHelp + `
+
+Examples:
+  nhncloud rds-mysql prune-db-snapshots --policy-file retention.yaml --dry-run
+  nhncloud rds-mysql prune-db-snapshots --db-instance-identifier mydb --policy-file retention.yaml --yes`,
+		Run: func(cmd *cobra.Command, args []string) {
+			policy := loadPrunePolicy(cmd)
+			e := engineFor()
+			ctx := context.Background()
+
+			var instances []rdsengine.Instance
+			if identifier, _ := cmd.Flags().GetString("db-instance-identifier"); identifier != "" {
+				inst, err := e.GetInstance(ctx, resolveRDSInstanceID(cmd, e))
+				if err != nil {
+					exitWithError("failed to get instance", err)
+				}
+				instances = append(instances, *inst)
+			} else {
+				var err error
+				if instances, err = e.ListInstances(ctx); err != nil {
+					exitWithError("failed to list instances", err)
+				}
+			}
+
+			var snapshots []retention.Snapshot
+			for _, inst := range instances {
+				backups, err := e.ListBackups(ctx, inst.ID)
+				if err != nil {
+					exitWithError(fmt.Sprintf("failed to list backups of %s", inst.Name), err)
+				}
+				for _, b := range backups {
+					if !strings.EqualFold(b.Status, "COMPLETED") || strings.EqualFold(b.Type, "AUTO") {
+						continue
+					}
+					created, ok := parseFilterDate(firstNonEmpty(b.CompletedAt, b.CreatedAt))
+					if !ok {
+						continue
+					}
+					snapshots = append(snapshots, retention.Snapshot{
+						ID:        b.ID,
+						Name:      b.Name,
+						Resource:  inst.Name,
+						CreatedAt: created,
+					})
+				}
+			}
+
+			runPrune(cmd, policy, snapshots, func(s retention.Snapshot) error {
+				return e.DeleteBackup(ctx, s.ID)
+			})
+		},
+	}
+	c.Flags().String("db-instance-identifier", "", "DB instance identifier (name or ID); all instances when omitted")
+	addPruneFlags(c)
+	return c
+}
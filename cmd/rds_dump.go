@@ -0,0 +1,361 @@
+package cmd
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/haung921209/nhn-cloud-cli/internal/rdsengine"
+	"github.com/haung921209/nhn-cloud-cli/internal/sqldump"
+	"github.com/spf13/cobra"
+)
+
+// ============================================================================
+// Logical dump / restore
+// ============================================================================
+
+// rdsDumpSummary is printed after a dump in json/yaml output
+type rdsDumpSummary struct {
+	Destination string  `json:"destination" yaml:"destination"`
+	Tables      int     `json:"tables" yaml:"tables"`
+	Views       int     `json:"views" yaml:"views"`
+	Rows        int64   `json:"rows" yaml:"rows"`
+	Bytes       int64   `json:"bytes" yaml:"bytes"`
+	Seconds     float64 `json:"seconds" yaml:"seconds"`
+}
+
+// countingWriter counts the bytes written to w
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+func newRDSDumpCommands(engineFor func() rdsengine.Engine) []*cobra.Command {
+	dump := &cobra.Command{
+		Use:   "dump",
+		Short: "Write a logical dump (SQL) of a database to a file or Object Storage",
+		Long: `Writes a logical dump of one database as plain SQL with the built-in driver
+(no mysqldump or pg_dump needed). All tables are read in one consistent
+snapshot transaction, so the dump is consistent without locking writes.
+
+Rows are written as multi-row INSERT statements (--rows-per-insert rows, at
+most 1 MiB each). MySQL/MariaDB dumps contain tables and views;
+PostgreSQL dumps contain schemas, extensions, enum types, sequences, tables,
+indexes, foreign keys and views (not functions, triggers or partitioned
+tables).
+
+--to is a local file or directory, '-' for stdout, or an Object Storage
+path (obs://container/prefix/). Names ending in .gz are gzip-compressed;
+without a file name, <instance>-<database>-<timestamp>.sql.gz is used.
+Object Storage uploads are streamed in --segment-size segments (SLO), so
+the dump never touches the local disk.
+
+Examples:
+  nhncloud rds-mysql dump --db-instance-identifier mydb --username admin \
+    --database app --to obs://backups/app/
+
+  nhncloud rds-postgresql dump --db-instance-identifier mypg --username admin \
+    --database app --tables public.users,public.orders --to ./users.sql
+
+  nhncloud rds-mariadb dump --db-instance-identifier mydb --username admin \
+    --database app --schema-only --to - | less`,
+		Run: func(cmd *cobra.Command, args []string) {
+			to, _ := cmd.Flags().GetString("to")
+			tables, _ := cmd.Flags().GetStringSlice("tables")
+			schemaOnly, _ := cmd.Flags().GetBool("schema-only")
+			dataOnly, _ := cmd.Flags().GetBool("data-only")
+			rowsPerInsert, _ := cmd.Flags().GetInt("rows-per-insert")
+			segmentSize, _ := cmd.Flags().GetInt64("segment-size")
+			database, _ := cmd.Flags().GetString("database")
+
+			if database == "" {
+				exitWithError("--database is required", nil)
+			}
+			if schemaOnly && dataOnly {
+				exitWithError("--schema-only and --data-only cannot be used together", nil)
+			}
+			if segmentSize <= 0 {
+				exitWithError("--segment-size must be positive", nil)
+			}
+
+			e := engineFor()
+			conn := openRDSSQLConnection(cmd, e)
+			defer conn.Close()
+
+			ctx := context.Background()
+			name := fmt.Sprintf("%s-%s-%s.sql.gz", conn.Instance.Name, database, time.Now().UTC().Format("20060102-150405"))
+			dest, destination := openRDSDumpDestination(ctx, to, name, segmentSize)
+
+			counter := &countingWriter{w: dest}
+			var w io.Writer = counter
+			var gz *gzip.Writer
+			if strings.HasSuffix(destination, ".gz") {
+				gz = gzip.NewWriter(counter)
+				w = gz
+			}
+
+			opts := sqldump.Options{
+				Tables:        tables,
+				SchemaOnly:    schemaOnly,
+				DataOnly:      dataOnly,
+				RowsPerInsert: rowsPerInsert,
+				Progress:      os.Stderr,
+			}
+			fmt.Fprintf(os.Stderr, "Dumping %s/%s to %s...\n", conn.Instance.Name, database, destination)
+			start := time.Now()
+			var stats *sqldump.Stats
+			var err error
+			if conn.Engine == rdsengine.PostgreSQL {
+				stats, err = sqldump.DumpPostgreSQL(ctx, conn.DB, w, opts)
+			} else {
+				stats, err = sqldump.DumpMySQL(ctx, conn.DB, w, opts)
+			}
+			if err == nil && gz != nil {
+				err = gz.Close()
+			}
+			if err != nil {
+				abortRDSDumpDestination(dest, destination)
+				exitWithError("dump failed", err)
+			}
+			if err := dest.Close(); err != nil {
+				abortRDSDumpDestination(nil, destination)
+				exitWithError("failed to write dump", err)
+			}
+
+			summary := rdsDumpSummary{
+				Destination: destination,
+				Tables:      stats.Tables,
+				Views:       stats.Views,
+				Rows:        stats.Rows,
+				Bytes:       counter.n,
+				Seconds:     time.Since(start).Round(time.Millisecond).Seconds(),
+			}
+			if output == "json" || output == "yaml" {
+				if err := printOutput(summary); err != nil {
+					exitWithError("failed to print summary", err)
+				}
+				return
+			}
+			msg := os.Stdout
+			if destination == "-" {
+				msg = os.Stderr
+			}
+			fmt.Fprintf(msg, "Dump complete: %s\n", destination)
+			fmt.Fprintf(msg, "  Tables: %d, Views: %d, Rows: %d, Size: %d bytes, Time: %.1fs\n",
+				summary.Tables, summary.Views, summary.Rows, summary.Bytes, summary.Seconds)
+		},
+	}
+	addRDSSQLFlags(dump)
+	dump.Flags().String("to", "", "Destination: local file or directory, '-' for stdout, or obs://container/prefix/ (default: current directory)")
+	dump.Flags().StringSlice("tables", nil, "Dump only these tables (table or schema.table, comma-separated)")
+	dump.Flags().Bool("schema-only", false, "Dump only the schema, no data")
+	dump.Flags().Bool("data-only", false, "Dump only the data, no schema")
+	dump.Flags().Int("rows-per-insert", 500, "Rows per INSERT statement")
+	dump.Flags().Int64("segment-size", 100*1024*1024, "Object Storage segment size in bytes")
+
+	restore := &cobra.Command{
+		Use:   "restore",
+		Short: "Restore a logical dump into a database",
+		Long: `Runs the statements of a dump written by 'dump' (or any plain SQL file) on
+one session of the built-in driver, stopping at the first error.
+
+--from is a local file, '-' for stdin, or an Object Storage object
+(obs://container/name). Gzip-compressed input is detected automatically.
+Existing tables of the same name are dropped by the dump, so a restore asks
+for confirmation unless --yes is given. --create-database creates the
+--database first if it does not exist.
+
+Examples:
+  nhncloud rds-mysql restore --db-instance-identifier mydb --username admin \
+    --database app --from obs://backups/app/mydb-app-20250101-000000.sql.gz
+
+  nhncloud rds-postgresql restore --db-instance-identifier mypg --username admin \
+    --database app_copy --create-database --from ./app.sql.gz --yes`,
+		Run: func(cmd *cobra.Command, args []string) {
+			from, _ := cmd.Flags().GetString("from")
+			createDatabase, _ := cmd.Flags().GetBool("create-database")
+			yes, _ := cmd.Flags().GetBool("yes")
+			database, _ := cmd.Flags().GetString("database")
+
+			if from == "" {
+				exitWithError("--from is required", nil)
+			}
+			if database == "" {
+				exitWithError("--database is required", nil)
+			}
+
+			e := engineFor()
+			conn := resolveRDSSQLConnection(cmd, e)
+			defer conn.Close()
+
+			if !yes {
+				if from == "-" {
+					exitWithError("restoring from stdin requires --yes", nil)
+				}
+				if !promptYesNo(fmt.Sprintf("Restore %s into %s/%s? Tables in the dump are replaced", from, conn.Instance.Name, database)) {
+					fmt.Println("Aborted.")
+					return
+				}
+			}
+
+			ctx := context.Background()
+			src := openRDSDumpSource(ctx, from)
+			defer src.Close()
+			reader := bufio.NewReader(src)
+			var r io.Reader = reader
+			if magic, _ := reader.Peek(2); len(magic) == 2 && magic[0] == 0x1f && magic[1] == 0x8b {
+				gz, err := gzip.NewReader(reader)
+				if err != nil {
+					exitWithError("failed to read gzip input", err)
+				}
+				defer gz.Close()
+				r = gz
+			}
+
+			if createDatabase {
+				if err := createRDSDatabase(ctx, conn, database); err != nil {
+					exitWithError("failed to create database", err)
+				}
+			}
+			var err error
+			if conn.DB, err = openRDSDatabase(ctx, conn.Engine, conn.Target); err != nil {
+				exitWithError("failed to connect to database", err)
+			}
+
+			fmt.Fprintf(os.Stderr, "Restoring %s into %s/%s...\n", from, conn.Instance.Name, database)
+			start := time.Now()
+			n, err := sqldump.Restore(ctx, conn.DB, rdsSQLDialect(conn.Engine).Syntax(), r, os.Stderr)
+			if err != nil {
+				exitWithError(fmt.Sprintf("restore failed after %d statements", n), err)
+			}
+			fmt.Printf("Restore complete: %d statements in %.1fs\n", n, time.Since(start).Seconds())
+		},
+	}
+	addRDSSQLFlags(restore)
+	restore.Flags().String("from", "", "Dump to restore: local file, '-' for stdin, or obs://container/name (required)")
+	restore.Flags().Bool("create-database", false, "Create --database if it does not exist")
+	restore.Flags().Bool("yes", false, "Skip the confirmation prompt")
+
+	return []*cobra.Command{dump, restore}
+}
+
+// openRDSDumpDestination opens the dump destination for --to; name is used
+// when to is empty or a directory. It returns the writer and the resolved
+// destination.
+func openRDSDumpDestination(ctx context.Context, to, name string, segmentSize int64) (io.WriteCloser, string) {
+	if to == "-" {
+		return nopWriteCloser{os.Stdout}, "-"
+	}
+	path, err := parseOBSPath(to)
+	if err != nil {
+		exitWithError("invalid --to", err)
+	}
+	if path.IsRemote {
+		object := path.Object
+		if object == "" || strings.HasSuffix(object, "/") {
+			object += name
+		}
+		client := getObjectStorageClient()
+		return newOBSStreamWriter(ctx, client, path.Container, object, segmentSize),
+			fmt.Sprintf("obs://%s/%s", path.Container, object)
+	}
+
+	file := to
+	if info, err := os.Stat(to); to == "" || strings.HasSuffix(to, "/") || (err == nil && info.IsDir()) {
+		file = filepath.Join(to, name)
+	}
+	if dir := filepath.Dir(file); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			exitWithError("failed to create directory", err)
+		}
+	}
+	f, err := os.Create(file)
+	if err != nil {
+		exitWithError("failed to create dump file", err)
+	}
+	return f, file
+}
+
+// abortRDSDumpDestination cleans up after a failed dump: a partial local file
+// is removed; uploaded Object Storage segments are left without a manifest
+func abortRDSDumpDestination(dest io.Closer, destination string) {
+	switch {
+	case destination == "-":
+	case strings.HasPrefix(destination, "obs://"):
+		fmt.Fprintf(os.Stderr, "Note: segments already uploaded for %s were left in the _segments container\n", destination)
+	default:
+		if dest != nil {
+			dest.Close()
+		}
+		os.Remove(destination)
+	}
+}
+
+type nopWriteCloser struct{ io.Writer }
+
+func (nopWriteCloser) Close() error { return nil }
+
+// openRDSDumpSource opens the dump to restore for --from
+func openRDSDumpSource(ctx context.Context, from string) io.ReadCloser {
+	if from == "-" {
+		return io.NopCloser(os.Stdin)
+	}
+	path, err := parseOBSPath(from)
+	if err != nil {
+		exitWithError("invalid --from", err)
+	}
+	if path.IsRemote {
+		if path.Object == "" || strings.HasSuffix(path.Object, "/") {
+			exitWithError("--from must name an object, not a container or prefix", nil)
+		}
+		out, err := getObjectStorageClient().GetObject(ctx, path.Container, path.Object)
+		if err != nil {
+			exitWithError("failed to download dump", err)
+		}
+		return out.Body
+	}
+	f, err := os.Open(from)
+	if err != nil {
+		exitWithError("failed to open dump file", err)
+	}
+	return f
+}
+
+// createRDSDatabase creates the target database of conn if it does not exist,
+// connected to the engine's default database
+func createRDSDatabase(ctx context.Context, conn *rdsSQLConnection, database string) error {
+	admin := conn.Target
+	admin.Database = ""
+	db, err := openRDSDatabase(ctx, conn.Engine, admin)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if conn.Engine != rdsengine.PostgreSQL {
+		_, err = db.ExecContext(ctx, "CREATE DATABASE IF NOT EXISTS `"+strings.ReplaceAll(database, "`", "``")+"`")
+		return err
+	}
+	var exists bool
+	if err := db.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM pg_catalog.pg_database WHERE datname = $1)", database).Scan(&exists); err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	if exists {
+		return nil
+	}
+	_, err = db.ExecContext(ctx, `CREATE DATABASE "`+strings.ReplaceAll(database, `"`, `""`)+`"`)
+	return err
+}
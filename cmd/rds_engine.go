@@ -0,0 +1,837 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/haung921209/nhn-cloud-cli/internal/rdsengine"
+	"github.com/haung921209/nhn-cloud-sdk-go/nhncloud/database/mariadb"
+	"github.com/haung921209/nhn-cloud-sdk-go/nhncloud/database/mysql"
+	"github.com/haung921209/nhn-cloud-sdk-go/nhncloud/database/postgresql"
+	"github.com/spf13/cobra"
+)
+
+// ============================================================================
+// Engine-agnostic RDS commands
+//
+// Every verb below is implemented once against rdsengine.Engine and is
+// available as:
+//
+//	nhncloud rds --engine mysql|mariadb|postgresql <verb>
+//	nhncloud rds-mysql|rds-mariadb|rds-postgresql <verb>
+//
+// The engine-specific trees only implement engine-only verbs themselves;
+// every verb below is added to them in init.
+// ============================================================================
+
+var rdsEngine string
+
+var rdsCmd = &cobra.Command{
+	Use:   "rds",
+	Short: "Manage RDS instances of any engine",
+	Long: `Engine-agnostic RDS commands. Select the engine with --engine (or the
+NHN_CLOUD_RDS_ENGINE environment variable); the engine-specific trees
+rds-mysql, rds-mariadb and rds-postgresql provide the same verbs plus
+engine-only features.
+
+Examples:
+  nhncloud rds --engine mysql describe-db-instances
+  nhncloud rds --engine postgresql wait-db-instance --db-instance-identifier mydb --for-state AVAILABLE
+  NHN_CLOUD_RDS_ENGINE=mariadb nhncloud rds show-db-endpoint --db-instance-identifier mydb`,
+}
+
+func init() {
+	rootCmd.AddCommand(rdsCmd)
+	rdsCmd.PersistentFlags().StringVar(&rdsEngine, "engine", os.Getenv("NHN_CLOUD_RDS_ENGINE"), "Database engine: mysql, mariadb or postgresql")
+
+	rdsCmd.AddCommand(newRDSEngineCommands(func() rdsengine.Engine {
+		name, err := rdsengine.NormalizeName(rdsEngine)
+		if err != nil {
+			exitWithError("invalid --engine", err)
+		}
+		return newRDSEngine(name)
+	}, true)...)
+
+	// rds and rds-mysql require --yes to delete an instance; rds-mariadb and
+	// rds-postgresql never did, and scripts rely on that
+	for tree, t := range map[*cobra.Command]struct {
+		engine        string
+		confirmDelete bool
+	}{
+		rdsMySQLCmd:      {rdsengine.MySQL, true},
+		rdsMariaDBCmd:    {rdsengine.MariaDB, false},
+		rdsPostgreSQLCmd: {rdsengine.PostgreSQL, false},
+	} {
+		engine := t.engine
+		tree.AddCommand(newRDSEngineCommands(func() rdsengine.Engine { return newRDSEngine(engine) }, t.confirmDelete)...)
+	}
+}
+
+// newRDSEngine returns the engine adapter for a normalized engine name
+func newRDSEngine(name string) rdsengine.Engine {
+	switch name {
+	case rdsengine.MySQL:
+		return rdsengine.NewMySQL(newMySQLClient())
+	case rdsengine.MariaDB:
+		return rdsengine.NewMariaDB(newMariaDBClient())
+	case rdsengine.PostgreSQL:
+		return rdsengine.NewPostgreSQL(newPostgreSQLClient())
+	}
+	exitWithError(fmt.Sprintf("unknown engine '%s'", name), nil)
+	return nil
+}
+
+// rdsInstanceFilterAliases are the short --filter fields of the
+// engine-agnostic describe-db-instances
+var rdsInstanceFilterAliases = filterAliases{
+	"name":    "dbInstanceName",
+	"id":      "dbInstanceId",
+	"status":  "dbInstanceStatus",
+	"version": "dbVersion",
+	"flavor":  "dbFlavorName",
+	"type":    "dbInstanceType",
+	"az":      "availabilityZone",
+	"subnet":  "subnetId",
+	"created": "createdAt",
+	// The SDK field paths printed by -o json
+	"network.availabilityzone": "availabilityZone",
+	"network.subnetid":         "subnetId",
+	"network.usepublicaccess":  "usePublicAccess",
+	"createdymdt":              "createdAt",
+}
+
+// rdsSDKInstanceFilterAliases are the short --filter fields for the SDK
+// instance types, which nest the network settings
+func rdsSDKInstanceFilterAliases(engine string) filterAliases {
+	aliases := filterAliases{
+		"name":    "dbInstanceName",
+		"id":      "dbInstanceId",
+		"status":  "dbInstanceStatus",
+		"version": "dbVersion",
+		"flavor":  "dbFlavorName",
+		"type":    "dbInstanceType",
+		"az":      "network.availabilityZone",
+		"subnet":  "network.subnetId",
+		"created": "createdAt",
+	}
+	if engine == rdsengine.MySQL {
+		aliases["created"] = "createdYmdt"
+	}
+	return aliases
+}
+
+// printRDSInstancesJSON prints describe-db-instances -o json as the SDK
+// response of the engine: {header, dbInstances: [...]}, or the header and
+// fields of one instance. Scripts read it with .dbInstances[].
+func printRDSInstancesJSON(ctx context.Context, engine, instanceID string) {
+	var result interface{}
+	var err error
+	aliases := rdsSDKInstanceFilterAliases(engine)
+	switch engine {
+	case rdsengine.MySQL:
+		client := newMySQLClient()
+		if instanceID != "" {
+			result, err = client.GetInstance(ctx, instanceID)
+			break
+		}
+		var list *mysql.ListInstancesResponse
+		if list, err = client.ListInstances(ctx); err == nil {
+			list.DBInstances = filterItems(list.DBInstances, aliases)
+		}
+		result = list
+	case rdsengine.MariaDB:
+		client := newMariaDBClient()
+		if instanceID != "" {
+			result, err = client.GetInstance(ctx, instanceID)
+			break
+		}
+		var list *mariadb.ListInstancesResponse
+		if list, err = client.ListInstances(ctx); err == nil {
+			list.DBInstances = filterItems(list.DBInstances, aliases)
+		}
+		result = list
+	case rdsengine.PostgreSQL:
+		client := newPostgreSQLClient()
+		if instanceID != "" {
+			result, err = client.GetInstance(ctx, instanceID)
+			break
+		}
+		var list *postgresql.ListInstancesResponse
+		if list, err = client.ListInstances(ctx); err == nil {
+			list.DBInstances = filterItems(list.DBInstances, aliases)
+		}
+		result = list
+	}
+	if err != nil {
+		exitWithError("failed to describe instances", err)
+	}
+	printJSON(result)
+}
+
+// resolveRDSInstanceID resolves --db-instance-identifier (name or ID)
+func resolveRDSInstanceID(cmd *cobra.Command, e rdsengine.Engine) string {
+	identifier, _ := cmd.Flags().GetString("db-instance-identifier")
+	id, err := rdsengine.ResolveInstanceID(context.Background(), e, identifier)
+	if err != nil {
+		exitWithError("failed to resolve instance ID", err)
+	}
+	return id
+}
+
+// waitForRDSInstance polls an instance until its status is want. States
+// containing FAIL are terminal and returned as an error. Progress lines go
+// to progress when it is not nil.
+func waitForRDSInstance(ctx context.Context, e rdsengine.Engine, instanceID, want string, interval time.Duration, progress io.Writer) (*rdsengine.Instance, error) {
+	want = strings.ToUpper(strings.TrimSpace(want))
+	for {
+		inst, err := e.GetInstance(ctx, instanceID)
+		if err == nil {
+			got := strings.ToUpper(inst.Status)
+			if got == want {
+				return inst, nil
+			}
+			if strings.Contains(got, "FAIL") {
+				return inst, fmt.Errorf("instance %s entered terminal state %s (wanted %s)", instanceID, got, want)
+			}
+			if progress != nil {
+				fmt.Fprintf(progress, "instance %s state=%s (wanted %s) — sleeping %s...\n", instanceID, got, want, interval)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			if err != nil {
+				return nil, fmt.Errorf("timed out while polling: %w", err)
+			}
+			return nil, fmt.Errorf("timed out waiting for %s", want)
+		case <-time.After(interval):
+		}
+	}
+}
+
+// rdsJobIdlePolls is how many consecutive idle polls waitForRDSJob accepts
+// as a finished job when it never saw the instance busy (the job finished
+// between two polls)
+const rdsJobIdlePolls = 3
+
+// waitForRDSJob waits for a job started on an instance to finish: the
+// instance's progress status, having been busy, is back to NONE. States
+// containing FAIL are returned as an error. Progress lines go to progress
+// when it is not nil.
+func waitForRDSJob(ctx context.Context, e rdsengine.Engine, instanceID string, interval time.Duration, progress io.Writer) error {
+	busySeen := false
+	idle := 0
+	for {
+		inst, err := e.GetInstance(ctx, instanceID)
+		if err == nil {
+			if strings.Contains(strings.ToUpper(inst.Status), "FAIL") {
+				return fmt.Errorf("instance %s entered state %s", instanceID, inst.Status)
+			}
+			if inst.ProgressStatus != "" && !strings.EqualFold(inst.ProgressStatus, "NONE") {
+				busySeen = true
+				idle = 0
+				if progress != nil {
+					fmt.Fprintf(progress, "instance %s progress=%s — waiting...\n", instanceID, inst.ProgressStatus)
+				}
+			} else {
+				idle++
+				if busySeen || idle >= rdsJobIdlePolls {
+					return nil
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for the job on instance %s", instanceID)
+		case <-time.After(interval):
+		}
+	}
+}
+
+// rdsEndpointHost picks the host clients outside the VPC should use: the
+// EXTERNAL endpoint, then the instance's domain, floating IP, public IP or
+// IP address, then any endpoint
+func rdsEndpointHost(inst *rdsengine.Instance, endpoints []rdsengine.Endpoint) string {
+	for _, ep := range endpoints {
+		if ep.Type == "EXTERNAL" && ep.Host() != "" {
+			return ep.Host()
+		}
+	}
+	if h := firstNonEmpty(inst.Domain, inst.FloatingIP, inst.PublicIP, inst.IPAddress); h != "" {
+		return h
+	}
+	for _, ep := range endpoints {
+		if ep.Host() != "" {
+			return ep.Host()
+		}
+	}
+	return ""
+}
+
+// firstNonEmpty returns the first non-empty string
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// newRDSEngineCommands builds the engine-agnostic verbs. engineFor is called
+// when a command runs, so that credentials are only loaded for the engine
+// actually used. With confirmDelete, delete-db-instance requires --yes.
+func newRDSEngineCommands(engineFor func() rdsengine.Engine, confirmDelete bool) []*cobra.Command {
+	addInstanceFlag := func(c *cobra.Command) *cobra.Command {
+		c.Flags().String("db-instance-identifier", "", "DB instance identifier (name or ID)")
+		return c
+	}
+
+	describeInstances := &cobra.Command{
+		Use:   "describe-db-instances",
+		Short: "Describe DB instances",
+		Long: `Describes one or more DB instances.
+If --db-instance-identifier is specified, describes a specific instance.
+Otherwise, describes all instances.
+
+Filters (--filter, repeatable):
+  name, id, status, version, flavor, type, az, subnet, created
+  and any other JSON field of the instance (e.g. dbPort, network.usePublicAccess)
+
+With -o json the engine's API response is printed as is, e.g.
+{"header": ..., "dbInstances": [...]} for the list.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			e := engineFor()
+			ctx := context.Background()
+
+			if identifier, _ := cmd.Flags().GetString("db-instance-identifier"); identifier != "" {
+				id := resolveRDSInstanceID(cmd, e)
+				if output == "json" {
+					printRDSInstancesJSON(ctx, e.Name(), id)
+					return
+				}
+				inst, err := e.GetInstance(ctx, id)
+				if err != nil {
+					exitWithError("failed to describe instance", err)
+				}
+				fmt.Printf("ID:             %s\n", inst.ID)
+				fmt.Printf("Name:           %s\n", inst.Name)
+				fmt.Printf("Engine:         %s %s\n", e.DisplayName(), inst.Version)
+				fmt.Printf("Status:         %s\n", inst.Status)
+				if inst.ProgressStatus != "" {
+					fmt.Printf("Progress:       %s\n", inst.ProgressStatus)
+				}
+				if inst.Type != "" {
+					fmt.Printf("Type:           %s\n", inst.Type)
+				}
+				fmt.Printf("Flavor:         %s\n", firstNonEmpty(inst.FlavorName, inst.FlavorID))
+				fmt.Printf("Port:           %d\n", inst.Port)
+				fmt.Printf("Storage:        %s %dGB\n", inst.StorageType, inst.StorageSize)
+				fmt.Printf("Subnet:         %s\n", inst.SubnetID)
+				fmt.Printf("AZ:             %s\n", inst.AvailabilityZone)
+				fmt.Printf("Public Access:  %v\n", inst.PublicAccess)
+				if inst.Domain != "" {
+					fmt.Printf("Domain:         %s\n", inst.Domain)
+				}
+				fmt.Printf("Created:        %s\n", inst.CreatedAt)
+				return
+			}
+
+			if output == "json" {
+				printRDSInstancesJSON(ctx, e.Name(), "")
+				return
+			}
+			instances, err := e.ListInstances(ctx)
+			if err != nil {
+				exitWithError("failed to list instances", err)
+			}
+			instances = filterItems(instances, rdsInstanceFilterAliases)
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "ID\tNAME\tSTATUS\tVERSION\tFLAVOR\tPORT\tCREATED")
+			for _, inst := range instances {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\t%s\n",
+					inst.ID, inst.Name, inst.Status, inst.Version, firstNonEmpty(inst.FlavorName, inst.FlavorID), inst.Port, inst.CreatedAt)
+			}
+			w.Flush()
+		},
+	}
+
+	addInstanceFlag(describeInstances)
+
+	instanceAction := func(use, short, done string, action func(rdsengine.Engine, context.Context, string) error) *cobra.Command {
+		return addInstanceFlag(&cobra.Command{
+			Use:   use,
+			Short: short,
+			Run: func(cmd *cobra.Command, args []string) {
+				e := engineFor()
+				id := resolveRDSInstanceID(cmd, e)
+				if err := action(e, context.Background(), id); err != nil {
+					exitWithError(fmt.Sprintf("failed to %s", strings.ToLower(short)), err)
+				}
+				fmt.Printf("%s: %s\n", done, id)
+			},
+		})
+	}
+
+	startInstance := instanceAction("start-db-instance", "Start a DB instance", "DB instance start initiated",
+		func(e rdsengine.Engine, ctx context.Context, id string) error { return e.StartInstance(ctx, id) })
+	stopInstance := instanceAction("stop-db-instance", "Stop a DB instance", "DB instance stop initiated",
+		func(e rdsengine.Engine, ctx context.Context, id string) error { return e.StopInstance(ctx, id) })
+	rebootInstance := addInstanceFlag(&cobra.Command{
+		Use:   "reboot-db-instance",
+		Short: "Reboot a DB instance",
+		Run: func(cmd *cobra.Command, args []string) {
+			e := engineFor()
+			id := resolveRDSInstanceID(cmd, e)
+			var opts rdsengine.RestartOptions
+			opts.UseOnlineFailover, _ = cmd.Flags().GetBool("use-online-failover")
+			opts.ExecuteBackup, _ = cmd.Flags().GetBool("execute-backup")
+			if err := e.RestartInstance(context.Background(), id, opts); err != nil {
+				exitWithError("failed to reboot instance", err)
+			}
+			fmt.Printf("DB instance reboot initiated: %s\n", id)
+		},
+	})
+	rebootInstance.Flags().Bool("use-online-failover", false, "Use online failover for HA instances")
+	rebootInstance.Flags().Bool("execute-backup", false, "Execute backup before reboot")
+
+	deleteLong := `Deletes a DB instance.`
+	if confirmDelete {
+		deleteLong = `Deletes a DB instance. Requires --yes.
+
+Example:
+  nhncloud rds --engine mysql delete-db-instance --db-instance-identifier mydb --yes`
+	}
+	deleteInstance := addInstanceFlag(&cobra.Command{
+		Use:   "delete-db-instance",
+		Short: "Delete a DB instance",
+		Long:  deleteLong,
+		Run: func(cmd *cobra.Command, args []string) {
+			e := engineFor()
+			id := resolveRDSInstanceID(cmd, e)
+			if yes, _ := cmd.Flags().GetBool("yes"); confirmDelete && !yes {
+				exitWithError("refusing to delete without --yes (non-interactive confirmation)", nil)
+			}
+			if err := e.DeleteInstance(context.Background(), id); err != nil {
+				exitWithError("failed to delete instance", err)
+			}
+			fmt.Printf("DB instance deletion initiated: %s\n", id)
+		},
+	})
+	deleteInstance.Flags().Bool("yes", false, "Confirm deletion")
+
+	waitInstance := addInstanceFlag(&cobra.Command{
+		Use:   "wait-db-instance",
+		Short: "Wait for a DB instance to reach a target dbInstanceStatus",
+		Long: `Polls the instance until dbInstanceStatus matches --for-state, or --timeout fires.
+
+Exit codes:
+  0  desired state reached
+  1  timeout elapsed before reaching desired state
+  2  instance entered a terminal-error state (any value containing "FAIL")
+
+Example:
+  nhncloud rds --engine mariadb wait-db-instance --db-instance-identifier mydb --for-state AVAILABLE --timeout 30m`,
+		Run: func(cmd *cobra.Command, args []string) {
+			e := engineFor()
+			id := resolveRDSInstanceID(cmd, e)
+
+			forState, _ := cmd.Flags().GetString("for-state")
+			if forState == "" {
+				exitWithError("--for-state is required", nil)
+			}
+			timeoutStr, _ := cmd.Flags().GetString("timeout")
+			intervalStr, _ := cmd.Flags().GetString("interval")
+			timeout, err := time.ParseDuration(timeoutStr)
+			if err != nil {
+				exitWithError(fmt.Sprintf("invalid --timeout %q", timeoutStr), err)
+			}
+			interval, err := time.ParseDuration(intervalStr)
+			if err != nil {
+				exitWithError(fmt.Sprintf("invalid --interval %q", intervalStr), err)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+			inst, err := waitForRDSInstance(ctx, e, id, forState, interval, os.Stdout)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%v\n", err)
+				if inst != nil {
+					os.Exit(2)
+				}
+				os.Exit(1)
+			}
+			fmt.Printf("instance %s reached state %s\n", id, inst.Status)
+		},
+	})
+	waitInstance.Flags().String("for-state", "", "Target dbInstanceStatus (e.g. AVAILABLE) (required)")
+	waitInstance.Flags().String("timeout", "30m", "Max time to wait (Go duration, e.g. 30m, 1h)")
+	waitInstance.Flags().String("interval", "15s", "Polling interval (Go duration)")
+
+	showEndpoint := addInstanceFlag(&cobra.Command{
+		Use:   "show-db-endpoint",
+		Short: "Print '<host>:<port>' for a DB instance (for shell substitution)",
+		Long: `Prints "<host>:<port>\n" for a DB instance, preferring the EXTERNAL endpoint.
+
+  ENDPOINT=$(nhncloud rds --engine postgresql show-db-endpoint --db-instance-identifier $ID)
+  HOST="${ENDPOINT%:*}"
+  PORT="${ENDPOINT##*:}"`,
+		Run: func(cmd *cobra.Command, args []string) {
+			e := engineFor()
+			ctx := context.Background()
+			id := resolveRDSInstanceID(cmd, e)
+
+			inst, err := e.GetInstance(ctx, id)
+			if err != nil {
+				exitWithError("failed to get instance details", err)
+			}
+			endpoints, _ := e.GetEndpoints(ctx, id)
+			host := rdsEndpointHost(inst, endpoints)
+			if host == "" {
+				exitWithError(fmt.Sprintf("unable to determine endpoint host for %q — instance may not have public access enabled", inst.Name), nil)
+			}
+			fmt.Printf("%s:%d\n", host, inst.Port)
+		},
+	})
+
+	describeFlavors := &cobra.Command{
+		Use:   "describe-db-flavors",
+		Short: "List available DB flavors",
+		Run: func(cmd *cobra.Command, args []string) {
+			flavors, err := engineFor().ListFlavors(context.Background())
+			if err != nil {
+				exitWithError("failed to list flavors", err)
+			}
+			flavors = filterItems(flavors, filterAliases{"name": "dbFlavorName", "id": "dbFlavorId"})
+			if output == "json" {
+				printJSON(flavors)
+				return
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "ID\tNAME\tVCPUS\tRAM (MB)")
+			for _, f := range flavors {
+				fmt.Fprintf(w, "%s\t%s\t%d\t%d\n", f.ID, f.Name, f.VCPUs, f.RAM)
+			}
+			w.Flush()
+		},
+	}
+
+	describeVersions := &cobra.Command{
+		Use:   "describe-db-engine-versions",
+		Short: "List available DB engine versions",
+		Run: func(cmd *cobra.Command, args []string) {
+			versions, err := engineFor().ListVersions(context.Background())
+			if err != nil {
+				exitWithError("failed to list versions", err)
+			}
+			if output == "json" {
+				printJSON(versions)
+				return
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "VERSION\tNAME")
+			for _, v := range versions {
+				fmt.Fprintf(w, "%s\t%s\n", v.Version, v.Name)
+			}
+			w.Flush()
+		},
+	}
+
+	describeStorageTypes := &cobra.Command{
+		Use:   "describe-db-storage-types",
+		Short: "List available storage types",
+		Run: func(cmd *cobra.Command, args []string) {
+			types, err := engineFor().ListStorageTypes(context.Background())
+			if err != nil {
+				exitWithError("failed to list storage types", err)
+			}
+			if output == "json" {
+				printJSON(types)
+				return
+			}
+			for _, t := range types {
+				fmt.Println(t)
+			}
+		},
+	}
+
+	describeSnapshots := addInstanceFlag(&cobra.Command{
+		Use:   "describe-db-snapshots",
+		Short: "List backups (snapshots) of a DB instance",
+		Run: func(cmd *cobra.Command, args []string) {
+			e := engineFor()
+			backups, err := e.ListBackups(context.Background(), resolveRDSInstanceID(cmd, e))
+			if err != nil {
+				exitWithError("failed to list backups", err)
+			}
+			backups = filterItems(backups, filterAliases{"name": "backupName", "id": "backupId", "status": "backupStatus", "type": "backupType", "created": "createdAt"})
+			if snapshot, _ := cmd.Flags().GetString("db-snapshot-identifier"); snapshot != "" {
+				var matched []rdsengine.Backup
+				for _, b := range backups {
+					if b.ID == snapshot || b.Name == snapshot {
+						matched = append(matched, b)
+					}
+				}
+				backups = matched
+			}
+			if output == "json" {
+				printJSON(backups)
+				return
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "BACKUP_ID\tNAME\tTYPE\tSTATUS\tSIZE\tCREATED")
+			for _, b := range backups {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\n", b.ID, b.Name, b.Type, b.Status, b.Size, b.CreatedAt)
+			}
+			w.Flush()
+		},
+	})
+
+	describeSnapshots.Flags().String("db-snapshot-identifier", "", "Only show this backup (name or ID)")
+
+	createSnapshot := addInstanceFlag(&cobra.Command{
+		Use:   "create-db-snapshot",
+		Short: "Create a backup (snapshot) of a DB instance",
+		Run: func(cmd *cobra.Command, args []string) {
+			e := engineFor()
+			id := resolveRDSInstanceID(cmd, e)
+			name, _ := cmd.Flags().GetString("db-snapshot-identifier")
+			if name == "" {
+				exitWithError("--db-snapshot-identifier is required", nil)
+			}
+			jobID, err := e.CreateBackup(context.Background(), id, name)
+			if err != nil {
+				exitWithError("failed to create backup", err)
+			}
+			if output == "json" {
+				printJSON(map[string]string{"jobId": jobID})
+				return
+			}
+			fmt.Printf("Backup creation initiated.\nJob ID: %s\n", jobID)
+		},
+	})
+	createSnapshot.Flags().String("db-snapshot-identifier", "", "Backup name (required)")
+
+	deleteSnapshot := &cobra.Command{
+		Use:   "delete-db-snapshot",
+		Short: "Delete a backup (snapshot)",
+		Run: func(cmd *cobra.Command, args []string) {
+			backupID, _ := cmd.Flags().GetString("db-snapshot-identifier")
+			if backupID == "" {
+				exitWithError("--db-snapshot-identifier is required", nil)
+			}
+			if err := engineFor().DeleteBackup(context.Background(), backupID); err != nil {
+				exitWithError("failed to delete backup", err)
+			}
+			fmt.Printf("Backup deleted: %s\n", backupID)
+		},
+	}
+	deleteSnapshot.Flags().String("db-snapshot-identifier", "", "Backup ID (required)")
+
+	describeParameterGroups := &cobra.Command{
+		Use:   "describe-db-parameter-groups",
+		Short: "List DB parameter groups",
+		Long: `Lists DB parameter groups. With --db-parameter-group-id, shows the
+parameters of one group.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if identifier, _ := cmd.Flags().GetString("db-parameter-group-id"); identifier != "" {
+				group := resolveRDSParameterGroup(context.Background(), engineFor(), identifier)
+				if output == "json" {
+					printJSON(group)
+					return
+				}
+				fmt.Printf("ID:          %s\n", group.ID)
+				fmt.Printf("Name:        %s\n", group.Name)
+				fmt.Printf("Version:     %s\n", group.Version)
+				fmt.Printf("Description: %s\n\n", group.Description)
+				w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+				fmt.Fprintln(w, "NAME\tVALUE\tDEFAULT\tMODIFIABLE\tAPPLY_TYPE")
+				for _, p := range group.Parameters {
+					fmt.Fprintf(w, "%s\t%s\t%s\t%v\t%s\n", p.Name, p.Value, p.DefaultValue, p.Modifiable, p.ApplyType)
+				}
+				w.Flush()
+				return
+			}
+			groups, err := engineFor().ListParameterGroups(context.Background())
+			if err != nil {
+				exitWithError("failed to list parameter groups", err)
+			}
+			groups = filterItems(groups, filterAliases{"name": "parameterGroupName", "id": "parameterGroupId", "version": "dbVersion"})
+			if output == "json" {
+				printJSON(groups)
+				return
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "ID\tNAME\tVERSION\tDESCRIPTION")
+			for _, g := range groups {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", g.ID, g.Name, g.Version, g.Description)
+			}
+			w.Flush()
+		},
+	}
+
+	describeParameterGroups.Flags().String("db-parameter-group-id", "", "Parameter group to show with its parameters (name or ID)")
+
+	describeSecurityGroups := &cobra.Command{
+		Use:   "describe-db-security-groups",
+		Short: "List DB security groups",
+		Run: func(cmd *cobra.Command, args []string) {
+			groups, err := engineFor().ListSecurityGroups(context.Background())
+			if err != nil {
+				exitWithError("failed to list security groups", err)
+			}
+			groups = filterItems(groups, filterAliases{"name": "dbSecurityGroupName", "id": "dbSecurityGroupId"})
+			if output == "json" {
+				printJSON(groups)
+				return
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "ID\tNAME\tRULES\tDESCRIPTION")
+			for _, g := range groups {
+				fmt.Fprintf(w, "%s\t%s\t%d\t%s\n", g.ID, g.Name, g.Rules, g.Description)
+			}
+			w.Flush()
+		},
+	}
+
+	describeUsers := addInstanceFlag(&cobra.Command{
+		Use:   "describe-db-users",
+		Short: "List DB users of an instance",
+		Run: func(cmd *cobra.Command, args []string) {
+			e := engineFor()
+			users, err := e.ListUsers(context.Background(), resolveRDSInstanceID(cmd, e))
+			if err != nil {
+				exitWithError("failed to list DB users", err)
+			}
+			if output == "json" {
+				printJSON(users)
+				return
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "USER_ID\tNAME\tHOST\tAUTHORITY")
+			for _, u := range users {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", u.ID, u.Name, u.Host, u.AuthorityType)
+			}
+			w.Flush()
+		},
+	})
+
+	describeMetrics := &cobra.Command{
+		Use:   "describe-metrics",
+		Short: "List available metrics",
+		Run: func(cmd *cobra.Command, args []string) {
+			metrics, err := engineFor().ListMetrics(context.Background())
+			if err != nil {
+				exitWithError("failed to list metrics", err)
+			}
+			if output == "json" {
+				printJSON(metrics)
+				return
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "METRIC\tUNIT")
+			for _, m := range metrics {
+				fmt.Fprintf(w, "%s\t%s\n", m.Name, m.Unit)
+			}
+			w.Flush()
+		},
+	}
+
+	getMetricStatistics := addInstanceFlag(&cobra.Command{
+		Use:   "get-metric-statistics",
+		Short: "Get metric statistics for an instance",
+		Run: func(cmd *cobra.Command, args []string) {
+			e := engineFor()
+			id := resolveRDSInstanceID(cmd, e)
+			from, _ := cmd.Flags().GetString("from")
+			to, _ := cmd.Flags().GetString("to")
+			interval, _ := cmd.Flags().GetInt("interval")
+			if from == "" || to == "" {
+				exitWithError("--from and --to are required (ISO8601 format)", nil)
+			}
+
+			series, err := e.GetMetricStatistics(context.Background(), id, from, to, interval)
+			if err != nil {
+				exitWithError("failed to get metric statistics", err)
+			}
+			if output == "json" {
+				printJSON(series)
+				return
+			}
+			for _, s := range series {
+				fmt.Printf("Metric: %s (%s)\n", s.Name, s.Unit)
+				w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+				fmt.Fprintln(w, "  TIMESTAMP\tVALUE")
+				for _, p := range s.Points {
+					fmt.Fprintf(w, "  %s\t%.2f\n", p.Timestamp, p.Value)
+				}
+				w.Flush()
+				fmt.Println()
+			}
+		},
+	})
+	getMetricStatistics.Flags().String("from", "", "Start time (ISO8601 format, required)")
+	getMetricStatistics.Flags().String("to", "", "End time (ISO8601 format, required)")
+	getMetricStatistics.Flags().Int("interval", 60, "Interval in seconds (1, 5, 30, 60)")
+
+	describeLogs := addInstanceFlag(&cobra.Command{
+		Use:   "describe-logs",
+		Short: "List log files of a DB instance",
+		Run: func(cmd *cobra.Command, args []string) {
+			e := engineFor()
+			files, err := e.ListLogFiles(context.Background(), resolveRDSInstanceID(cmd, e))
+			if err != nil {
+				exitWithError("failed to list log files", err)
+			}
+			if output == "json" {
+				printJSON(files)
+				return
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "LOG_FILE\tSIZE\tMODIFIED")
+			for _, f := range files {
+				fmt.Fprintf(w, "%s\t%d\t%s\n", f.Name, f.Size, f.ModifiedAt)
+			}
+			w.Flush()
+		},
+	})
+
+	cmds := []*cobra.Command{
+		describeInstances,
+		startInstance,
+		stopInstance,
+		rebootInstance,
+		deleteInstance,
+		waitInstance,
+		showEndpoint,
+		describeFlavors,
+		describeVersions,
+		describeStorageTypes,
+		describeSnapshots,
+		createSnapshot,
+		deleteSnapshot,
+		describeParameterGroups,
+		describeSecurityGroups,
+		describeUsers,
+		describeMetrics,
+		getMetricStatistics,
+		describeLogs,
+		newRDSTopCmd(engineFor),
+		newRDSQueryCmd(engineFor),
+		newRDSPruneCmd(engineFor),
+		newRDSTopologyCmd(engineFor),
+		newRDSConnectionStringCmd(engineFor),
+		newRDSInstanceSpecCmd(engineFor),
+	}
+	cmds = append(cmds, newRDSParameterCommands(engineFor)...)
+	cmds = append(cmds, newRDSDumpCommands(engineFor)...)
+	cmds = append(cmds, newRDSRestoreCommands(engineFor)...)
+	cmds = append(cmds, newRDSLogCommands(engineFor)...)
+	return cmds
+}
@@ -0,0 +1,830 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/haung921209/nhn-cloud-cli/internal/rdsengine"
+	"github.com/haung921209/nhn-cloud-sdk-go/nhncloud/core"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// ============================================================================
+// Instance specs (export-db-instance-spec / create-db-instance --from-spec)
+// ============================================================================
+
+// rdsInstanceSpec describes a DB instance with its parameter group and DB
+// security groups. Region-specific IDs are not kept: the flavor, subnet and
+// notification groups are referred to by name and resolved where the spec is
+// created.
+type rdsInstanceSpec struct {
+	Engine               string                 `yaml:"engine"`
+	SourceRegion         string                 `yaml:"sourceRegion,omitempty"`
+	DBInstanceName       string                 `yaml:"dbInstanceName"`
+	Description          string                 `yaml:"description,omitempty"`
+	DBVersion            string                 `yaml:"dbVersion"`
+	DBFlavorName         string                 `yaml:"dbFlavorName"`
+	DBPort               int                    `yaml:"dbPort,omitempty"`
+	DatabaseName         string                 `yaml:"databaseName,omitempty"`
+	AdminUser            rdsSpecAdminUser       `yaml:"adminUser"`
+	Network              rdsSpecNetwork         `yaml:"network"`
+	Storage              rdsSpecStorage         `yaml:"storage"`
+	HighAvailability     bool                   `yaml:"highAvailability"`
+	DeletionProtection   bool                   `yaml:"deletionProtection"`
+	AuthenticationPlugin string                 `yaml:"authenticationPlugin,omitempty"`
+	Backup               rdsSpecBackup          `yaml:"backup"`
+	ParameterGroup       *rdsParameterGroupSpec `yaml:"parameterGroup,omitempty"`
+	SecurityGroups       []rdsSpecSecurityGroup `yaml:"securityGroups,omitempty"`
+	NotificationGroups   []string               `yaml:"notificationGroups,omitempty"`
+}
+
+// rdsSpecAdminUser is the user created with the instance; the password is
+// never written to the spec
+type rdsSpecAdminUser struct {
+	Name     string             `yaml:"name"`
+	Password *rdsPasswordSource `yaml:"password,omitempty"`
+}
+
+type rdsSpecNetwork struct {
+	SubnetName       string `yaml:"subnetName,omitempty"`
+	SubnetID         string `yaml:"subnetId,omitempty"`
+	AvailabilityZone string `yaml:"availabilityZone,omitempty"`
+	UsePublicAccess  bool   `yaml:"usePublicAccess"`
+}
+
+type rdsSpecStorage struct {
+	StorageType      string               `json:"storageType" yaml:"storageType"`
+	StorageSize      int                  `json:"storageSize" yaml:"storageSize"`
+	StorageAutoscale *rdsStorageAutoscale `json:"storageAutoscale,omitempty" yaml:"storageAutoscale,omitempty"`
+}
+
+type rdsStorageAutoscale struct {
+	UseStorageAutoscale *bool `json:"useStorageAutoscale,omitempty" yaml:"useStorageAutoscale,omitempty"`
+	Threshold           *int  `json:"threshold,omitempty" yaml:"threshold,omitempty"`
+	MaxStorageSize      *int  `json:"maxStorageSize,omitempty" yaml:"maxStorageSize,omitempty"`
+	CooldownTime        *int  `json:"cooldownTime,omitempty" yaml:"cooldownTime,omitempty"`
+}
+
+type rdsSpecBackup struct {
+	BackupPeriod     int                `json:"backupPeriod" yaml:"backupPeriod"`
+	BackupRetryCount int                `json:"backupRetryCount,omitempty" yaml:"backupRetryCount,omitempty"`
+	BackupSchedules  []rdsBackupWindows `json:"backupSchedules,omitempty" yaml:"backupSchedules,omitempty"`
+}
+
+type rdsSpecSecurityGroup struct {
+	Name        string            `yaml:"name"`
+	Description string            `yaml:"description,omitempty"`
+	Rules       []rdsSecurityRule `yaml:"rules"`
+}
+
+type rdsSecurityRule struct {
+	Description string          `json:"description,omitempty" yaml:"description,omitempty"`
+	Direction   string          `json:"direction" yaml:"direction"`
+	EtherType   string          `json:"etherType" yaml:"etherType"`
+	Port        rdsSecurityPort `json:"port" yaml:"port"`
+	CIDR        string          `json:"cidr" yaml:"cidr"`
+}
+
+type rdsSecurityPort struct {
+	PortType string `json:"portType" yaml:"portType"`
+	MinPort  *int   `json:"minPort,omitempty" yaml:"minPort,omitempty"`
+	MaxPort  *int   `json:"maxPort,omitempty" yaml:"maxPort,omitempty"`
+}
+
+// Raw API responses; the SDK types differ between the engines
+
+type rdsInstanceDetail struct {
+	Header                core.ResponseHeader `json:"header"`
+	DBInstanceName        string              `json:"dbInstanceName"`
+	Description           string              `json:"description"`
+	DBVersion             string              `json:"dbVersion"`
+	DBPort                int                 `json:"dbPort"`
+	DBFlavorID            string              `json:"dbFlavorId"`
+	ParameterGroupID      string              `json:"parameterGroupId"`
+	DBSecurityGroupIDs    []string            `json:"dbSecurityGroupIds"`
+	NotificationGroupIDs  []string            `json:"notificationGroupIds"`
+	UseDeletionProtection bool                `json:"useDeletionProtection"`
+	AuthenticationPlugin  string              `json:"authenticationPlugin"`
+}
+
+// GetHeader implements core.WithHeader
+func (r *rdsInstanceDetail) GetHeader() *core.ResponseHeader {
+	return &r.Header
+}
+
+type rdsNetworkInfo struct {
+	Header           core.ResponseHeader `json:"header"`
+	AvailabilityZone string              `json:"availabilityZone"`
+	Subnet           rdsSubnet           `json:"subnet"`
+}
+
+// GetHeader implements core.WithHeader
+func (r *rdsNetworkInfo) GetHeader() *core.ResponseHeader {
+	return &r.Header
+}
+
+type rdsSubnet struct {
+	ID   string `json:"subnetId"`
+	Name string `json:"subnetName"`
+}
+
+type rdsSubnetList struct {
+	Header  core.ResponseHeader `json:"header"`
+	Subnets []rdsSubnet         `json:"subnets"`
+}
+
+// GetHeader implements core.WithHeader
+func (r *rdsSubnetList) GetHeader() *core.ResponseHeader {
+	return &r.Header
+}
+
+type rdsStorageInfo struct {
+	Header           core.ResponseHeader  `json:"header"`
+	StorageType      string               `json:"storageType"`
+	StorageSize      int                  `json:"storageSize"`
+	StorageAutoscale *rdsStorageAutoscale `json:"storageAutoscale"`
+}
+
+// GetHeader implements core.WithHeader
+func (r *rdsStorageInfo) GetHeader() *core.ResponseHeader {
+	return &r.Header
+}
+
+type rdsBackupInfo struct {
+	Header core.ResponseHeader `json:"header"`
+	rdsSpecBackup
+}
+
+// GetHeader implements core.WithHeader
+func (r *rdsBackupInfo) GetHeader() *core.ResponseHeader {
+	return &r.Header
+}
+
+type rdsSecurityGroupDetail struct {
+	Header          core.ResponseHeader `json:"header"`
+	DBSecurityGroup struct {
+		Name        string            `json:"dbSecurityGroupName"`
+		Description string            `json:"description"`
+		Rules       []rdsSecurityRule `json:"rules"`
+	} `json:"dbSecurityGroup"`
+}
+
+// GetHeader implements core.WithHeader
+func (r *rdsSecurityGroupDetail) GetHeader() *core.ResponseHeader {
+	return &r.Header
+}
+
+type rdsCreateSecurityGroupRequest struct {
+	Name        string            `json:"dbSecurityGroupName"`
+	Description string            `json:"description,omitempty"`
+	Rules       []rdsSecurityRule `json:"rules"`
+}
+
+type rdsCreateSecurityGroupResponse struct {
+	Header core.ResponseHeader `json:"header"`
+	ID     string              `json:"dbSecurityGroupId"`
+}
+
+// GetHeader implements core.WithHeader
+func (r *rdsCreateSecurityGroupResponse) GetHeader() *core.ResponseHeader {
+	return &r.Header
+}
+
+type rdsNotificationGroupList struct {
+	Header             core.ResponseHeader `json:"header"`
+	NotificationGroups []struct {
+		ID   string `json:"notificationGroupId"`
+		Name string `json:"notificationGroupName"`
+	} `json:"notificationGroups"`
+}
+
+// GetHeader implements core.WithHeader
+func (r *rdsNotificationGroupList) GetHeader() *core.ResponseHeader {
+	return &r.Header
+}
+
+type rdsDatabaseList struct {
+	Header    core.ResponseHeader `json:"header"`
+	Databases []struct {
+		Name string `json:"databaseName"`
+	} `json:"databases"`
+}
+
+// GetHeader implements core.WithHeader
+func (r *rdsDatabaseList) GetHeader() *core.ResponseHeader {
+	return &r.Header
+}
+
+// rdsCreateInstanceRequest is the create request common to the engines;
+// databaseName is PostgreSQL only, authenticationPlugin MySQL only
+type rdsCreateInstanceRequest struct {
+	DBInstanceName        string            `json:"dbInstanceName"`
+	Description           string            `json:"description,omitempty"`
+	DatabaseName          string            `json:"databaseName,omitempty"`
+	DBFlavorID            string            `json:"dbFlavorId"`
+	DBVersion             string            `json:"dbVersion"`
+	DBUserName            string            `json:"dbUserName"`
+	DBPassword            string            `json:"dbPassword"`
+	DBPort                int               `json:"dbPort,omitempty"`
+	ParameterGroupID      string            `json:"parameterGroupId"`
+	DBSecurityGroupIDs    []string          `json:"dbSecurityGroupIds,omitempty"`
+	NotificationGroupIDs  []string          `json:"notificationGroupIds,omitempty"`
+	Network               rdsRestoreNetwork `json:"network"`
+	Storage               rdsSpecStorage    `json:"storage"`
+	Backup                rdsSpecBackup     `json:"backup"`
+	UseHighAvailability   bool              `json:"useHighAvailability"`
+	UseDeletionProtection bool              `json:"useDeletionProtection"`
+	AuthenticationPlugin  string            `json:"authenticationPlugin,omitempty"`
+}
+
+// exportRDSInstanceSpec reads an instance and its dependencies into a spec
+func exportRDSInstanceSpec(ctx context.Context, e rdsengine.Engine, instanceID string) (*rdsInstanceSpec, error) {
+	api := newRDSAPI(e.Name())
+	var detail rdsInstanceDetail
+	if err := api.Do(ctx, http.MethodGet, "/db-instances/"+instanceID, nil, &detail); err != nil {
+		return nil, fmt.Errorf("get instance: %w", err)
+	}
+	spec := &rdsInstanceSpec{
+		Engine:               e.Name(),
+		SourceRegion:         api.region,
+		DBInstanceName:       detail.DBInstanceName,
+		Description:          detail.Description,
+		DBVersion:            detail.DBVersion,
+		DBPort:               detail.DBPort,
+		DeletionProtection:   detail.UseDeletionProtection,
+		AuthenticationPlugin: detail.AuthenticationPlugin,
+		AdminUser:            rdsSpecAdminUser{Password: &rdsPasswordSource{Env: "NHN_CLOUD_RDS_PASSWORD"}},
+	}
+
+	flavors, err := e.ListFlavors(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list flavors: %w", err)
+	}
+	for _, f := range flavors {
+		if f.ID == detail.DBFlavorID {
+			spec.DBFlavorName = f.Name
+		}
+	}
+	if spec.DBFlavorName == "" {
+		return nil, fmt.Errorf("flavor %s not found", detail.DBFlavorID)
+	}
+
+	var network rdsNetworkInfo
+	if err := api.Do(ctx, http.MethodGet, "/db-instances/"+instanceID+"/network-info", nil, &network); err != nil {
+		return nil, fmt.Errorf("get network: %w", err)
+	}
+	spec.Network = rdsSpecNetwork{
+		SubnetName:       network.Subnet.Name,
+		SubnetID:         network.Subnet.ID,
+		AvailabilityZone: network.AvailabilityZone,
+	}
+	if endpoints, err := e.GetEndpoints(ctx, instanceID); err == nil {
+		for _, ep := range endpoints {
+			if ep.Type == "EXTERNAL" {
+				spec.Network.UsePublicAccess = true
+			}
+		}
+	}
+
+	var storage rdsStorageInfo
+	if err := api.Do(ctx, http.MethodGet, "/db-instances/"+instanceID+"/storage-info", nil, &storage); err != nil {
+		return nil, fmt.Errorf("get storage: %w", err)
+	}
+	spec.Storage = rdsSpecStorage{StorageType: storage.StorageType, StorageSize: storage.StorageSize, StorageAutoscale: storage.StorageAutoscale}
+
+	var backup rdsBackupInfo
+	if err := api.Do(ctx, http.MethodGet, "/db-instances/"+instanceID+"/backup-info", nil, &backup); err != nil {
+		return nil, fmt.Errorf("get backup settings: %w", err)
+	}
+	spec.Backup = backup.rdsSpecBackup
+
+	// A high availability instance has a candidate master in its group
+	var members rdsGroupMemberList
+	if err := api.Do(ctx, http.MethodGet, "/db-instances", nil, &members); err == nil {
+		groupOf := map[string]string{}
+		for _, m := range members.DBInstances {
+			groupOf[m.ID] = m.GroupID
+		}
+		for _, m := range members.DBInstances {
+			if m.GroupID != "" && m.GroupID == groupOf[instanceID] && rdsTopologyRole(m.Type) == "ha-standby" {
+				spec.HighAvailability = true
+			}
+		}
+	}
+
+	if e.Name() == rdsengine.PostgreSQL {
+		var databases rdsDatabaseList
+		if err := api.Do(ctx, http.MethodGet, "/db-instances/"+instanceID+"/databases", nil, &databases); err == nil && len(databases.Databases) > 0 {
+			spec.DatabaseName = databases.Databases[0].Name
+		}
+	}
+
+	if detail.ParameterGroupID != "" {
+		group, err := e.GetParameterGroup(ctx, detail.ParameterGroupID)
+		if err != nil {
+			return nil, fmt.Errorf("get parameter group: %w", err)
+		}
+		spec.ParameterGroup = &rdsParameterGroupSpec{
+			Engine:      e.Name(),
+			Name:        group.Name,
+			Description: group.Description,
+			DBVersion:   group.Version,
+			Parameters:  map[string]string{},
+		}
+		// Defaults are those of the target region's new group; only the
+		// changed values are carried over
+		for _, p := range group.Parameters {
+			if p.Value != p.DefaultValue {
+				spec.ParameterGroup.Parameters[p.Name] = p.Value
+			}
+		}
+	}
+
+	for _, id := range detail.DBSecurityGroupIDs {
+		var sg rdsSecurityGroupDetail
+		if err := api.Do(ctx, http.MethodGet, "/db-security-groups/"+id, nil, &sg); err != nil {
+			return nil, fmt.Errorf("get DB security group %s: %w", id, err)
+		}
+		spec.SecurityGroups = append(spec.SecurityGroups, rdsSpecSecurityGroup{
+			Name:        sg.DBSecurityGroup.Name,
+			Description: sg.DBSecurityGroup.Description,
+			Rules:       sg.DBSecurityGroup.Rules,
+		})
+	}
+
+	if len(detail.NotificationGroupIDs) > 0 {
+		var groups rdsNotificationGroupList
+		if err := api.Do(ctx, http.MethodGet, "/notification-groups", nil, &groups); err != nil {
+			return nil, fmt.Errorf("list notification groups: %w", err)
+		}
+		for _, g := range groups.NotificationGroups {
+			if slices.Contains(detail.NotificationGroupIDs, g.ID) {
+				spec.NotificationGroups = append(spec.NotificationGroups, g.Name)
+			}
+		}
+	}
+	return spec, nil
+}
+
+func newRDSInstanceSpecCmd(engineFor func() rdsengine.Engine) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "export-db-instance-spec",
+		Short: "Export a DB instance as a create spec",
+		Long: `Writes a YAML spec of a DB instance for 'create-db-instance --from-spec':
+flavor, version, storage, network, high availability, deletion protection,
+backup settings, notification groups, the non-default values of its
+parameter group and the rules of its DB security groups.
+
+The flavor, subnet and notification groups are recorded by name so that the
+spec can be created in another region; availability zones differ between
+regions, so pass --availability-zone there. The administrator password is not
+exported; adminUser.password names where it is read from at creation
+($NHN_CLOUD_RDS_PASSWORD by default), and adminUser.name has to be filled
+in.
+
+Examples:
+  nhncloud rds-mysql export-db-instance-spec --db-instance-identifier prod-db > spec.yaml
+  nhncloud rds-mysql create-db-instance --from-spec spec.yaml --region jp1 --availability-zone jp-pub-a`,
+		Run: func(cmd *cobra.Command, args []string) {
+			e := engineFor()
+			file, _ := cmd.Flags().GetString("file")
+			spec, err := exportRDSInstanceSpec(context.Background(), e, resolveRDSInstanceID(cmd, e))
+			if err != nil {
+				exitWithError("failed to export instance spec", err)
+			}
+			data, err := yaml.Marshal(spec)
+			if err != nil {
+				exitWithError("failed to encode instance spec", err)
+			}
+			fmt.Fprintln(os.Stderr, "Note: set adminUser.name in the spec before creating from it; the password is read from $NHN_CLOUD_RDS_PASSWORD unless adminUser.password says otherwise")
+			if file == "" {
+				os.Stdout.Write(data)
+				return
+			}
+			if err := os.WriteFile(file, data, 0644); err != nil {
+				exitWithError("failed to write file", err)
+			}
+			fmt.Printf("Exported %s to %s\n", spec.DBInstanceName, file)
+		},
+	}
+	c.Flags().String("db-instance-identifier", "", "DB instance identifier (name or ID)")
+	c.Flags().String("file", "", "Output file (default: stdout)")
+	return c
+}
+
+// rdsSpecOverrides are the create-db-instance flags that take precedence
+// over the spec
+type rdsSpecOverrides struct {
+	Name             string
+	UserName         string
+	Password         string
+	SubnetID         string
+	AvailabilityZone string
+}
+
+// addRDSFromSpecFlags adds the --from-spec flags to an engine's
+// create-db-instance command
+func addRDSFromSpecFlags(c *cobra.Command) {
+	c.Flags().String("from-spec", "", "Create from a spec written by export-db-instance-spec (with its parameter group and DB security groups)")
+	c.Flags().Bool("dry-run", false, "With --from-spec, show what would be created")
+	c.Flags().Bool("wait", false, "With --from-spec, wait until the instance is AVAILABLE")
+	c.Flags().String("timeout", "1h", "Max time to wait with --wait (Go duration)")
+}
+
+// runRDSCreateFromSpec handles --from-spec for a create-db-instance command
+// whose name, user and password flags are named as given; it reports
+// whether the flag was set
+func runRDSCreateFromSpec(cmd *cobra.Command, engine, nameFlag, userFlag, passwordFlag string) bool {
+	file, _ := cmd.Flags().GetString("from-spec")
+	if file == "" {
+		return false
+	}
+	var o rdsSpecOverrides
+	o.Name, _ = cmd.Flags().GetString(nameFlag)
+	o.UserName, _ = cmd.Flags().GetString(userFlag)
+	o.Password, _ = cmd.Flags().GetString(passwordFlag)
+	o.SubnetID, _ = cmd.Flags().GetString("subnet-id")
+	o.AvailabilityZone, _ = cmd.Flags().GetString("availability-zone")
+	createRDSInstanceFromSpec(cmd, engine, file, o)
+	return true
+}
+
+// rdsParameterGroupDrift lists how an existing parameter group differs from
+// the one a spec would create: that group has the default values except for
+// the spec's parameters
+func rdsParameterGroupDrift(group *rdsengine.ParameterGroup, version string, values map[string]string) []string {
+	var drift []string
+	if group.Version != version {
+		drift = append(drift, fmt.Sprintf("version %s, spec %s", group.Version, version))
+	}
+	known := map[string]bool{}
+	for _, p := range group.Parameters {
+		known[p.Name] = true
+		want, ok := values[p.Name]
+		if !ok {
+			want = p.DefaultValue
+		}
+		if p.Value != want {
+			drift = append(drift, fmt.Sprintf("%s=%s, spec %s", p.Name, p.Value, want))
+		}
+	}
+	for _, name := range sortedKeys(values) {
+		if !known[name] {
+			drift = append(drift, fmt.Sprintf("unknown parameter %s", name))
+		}
+	}
+	return drift
+}
+
+// rdsSecurityRulesDrift lists the rules missing from have and the extra
+// rules in it, compared with want; descriptions and order are ignored
+func rdsSecurityRulesDrift(have, want []rdsSecurityRule) []string {
+	key := func(r rdsSecurityRule) string {
+		port := r.Port.PortType
+		if r.Port.MinPort != nil || r.Port.MaxPort != nil {
+			var minPort, maxPort int
+			if r.Port.MinPort != nil {
+				minPort = *r.Port.MinPort
+			}
+			if r.Port.MaxPort != nil {
+				maxPort = *r.Port.MaxPort
+			}
+			port = fmt.Sprintf("%s %d-%d", port, minPort, maxPort)
+		}
+		return fmt.Sprintf("%s %s %s %s", r.Direction, r.EtherType, r.CIDR, port)
+	}
+	count := map[string]int{}
+	for _, r := range have {
+		count[key(r)]++
+	}
+	for _, r := range want {
+		count[key(r)]--
+	}
+	var drift []string
+	for _, k := range sortedKeys(count) {
+		switch {
+		case count[k] < 0:
+			drift = append(drift, "missing rule "+k)
+		case count[k] > 0:
+			drift = append(drift, "extra rule "+k)
+		}
+	}
+	return drift
+}
+
+// createRDSInstanceFromSpec creates the parameter group and DB security
+// groups of a spec, reusing those that already exist by name, and then the
+// instance. Existing groups are only reused when their settings match the
+// spec. The groups it created are deleted again if a later step fails.
+func createRDSInstanceFromSpec(cmd *cobra.Command, engine, file string, o rdsSpecOverrides) {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	wait, _ := cmd.Flags().GetBool("wait")
+	timeout := getDurationFlag(cmd, "timeout")
+	ctx := context.Background()
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		exitWithError("failed to read spec", err)
+	}
+	var spec rdsInstanceSpec
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&spec); err != nil {
+		exitWithError(fmt.Sprintf("failed to parse %s", file), err)
+	}
+	if spec.Engine != "" {
+		specEngine, err := rdsengine.NormalizeName(spec.Engine)
+		if err != nil {
+			exitWithError(fmt.Sprintf("invalid engine in %s", file), err)
+		}
+		if specEngine != engine {
+			exitWithError(fmt.Sprintf("%s is a %s instance spec, not %s", file, specEngine, engine), nil)
+		}
+	}
+
+	spec.DBInstanceName = firstNonEmpty(o.Name, spec.DBInstanceName)
+	spec.AdminUser.Name = firstNonEmpty(o.UserName, spec.AdminUser.Name)
+	if o.SubnetID != "" {
+		spec.Network.SubnetID, spec.Network.SubnetName = o.SubnetID, ""
+	}
+	spec.Network.AvailabilityZone = firstNonEmpty(o.AvailabilityZone, spec.Network.AvailabilityZone)
+	switch {
+	case spec.DBInstanceName == "":
+		exitWithError("the spec has no dbInstanceName", nil)
+	case spec.AdminUser.Name == "":
+		exitWithError("set adminUser.name in the spec or pass the user name flag", nil)
+	case spec.DBVersion == "" || spec.DBFlavorName == "":
+		exitWithError("the spec needs dbVersion and dbFlavorName", nil)
+	case engine == rdsengine.PostgreSQL && spec.DatabaseName == "":
+		exitWithError("the spec needs databaseName for PostgreSQL", nil)
+	}
+
+	// Everything that can fail is checked before the first resource is
+	// created, so that a failed run leaves nothing behind
+	password := o.Password
+	if password == "" && spec.AdminUser.Password.isSet() {
+		if password, err = spec.AdminUser.Password.resolve(ctx); err != nil {
+			exitWithError("failed to read the administrator password", err)
+		}
+	}
+	if password == "" {
+		exitWithError("the administrator password is required: set adminUser.password in the spec or pass the password flag", nil)
+	}
+
+	e := newRDSEngine(engine)
+	api := newRDSAPI(engine)
+
+	instances, err := e.ListInstances(ctx)
+	if err != nil {
+		exitWithError("failed to list instances", err)
+	}
+	for _, inst := range instances {
+		if inst.Name == spec.DBInstanceName {
+			exitWithError(fmt.Sprintf("instance %s already exists in %s", spec.DBInstanceName, api.region), nil)
+		}
+	}
+	// Availability zone names belong to a region (kr-pub-a, jp-pub-a), so
+	// the source's zone cannot be used in another one
+	if o.AvailabilityZone == "" && spec.Network.AvailabilityZone != "" &&
+		spec.SourceRegion != "" && !strings.EqualFold(spec.SourceRegion, api.region) {
+		exitWithError(fmt.Sprintf("availability zone %s is in %s, not %s; pass --availability-zone", spec.Network.AvailabilityZone, spec.SourceRegion, api.region), nil)
+	}
+
+	// Region-specific IDs are resolved by name
+	req := rdsCreateInstanceRequest{
+		DBInstanceName:        spec.DBInstanceName,
+		Description:           spec.Description,
+		DatabaseName:          spec.DatabaseName,
+		DBVersion:             spec.DBVersion,
+		DBUserName:            spec.AdminUser.Name,
+		DBPassword:            password,
+		DBPort:                spec.DBPort,
+		Network:               rdsRestoreNetwork{UsePublicAccess: spec.Network.UsePublicAccess, AvailabilityZone: spec.Network.AvailabilityZone},
+		Storage:               spec.Storage,
+		Backup:                spec.Backup,
+		UseHighAvailability:   spec.HighAvailability,
+		UseDeletionProtection: spec.DeletionProtection,
+		AuthenticationPlugin:  spec.AuthenticationPlugin,
+	}
+	flavors, err := e.ListFlavors(ctx)
+	if err != nil {
+		exitWithError("failed to list flavors", err)
+	}
+	for _, f := range flavors {
+		if f.Name == spec.DBFlavorName {
+			req.DBFlavorID = f.ID
+		}
+	}
+	if req.DBFlavorID == "" {
+		exitWithError(fmt.Sprintf("flavor %s is not available in %s", spec.DBFlavorName, api.region), nil)
+	}
+
+	var subnets rdsSubnetList
+	if err := api.Do(ctx, http.MethodGet, "/network/subnets", nil, &subnets); err != nil {
+		exitWithError("failed to list subnets", err)
+	}
+	for _, s := range subnets.Subnets {
+		if s.ID == spec.Network.SubnetID {
+			req.Network.SubnetID = s.ID
+			break
+		}
+		if spec.Network.SubnetName != "" && s.Name == spec.Network.SubnetName && req.Network.SubnetID == "" {
+			req.Network.SubnetID = s.ID
+		}
+	}
+	if req.Network.SubnetID == "" {
+		exitWithError(fmt.Sprintf("subnet %s not found in %s; pass --subnet-id", firstNonEmpty(spec.Network.SubnetName, spec.Network.SubnetID), api.region), nil)
+	}
+
+	var notificationGroups rdsNotificationGroupList
+	if len(spec.NotificationGroups) > 0 {
+		if err := api.Do(ctx, http.MethodGet, "/notification-groups", nil, &notificationGroups); err != nil {
+			exitWithError("failed to list notification groups", err)
+		}
+	}
+	for _, name := range spec.NotificationGroups {
+		found := false
+		for _, g := range notificationGroups.NotificationGroups {
+			if g.Name == name {
+				req.NotificationGroupIDs = append(req.NotificationGroupIDs, g.ID)
+				found = true
+			}
+		}
+		if !found {
+			fmt.Fprintf(os.Stderr, "Warning: notification group %s not found in %s; skipped\n", name, api.region)
+		}
+	}
+
+	// Dependencies. The parameters are checked against an existing group of
+	// the same version before a new group is created.
+	var createParameterGroup bool
+	if spec.ParameterGroup != nil {
+		version := firstNonEmpty(spec.ParameterGroup.DBVersion, spec.DBVersion)
+		groups, err := e.ListParameterGroups(ctx)
+		if err != nil {
+			exitWithError("failed to list parameter groups", err)
+		}
+		var sameVersion string
+		for _, g := range groups {
+			if g.Name == spec.ParameterGroup.Name {
+				req.ParameterGroupID = g.ID
+			}
+			if g.Version == version {
+				sameVersion = g.ID
+			}
+		}
+		switch {
+		case req.ParameterGroupID != "":
+			group, err := e.GetParameterGroup(ctx, req.ParameterGroupID)
+			if err != nil {
+				exitWithError("failed to get parameter group", err)
+			}
+			if drift := rdsParameterGroupDrift(group, version, spec.ParameterGroup.Parameters); len(drift) > 0 {
+				exitWithError(fmt.Sprintf("parameter group %s exists with other settings (%s); rename it in the spec or align it first",
+					spec.ParameterGroup.Name, strings.Join(drift, "; ")), nil)
+			}
+			fmt.Printf("Parameter group %s exists (%s) with the same settings; reusing it\n", spec.ParameterGroup.Name, req.ParameterGroupID)
+		case sameVersion != "":
+			group, err := e.GetParameterGroup(ctx, sameVersion)
+			if err != nil {
+				exitWithError("failed to get parameter group", err)
+			}
+			if _, err := planRDSParameterChanges(group, spec.ParameterGroup.Parameters, false); err != nil {
+				exitWithError("invalid parameters", err)
+			}
+			createParameterGroup = true
+		default:
+			createParameterGroup = true
+		}
+	}
+
+	var existingSecurityGroups []rdsengine.SecurityGroup
+	if len(spec.SecurityGroups) > 0 {
+		if existingSecurityGroups, err = e.ListSecurityGroups(ctx); err != nil {
+			exitWithError("failed to list DB security groups", err)
+		}
+	}
+	for _, sg := range spec.SecurityGroups {
+		for _, g := range existingSecurityGroups {
+			if g.Name != sg.Name {
+				continue
+			}
+			var detail rdsSecurityGroupDetail
+			if err := api.Do(ctx, http.MethodGet, "/db-security-groups/"+g.ID, nil, &detail); err != nil {
+				exitWithError(fmt.Sprintf("failed to get DB security group %s", sg.Name), err)
+			}
+			if drift := rdsSecurityRulesDrift(detail.DBSecurityGroup.Rules, sg.Rules); len(drift) > 0 {
+				exitWithError(fmt.Sprintf("DB security group %s exists with other rules (%s); rename it in the spec or align it first",
+					sg.Name, strings.Join(drift, "; ")), nil)
+			}
+		}
+	}
+
+	// Resources created by this run are deleted again when a later step
+	// fails
+	var created []string
+	fail := func(msg string, err error) {
+		for i := len(created) - 1; i >= 0; i-- {
+			var resp rdsJobResponse
+			if derr := api.Do(ctx, http.MethodDelete, created[i], nil, &resp); derr != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to delete %s: %v\n", created[i], derr)
+			} else {
+				fmt.Fprintf(os.Stderr, "Deleted %s\n", created[i])
+			}
+		}
+		exitWithError(msg, err)
+	}
+
+	if createParameterGroup {
+		if dryRun {
+			fmt.Printf("Would create parameter group %s (%s) with %d changed parameter(s)\n", spec.ParameterGroup.Name, spec.DBVersion, len(spec.ParameterGroup.Parameters))
+		} else {
+			id, err := e.CreateParameterGroup(ctx, spec.ParameterGroup.Name, spec.ParameterGroup.Description, firstNonEmpty(spec.ParameterGroup.DBVersion, spec.DBVersion))
+			if err != nil {
+				fail("failed to create parameter group", err)
+			}
+			created = append(created, "/parameter-groups/"+id)
+			fmt.Printf("Created parameter group %s: %s\n", spec.ParameterGroup.Name, id)
+			group, err := e.GetParameterGroup(ctx, id)
+			if err != nil {
+				fail("failed to get parameter group", err)
+			}
+			changes, err := planRDSParameterChanges(group, spec.ParameterGroup.Parameters, false)
+			if err != nil {
+				fail("invalid parameters", err)
+			}
+			if len(changes) > 0 {
+				values := map[string]string{}
+				for _, c := range changes {
+					values[c.ID] = c.To
+				}
+				if err := e.ModifyParameters(ctx, id, values); err != nil {
+					fail("failed to modify parameters", err)
+				}
+				fmt.Printf("Modified %d parameter(s) in %s\n", len(changes), spec.ParameterGroup.Name)
+			}
+			req.ParameterGroupID = id
+		}
+	}
+
+	for _, sg := range spec.SecurityGroups {
+		id := ""
+		for _, g := range existingSecurityGroups {
+			if g.Name == sg.Name {
+				id = g.ID
+			}
+		}
+		switch {
+		case id != "":
+			fmt.Printf("DB security group %s exists (%s) with the same rules; reusing it\n", sg.Name, id)
+		case dryRun:
+			fmt.Printf("Would create DB security group %s with %d rule(s)\n", sg.Name, len(sg.Rules))
+		default:
+			var resp rdsCreateSecurityGroupResponse
+			if err := api.Do(ctx, http.MethodPost, "/db-security-groups", rdsCreateSecurityGroupRequest{
+				Name:        sg.Name,
+				Description: sg.Description,
+				Rules:       sg.Rules,
+			}, &resp); err != nil {
+				fail(fmt.Sprintf("failed to create DB security group %s", sg.Name), err)
+			}
+			created = append(created, "/db-security-groups/"+resp.ID)
+			fmt.Printf("Created DB security group %s: %s\n", sg.Name, resp.ID)
+			id = resp.ID
+		}
+		if id != "" {
+			req.DBSecurityGroupIDs = append(req.DBSecurityGroupIDs, id)
+		}
+	}
+
+	fmt.Printf("Instance %s: %s %s, %s %d GB, subnet %s, az %s, HA %t\n", req.DBInstanceName, spec.DBVersion, spec.DBFlavorName,
+		req.Storage.StorageType, req.Storage.StorageSize, req.Network.SubnetID, firstNonEmpty(req.Network.AvailabilityZone, "-"), req.UseHighAvailability)
+	if dryRun {
+		fmt.Println("Dry run: nothing created.")
+		return
+	}
+
+	var resp rdsJobResponse
+	if err := api.Do(ctx, http.MethodPost, "/db-instances", req, &resp); err != nil {
+		fail("failed to create instance", err)
+	}
+	fmt.Printf("Instance creation initiated.\n")
+	fmt.Printf("Job ID: %s\n", resp.JobID)
+	if !wait {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	inst, err := waitForRDSInstanceByName(ctx, e, req.DBInstanceName, "AVAILABLE", os.Stderr)
+	if err != nil {
+		exitWithError("instance did not become available", err)
+	}
+	fmt.Printf("DB instance %s (%s) is %s\n", inst.Name, inst.ID, inst.Status)
+}
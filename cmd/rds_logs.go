@@ -0,0 +1,400 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/haung921209/nhn-cloud-cli/internal/rdsengine"
+	"github.com/haung921209/nhn-cloud-cli/internal/slowlog"
+	"github.com/haung921209/nhn-cloud-sdk-go/nhncloud/storage/object"
+	"github.com/spf13/cobra"
+)
+
+// ============================================================================
+// Log file download and slow query analysis
+//
+// The RDS API has no endpoint returning log file contents; a log file is
+// exported into Object Storage (POST /db-instances/{id}/log-files/export),
+// read from there and the staged copy removed.
+// ============================================================================
+
+type rdsLogExportRequest struct {
+	TenantID        string `json:"tenantId"`
+	Username        string `json:"username"`
+	Password        string `json:"password"`
+	TargetContainer string `json:"targetContainer"`
+	ObjectPath      string `json:"objectPath"`
+	LogFileName     string `json:"logFileName"`
+}
+
+// rdsLogStaging exports log files of one instance through an Object Storage
+// path
+type rdsLogStaging struct {
+	engine     rdsengine.Engine
+	instanceID string
+	path       *OBSPath
+	keep       bool
+	client     *object.Client
+}
+
+func newRDSLogStaging(cmd *cobra.Command, e rdsengine.Engine, instanceID string) *rdsLogStaging {
+	staging, _ := cmd.Flags().GetString("staging")
+	keep, _ := cmd.Flags().GetBool("keep-staging")
+	p, err := parseOBSPath(staging)
+	if err != nil || !p.IsRemote {
+		exitWithError("--staging must be an Object Storage path (obs://container/path/)", err)
+	}
+	if getTenantID() == "" || getUsername() == "" || getPassword() == "" {
+		exitWithError("Object Storage credentials are required: set --tenant-id, --username and --password (or NHN_CLOUD_TENANT_ID, NHN_CLOUD_USERNAME, NHN_CLOUD_PASSWORD)", nil)
+	}
+	return &rdsLogStaging{engine: e, instanceID: instanceID, path: p, keep: keep, client: getObjectStorageClient()}
+}
+
+// fetch exports logFile and writes its contents from offset on to w. It
+// returns the size of the exported copy.
+func (s *rdsLogStaging) fetch(ctx context.Context, logFile string, offset int64, w io.Writer) (int64, error) {
+	prefix := path.Join(s.path.Object, s.instanceID, time.Now().UTC().Format("20060102T150405.000000000")) + "/"
+	req := rdsLogExportRequest{
+		TenantID:        getTenantID(),
+		Username:        getUsername(),
+		Password:        getPassword(),
+		TargetContainer: s.path.Container,
+		ObjectPath:      prefix + logFile,
+		LogFileName:     logFile,
+	}
+	var job rdsJobResponse
+	if err := newRDSAPI(s.engine.Name()).Do(ctx, "POST", "/db-instances/"+s.instanceID+"/log-files/export", req, &job); err != nil {
+		return 0, fmt.Errorf("export %s: %w", logFile, err)
+	}
+
+	var staged object.Object
+	for {
+		out, err := s.client.ListObjects(ctx, s.path.Container, &object.ListObjectsInput{Prefix: prefix})
+		if err == nil {
+			for _, o := range out.Objects {
+				if !strings.HasSuffix(o.Name, "/") {
+					staged = o
+					break
+				}
+			}
+		}
+		if staged.Name != "" {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return 0, fmt.Errorf("timed out waiting for %s in obs://%s/%s", logFile, s.path.Container, prefix)
+		case <-time.After(5 * time.Second):
+		}
+	}
+	if !s.keep {
+		defer s.client.DeleteObject(context.Background(), s.path.Container, staged.Name)
+	}
+
+	out, err := s.client.GetObject(ctx, s.path.Container, staged.Name)
+	if err != nil {
+		return 0, err
+	}
+	defer out.Body.Close()
+	if offset > 0 {
+		if _, err := io.CopyN(io.Discard, out.Body, offset); err != nil && err != io.EOF {
+			return 0, err
+		}
+	}
+	n, err := io.Copy(w, out.Body)
+	return offset + n, err
+}
+
+// findRDSLogFile returns the log file named name, or with an empty name the
+// most recently modified file whose name contains hint
+func findRDSLogFile(ctx context.Context, e rdsengine.Engine, instanceID, name, hint string) (*rdsengine.LogFile, error) {
+	files, err := e.ListLogFiles(ctx, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(files, func(i, j int) bool { return files[i].ModifiedAt > files[j].ModifiedAt })
+	for i, f := range files {
+		if f.Name == name || name == "" && strings.Contains(strings.ToLower(f.Name), hint) {
+			return &files[i], nil
+		}
+	}
+	if name == "" {
+		return nil, fmt.Errorf("no log file matching %q; specify --log-file (see describe-logs)", hint)
+	}
+	return nil, fmt.Errorf("log file %s not found (see describe-logs)", name)
+}
+
+// rdsSlowLogHint is part of the name of the log holding slow queries
+func rdsSlowLogHint(engine string) string {
+	if engine == rdsengine.PostgreSQL {
+		return "postgresql"
+	}
+	return "slow"
+}
+
+func addRDSLogStagingFlags(c *cobra.Command) {
+	c.Flags().String("staging", "", "Object Storage path the log file is exported through (obs://container/path/)")
+	c.Flags().Bool("keep-staging", false, "Keep the exported copy in Object Storage")
+	c.Flags().String("timeout", "10m", "Max time to wait for an export (Go duration)")
+}
+
+func newRDSLogCommands(engineFor func() rdsengine.Engine) []*cobra.Command {
+	download := &cobra.Command{
+		Use:   "download-db-log-file",
+		Short: "Download a log file of a DB instance",
+		Long: `Downloads a log file listed by describe-logs.
+
+The file is exported by the RDS service into the Object Storage path given
+by --staging, downloaded and then deleted from there (--keep-staging keeps
+it). The Object Storage credentials are the tenant ID, NHN Cloud ID and API
+password of the CLI configuration.
+
+With --follow the log is checked every --interval and what was appended is
+written out, like tail -f; a rotated log is read again from the start. The
+service can only export whole files, so every check exports and downloads
+the entire log again: keep --interval long for large logs.
+
+Examples:
+  nhncloud rds-mysql download-db-log-file --db-instance-identifier mydb \
+    --log-file mysql-slow.log --staging obs://logs/rds/ --to ./
+
+  nhncloud rds-postgresql download-db-log-file --db-instance-identifier my-pg \
+    --log-file postgresql.log --staging obs://logs/rds/ --follow`,
+		Run: func(cmd *cobra.Command, args []string) {
+			e := engineFor()
+			ctx := context.Background()
+			id := resolveRDSInstanceID(cmd, e)
+			logFile, _ := cmd.Flags().GetString("log-file")
+			to, _ := cmd.Flags().GetString("to")
+			follow, _ := cmd.Flags().GetBool("follow")
+			interval := getDurationFlag(cmd, "interval")
+			timeout := getDurationFlag(cmd, "timeout")
+			if logFile == "" {
+				exitWithError("--log-file is required (see describe-logs)", nil)
+			}
+			file, err := findRDSLogFile(ctx, e, id, logFile, "")
+			if err != nil {
+				exitWithError("failed to find log file", err)
+			}
+			staging := newRDSLogStaging(cmd, e, id)
+
+			var w io.Writer = os.Stdout
+			dest := "-"
+			if to != "-" && (to != "" || !follow) {
+				dest = to
+				if info, err := os.Stat(dest); dest == "" || strings.HasSuffix(dest, "/") || err == nil && info.IsDir() {
+					dest = path.Join(firstNonEmpty(dest, "."), path.Base(file.Name))
+				}
+				f, err := os.Create(dest)
+				if err != nil {
+					exitWithError("failed to create output file", err)
+				}
+				defer f.Close()
+				w = f
+			}
+
+			fetchCtx, cancel := context.WithTimeout(ctx, timeout)
+			offset, err := staging.fetch(fetchCtx, file.Name, 0, w)
+			cancel()
+			if err != nil {
+				exitWithError("failed to download log file", err)
+			}
+			if dest != "-" {
+				fmt.Fprintf(os.Stderr, "Downloaded %s to %s (%d bytes)\n", file.Name, dest, offset)
+			}
+			if !follow {
+				return
+			}
+
+			size := file.Size
+			for {
+				time.Sleep(interval)
+				current, err := findRDSLogFile(ctx, e, id, file.Name, "")
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+					continue
+				}
+				if current.Size == size {
+					continue
+				}
+				if current.Size < size {
+					fmt.Fprintf(os.Stderr, "%s was rotated; reading from the start\n", file.Name)
+					offset = 0
+				}
+				size = current.Size
+				fetchCtx, cancel := context.WithTimeout(ctx, timeout)
+				next, err := staging.fetch(fetchCtx, file.Name, offset, w)
+				cancel()
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+					continue
+				}
+				offset = next
+			}
+		},
+	}
+	download.Flags().String("db-instance-identifier", "", "DB instance identifier (name or ID)")
+	download.Flags().String("log-file", "", "Log file name (see describe-logs) (required)")
+	download.Flags().String("to", "", "Local file or directory; - for stdout (default: current directory, stdout with --follow)")
+	download.Flags().Bool("follow", false, "Keep writing what is appended to the log")
+	download.Flags().String("interval", "1m", "Polling interval with --follow (Go duration)")
+	addRDSLogStagingFlags(download)
+
+	analyze := &cobra.Command{
+		Use:   "analyze-slow-log",
+		Short: "Summarize slow queries by fingerprint",
+		Long: `Parses a slow query log and groups the statements by fingerprint: the
+statement with comments removed, literals replaced by ? and value lists
+collapsed. For each fingerprint it reports the count, total, average, 95th
+percentile and maximum time and the rows examined, ordered by total time.
+
+MySQL and MariaDB slow query logs and PostgreSQL logs written with
+log_min_duration_statement are understood (PostgreSQL does not log rows
+examined).
+
+The log is read from --file (- for stdin), or downloaded from the DB
+instance like download-db-log-file; without --log-file the most recent
+slow query log (PostgreSQL: postgresql log) is used.
+
+Examples:
+  nhncloud rds-mysql analyze-slow-log --db-instance-identifier mydb --staging obs://logs/rds/
+  nhncloud rds-mysql analyze-slow-log --file mysql-slow.log --sort-by p95 --limit 10
+  nhncloud rds-postgresql analyze-slow-log --file postgresql.log -o json`,
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := context.Background()
+			file, _ := cmd.Flags().GetString("file")
+			logFile, _ := cmd.Flags().GetString("log-file")
+			format, _ := cmd.Flags().GetString("log-format")
+			sortBy, _ := cmd.Flags().GetString("sort-by")
+			limit, _ := cmd.Flags().GetInt("limit")
+			width, _ := cmd.Flags().GetInt("width")
+			timeout := getDurationFlag(cmd, "timeout")
+			if !slices.Contains(slowlog.SortKeys, sortBy) {
+				exitWithError(fmt.Sprintf("invalid --sort-by %q (%s)", sortBy, strings.Join(slowlog.SortKeys, ", ")), nil)
+			}
+			if format != "" && format != slowlog.MySQL && format != slowlog.PostgreSQL {
+				exitWithError(fmt.Sprintf("invalid --log-format %q (mysql, postgresql)", format), nil)
+			}
+
+			var in io.Reader
+			source := file
+			switch {
+			case file == "-":
+				in = os.Stdin
+			case file != "":
+				f, err := os.Open(file)
+				if err != nil {
+					exitWithError("failed to open log file", err)
+				}
+				defer f.Close()
+				in = f
+			default:
+				e := engineFor()
+				if format == "" {
+					format = slowlog.MySQL
+					if e.Name() == rdsengine.PostgreSQL {
+						format = slowlog.PostgreSQL
+					}
+				}
+				id := resolveRDSInstanceID(cmd, e)
+				found, err := findRDSLogFile(ctx, e, id, logFile, rdsSlowLogHint(e.Name()))
+				if err != nil {
+					exitWithError("failed to find log file", err)
+				}
+				staging := newRDSLogStaging(cmd, e, id)
+				tmp, err := os.CreateTemp("", "nhncloud-slowlog-*")
+				if err != nil {
+					exitWithError("failed to create temporary file", err)
+				}
+				defer os.Remove(tmp.Name())
+				defer tmp.Close()
+				fetchCtx, cancel := context.WithTimeout(ctx, timeout)
+				_, err = staging.fetch(fetchCtx, found.Name, 0, tmp)
+				cancel()
+				if err != nil {
+					exitWithError("failed to download log file", err)
+				}
+				if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+					exitWithError("failed to read log file", err)
+				}
+				in, source = tmp, found.Name
+			}
+
+			r := bufio.NewReaderSize(in, 64*1024)
+			if format == "" {
+				// Local files are not tied to an engine
+				head, _ := r.Peek(64 * 1024)
+				if format = slowlog.Detect(head); format == "" {
+					exitWithError("cannot tell the log format; set --log-format", nil)
+				}
+			}
+
+			queries, err := slowlog.Parse(r, format)
+			if err != nil {
+				exitWithError("failed to parse log", err)
+			}
+			report := slowlog.Digest(queries, format)
+			slowlog.Sort(report.Classes, sortBy)
+			if limit > 0 && len(report.Classes) > limit {
+				report.Classes = report.Classes[:limit]
+			}
+
+			if output == "json" || output == "yaml" {
+				if err := printOutput(report); err != nil {
+					exitWithError("failed to print report", err)
+				}
+				return
+			}
+			renderSlowLogReport(source, report, width)
+		},
+	}
+	analyze.Flags().String("db-instance-identifier", "", "DB instance identifier (name or ID)")
+	analyze.Flags().String("file", "", "Local log file to analyze; - for stdin")
+	analyze.Flags().String("log-file", "", "Log file of the instance (default: the most recent slow query log)")
+	analyze.Flags().String("log-format", "", "Log format of --file: mysql (also MariaDB) or postgresql (default: detected)")
+	analyze.Flags().String("sort-by", "total", "Order: "+strings.Join(slowlog.SortKeys, ", "))
+	analyze.Flags().Int("limit", 20, "Number of fingerprints to show (0 for all)")
+	analyze.Flags().Int("width", 80, "Truncate fingerprints to this many characters in table output (0 for no limit)")
+	addRDSLogStagingFlags(analyze)
+
+	return []*cobra.Command{download, analyze}
+}
+
+func renderSlowLogReport(source string, report *slowlog.Report, width int) {
+	fmt.Printf("Source: %s\n", firstNonEmpty(source, "-"))
+	fmt.Printf("Queries: %d  Fingerprints: %d  Total time: %.3fs\n\n", report.Queries, report.Fingerprints, report.TotalSeconds)
+	if len(report.Classes) == 0 {
+		fmt.Println("No queries found.")
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "RANK\tQUERY_ID\tCOUNT\tTOTAL\tPCT\tAVG\tP95\tMAX\tROWS_EXAMINED\tROWS/CALL\tFINGERPRINT")
+	for i, c := range report.Classes {
+		pct := 0.0
+		if report.TotalSeconds > 0 {
+			pct = 100 * c.TotalSeconds / report.TotalSeconds
+		}
+		// Truncate by rune so multi-byte characters in literals stay intact
+		fp := c.Fingerprint
+		runes := []rune(fp)
+		switch {
+		case width > 3 && len(runes) > width:
+			fp = string(runes[:width-3]) + "..."
+		case width > 0 && len(runes) > width:
+			fp = string(runes[:width])
+		}
+		fmt.Fprintf(w, "%d\t%s\t%d\t%.3fs\t%.1f\t%.3fs\t%.3fs\t%.3fs\t%d\t%d\t%s\n",
+			i+1, c.ID, c.Count, c.TotalSeconds, pct, c.AvgSeconds, c.P95Seconds, c.MaxSeconds,
+			c.RowsExamined, c.RowsExamined/int64(c.Count), fp)
+	}
+	w.Flush()
+}
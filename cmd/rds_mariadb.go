@@ -4,9 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"os"
-	"text/tabwriter"
 
+	"github.com/haung921209/nhn-cloud-cli/internal/rdsengine"
 	"github.com/haung921209/nhn-cloud-cli/pkg/auth"
 	"github.com/haung921209/nhn-cloud-sdk-go/nhncloud/database/mariadb"
 	"github.com/spf13/cobra"
@@ -18,43 +17,6 @@ var rdsMariaDBCmd = &cobra.Command{
 	Long:  `Manage RDS for MariaDB instances, backups, parameter groups, and more.`,
 }
 
-// ============================================================================
-// Instance Commands
-// ============================================================================
-
-var describeMariaDBInstancesCmd = &cobra.Command{
-	Use:   "describe-db-instances",
-	Short: "Describe MariaDB DB instances",
-	Long: `Describes one or more MariaDB DB instances.
-If --db-instance-identifier is specified, describes a specific instance.
-Otherwise, describes all instances.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		client := newMariaDBClient()
-		instanceID, _ := cmd.Flags().GetString("db-instance-identifier")
-
-		if instanceID != "" {
-			// Describe specific instance
-			resolvedID, err := resolveMariaDBInstanceIdentifier(client, instanceID)
-			if err != nil {
-				exitWithError("failed to resolve instance identifier", err)
-			}
-
-			result, err := client.GetInstance(context.Background(), resolvedID)
-			if err != nil {
-				exitWithError("failed to describe instance", err)
-			}
-			mariadbPrintInstanceDetail(result)
-		} else {
-			// List all instances
-			result, err := client.ListInstances(context.Background())
-			if err != nil {
-				exitWithError("failed to list instances", err)
-			}
-			mariadbPrintInstanceList(result)
-		}
-	},
-}
-
 // ============================================================================
 // Helper Functions
 // ============================================================================
@@ -64,6 +26,7 @@ func newMariaDBClient() *mariadb.Client {
 	if err != nil {
 		exitWithError("failed to load MariaDB credentials", err)
 	}
+	cfg.Region = rdsRegion(cfg.Region)
 
 	client, err := mariadb.NewClient(cfg)
 	if err != nil {
@@ -99,67 +62,6 @@ func resolveMariaDBInstanceIdentifier(client *mariadb.Client, identifier string)
 // Print Functions
 // ============================================================================
 
-func mariadbPrintInstanceList(result *mariadb.ListInstancesResponse) {
-	if output == "json" {
-		mariadbPrintJSON(result)
-		return
-	}
-
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	// Similar to MySQL, ListInstances API might not return Flavor info, so omitting it from table
-	fmt.Fprintln(w, "DB_INSTANCE_ID\tNAME\tSTATUS\tVERSION")
-	for _, inst := range result.DBInstances {
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
-			inst.DBInstanceID,
-			inst.DBInstanceName,
-			inst.DBInstanceStatus,
-			inst.DBVersion,
-		)
-	}
-	w.Flush()
-}
-
-func mariadbPrintInstanceDetail(result *mariadb.GetInstanceResponse) {
-	if output == "json" {
-		mariadbPrintJSON(result)
-		return
-	}
-
-	inst := result.DatabaseInstance
-	fmt.Printf("DB Instance: %s\n", inst.DBInstanceName)
-	fmt.Printf("  ID: %s\n", inst.DBInstanceID)
-	// DBInstanceGroupID and DBInstanceType are not in MariaDB SDK
-
-	fmt.Printf("  Status: %s\n", inst.DBInstanceStatus)
-	if inst.ProgressStatus != "" {
-		fmt.Printf("  Progress: %s\n", inst.ProgressStatus)
-	}
-	fmt.Printf("  Flavor: %s\n", inst.DBFlavorID)
-	// DBFlavorName is optional
-	if inst.DBFlavorName != "" {
-		fmt.Printf("  Flavor Name: %s\n", inst.DBFlavorName)
-	}
-	fmt.Printf("  Version: %s\n", inst.DBVersion)
-	fmt.Printf("  Port: %d\n", inst.DBPort)
-	if inst.ParameterGroupID != "" {
-		fmt.Printf("  Parameter Group: %s\n", inst.ParameterGroupID)
-	}
-	if len(inst.DBSecurityGroupIDs) > 0 {
-		fmt.Printf("  Security Groups: %v\n", inst.DBSecurityGroupIDs)
-	}
-	if len(inst.NotificationGroupIDs) > 0 {
-		fmt.Printf("  Notification Groups: %v\n", inst.NotificationGroupIDs)
-	}
-
-	// MariaDB SDK only has CreatedAt/UpdatedAt
-	if inst.CreatedAt != "" {
-		fmt.Printf("  Created: %s\n", inst.CreatedAt)
-	}
-	if inst.UpdatedAt != "" {
-		fmt.Printf("  Updated: %s\n", inst.UpdatedAt)
-	}
-}
-
 func mariadbPrintJSON(v interface{}) {
 	b, _ := json.MarshalIndent(v, "", "  ")
 	fmt.Println(string(b))
@@ -168,9 +70,6 @@ func mariadbPrintJSON(v interface{}) {
 func init() {
 	rootCmd.AddCommand(rdsMariaDBCmd)
 
-	rdsMariaDBCmd.AddCommand(describeMariaDBInstancesCmd)
-	describeMariaDBInstancesCmd.Flags().String("db-instance-identifier", "", "DB instance identifier")
-
 	// create-db-instance
 	rdsMariaDBCmd.AddCommand(createMariaDBInstanceCmd)
 	createMariaDBInstanceCmd.Flags().String("db-instance-identifier", "", "DB instance identifier (required)")
@@ -191,6 +90,7 @@ func init() {
 	createMariaDBInstanceCmd.Flags().Bool("multi-az", false, "Enable multi-AZ deployment")
 	createMariaDBInstanceCmd.Flags().Int("backup-retention-period", 0, "Backup retention period in days")
 	createMariaDBInstanceCmd.Flags().String("backup-window", "00:00", "Backup window time (HH:MM)")
+	addRDSFromSpecFlags(createMariaDBInstanceCmd)
 
 	// modify-db-instance
 	rdsMariaDBCmd.AddCommand(modifyMariaDBInstanceCmd)
@@ -199,10 +99,6 @@ func init() {
 	modifyMariaDBInstanceCmd.Flags().String("db-flavor-id", "", "New DB flavor ID")
 	modifyMariaDBInstanceCmd.Flags().StringSlice("db-security-group-ids", nil, "New DB security group IDs (comma-separated)")
 	modifyMariaDBInstanceCmd.Flags().Int("port", 0, "New database port")
-
-	// delete-db-instance
-	rdsMariaDBCmd.AddCommand(deleteMariaDBInstanceCmd)
-	deleteMariaDBInstanceCmd.Flags().String("db-instance-identifier", "", "DB instance identifier (required)")
 }
 
 var modifyMariaDBInstanceCmd = &cobra.Command{
@@ -255,26 +151,6 @@ var modifyMariaDBInstanceCmd = &cobra.Command{
 	},
 }
 
-var deleteMariaDBInstanceCmd = &cobra.Command{
-	Use:   "delete-db-instance",
-	Short: "Delete a MariaDB DB instance",
-	Run: func(cmd *cobra.Command, args []string) {
-		client := newMariaDBClient()
-		dbInstanceID, err := getResolvedMariaDBInstanceID(cmd, client)
-		if err != nil {
-			exitWithError("failed to resolve instance ID", err)
-		}
-
-		result, err := client.DeleteInstance(context.Background(), dbInstanceID)
-		if err != nil {
-			exitWithError("failed to delete instance", err)
-		}
-
-		fmt.Printf("DB instance deletion initiated.\n")
-		fmt.Printf("Job ID: %s\n", result.JobID)
-	},
-}
-
 // getResolvedMariaDBInstanceID is a helper that gets and resolves instance ID from command flags
 func getResolvedMariaDBInstanceID(cmd *cobra.Command, client *mariadb.Client) (string, error) {
 	identifier, _ := cmd.Flags().GetString("db-instance-identifier")
@@ -298,8 +174,14 @@ Example:
     --master-user-password SecurePass123 \
     --allocated-storage 20 \
     --subnet-id <subnet-uuid> \
-    --availability-zone kr-pub-a`,
+    --availability-zone kr-pub-a
+
+  # Recreate an exported instance with its parameter group and DB security groups
+  nhncloud rds-mariadb create-db-instance --from-spec spec.yaml --region jp1 --availability-zone jp-pub-a`,
 	Run: func(cmd *cobra.Command, args []string) {
+		if runRDSCreateFromSpec(cmd, rdsengine.MariaDB, "db-instance-identifier", "master-username", "master-user-password") {
+			return
+		}
 		ctx := context.Background()
 		client := newMariaDBClient()
 
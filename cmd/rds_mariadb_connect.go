@@ -2,16 +2,14 @@ package cmd
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
 	"os"
 	"os/exec"
 	"strconv"
 
 	"github.com/haung921209/nhn-cloud-cli/internal/cert"
+	"github.com/haung921209/nhn-cloud-cli/internal/rdsengine"
 	"github.com/spf13/cobra"
-
-	_ "github.com/go-sql-driver/mysql"
 )
 
 func init() {
@@ -26,6 +24,9 @@ func init() {
 
 	// Native execution flag
 	connectMariaDBCmd.Flags().StringP("execute", "e", "", "Execute query and exit (Uses built-in driver, no external dependency)")
+	connectMariaDBCmd.Flags().Bool("native", false, "Use the built-in shell even if the mysql client is installed")
+
+	addBastionFlags(connectMariaDBCmd)
 
 	connectMariaDBCmd.MarkFlagRequired("db-instance-identifier")
 	connectMariaDBCmd.MarkFlagRequired("username")
@@ -37,12 +38,20 @@ var connectMariaDBCmd = &cobra.Command{
 	Short: "Connect to a MariaDB DB instance",
 	Long: `Connects to a MariaDB DB instance.
 Modes:
-1. Interactive: Launches 'mysql' client (Requires local installation).
-2. Execute: Runs a query using the built-in Go driver (No external dependency).
+1. Interactive: Launches the 'mysql' client, or the built-in shell when it
+   is not installed (or with --native). Type \? in the built-in shell for
+   its commands (\G, source, \timing, \o, ...).
+2. Execute: Runs statements using the built-in Go driver (No external dependency).
+
+The built-in driver verifies the server with the CA from the cert store.
+
+Instances without Public Access can be reached with --via-bastion, which
+opens an SSH tunnel through the given compute instance.
 
 Example:
   Interactive: nhncloud rds-mariadb connect --db-instance-identifier mydb -u user -p pass
-  Execute:     nhncloud rds-mariadb connect --db-instance-identifier mydb -u user -p pass -e "SHOW DATABASES"`,
+  Execute:     nhncloud rds-mariadb connect --db-instance-identifier mydb -u user -p pass -e "SHOW DATABASES"
+  Private:     nhncloud rds-mariadb connect --db-instance-identifier mydb -u user -p pass --via-bastion bastion`,
 	Run: func(cmd *cobra.Command, args []string) {
 		client := newMariaDBClient()
 
@@ -100,11 +109,38 @@ Example:
 			}
 		}
 
-		if host == "" {
-			exitWithError(fmt.Sprintf("Unable to determine public host for instance '%s'. Only instances with Public Access enabled can be connected to via CLI.", inst.DBInstanceName), nil)
+		viaBastion, _ := cmd.Flags().GetString("via-bastion")
+		if host == "" && viaBastion == "" {
+			exitWithError(fmt.Sprintf("Unable to determine public host for instance '%s'. Only instances with Public Access enabled can be connected to via CLI (or use --via-bastion).", inst.DBInstanceName), nil)
+		}
+
+		// Private instances are reached through an SSH tunnel on a bastion
+		port := inst.DBPort
+		serverName := host
+		if viaBastion != "" {
+			var endpoints []rdsEndpoint
+			if netInfo, err := client.GetNetworkInfo(ctx, dbInstanceID); err == nil && netInfo != nil {
+				for _, ep := range netInfo.EndPoints {
+					endpoints = append(endpoints, rdsEndpoint{Type: ep.EndPointType, Domain: ep.Domain, IPAddress: ep.IPAddress})
+				}
+			}
+			privateHost := privateRDSHost(endpoints, inst.Network.DomainName, inst.Network.IPAddress)
+			if privateHost == "" {
+				exitWithError(fmt.Sprintf("Unable to determine private host for instance '%s'", inst.DBInstanceName), nil)
+			}
+
+			tunnel, err := openBastionTunnelFromFlags(cmd, privateHost, inst.DBPort)
+			if err != nil {
+				exitWithError("failed to open bastion tunnel", err)
+			}
+			defer tunnel.Close()
+
+			host = tunnel.LocalHost()
+			port = tunnel.LocalPort()
+			serverName = privateHost
 		}
 
-		fmt.Printf("Connecting to %s (%s:%d)...\n", inst.DBInstanceName, host, inst.DBPort)
+		fmt.Printf("Connecting to %s (%s:%d)...\n", inst.DBInstanceName, host, port)
 
 		// Initialize Helper
 		helper, err := cert.NewHelper()
@@ -112,85 +148,30 @@ Example:
 			exitWithError("failed to initialize certificate helper", err)
 		}
 
-		// If -e/--execute is provided, use Native Go Driver
-		if query != "" {
-			fmt.Println("Synthesizing native connection...")
-
-			// Get Cert Paths specifically for Native use (Helper might need 'mysql' type logic hint, but we just need paths)
-			// Actually we need to register certs with the driver if we want SSL
-			// The go-sql-driver/mysql uses 'tls' param in DSN and SystemCertPool or custom
-			// For simplicity first pass: basic native connection.
-			// Wait, RDS REQUIRES SSL usually if we follow 'ssl-ca'.
-			// The driver supports `tls=skip-verify` or custom TLS config.
-
-			// IMPORTANT: Registering Custom TLS Config for NHN Cloud Certs
-			// We need the CA Path.
-			_, err := helper.GetCertificateForDatabase("rds-mariadb", region, dbInstanceID, inst.DBVersion, true, caPath, "CA") // Ignore caPathReal for now
-			if err != nil {
-				fmt.Printf("Warning: Could not locate CA cert: %v. Connection may fail if SSL required.\n", err)
-			}
-
-			// TODO: Register TLS config with driver if CA exists.
-			// For now, let's try strict DSN construction based on documentation.
-			// DSN Format: user:password@tcp(host:port)/dbname?tls=custom&...
-
-			// Just for this iteration: Print and use DSN without complex TLS reg (or skip-verify if needed)
-			// But NHN Cloud usually needs CA.
-			// Implementing properly means reading the CA file and `mysql.RegisterTLSConfig`.
-
-			dsn := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?tls=skip-verify&allowNativePasswords=true", username, password, host, inst.DBPort, database)
-			// Note: allowNativePasswords=true is CRITICAL for MariaDB/MySQL 9 compat issue
+		target := rdsSQLTarget{
+			Host:       host,
+			Port:       port,
+			ServerName: serverName,
+			Username:   username,
+			Password:   password,
+			Database:   database,
+			Region:     region,
+			InstanceID: dbInstanceID,
+			Version:    inst.DBVersion,
+			CAPath:     caPath,
+		}
 
-			db, err := sql.Open("mysql", dsn)
+		// If -e/--execute is provided, use the built-in driver
+		if query != "" {
+			db, err := openRDSDatabase(ctx, rdsengine.MariaDB, target)
 			if err != nil {
-				exitWithError("failed to open database connection", err)
+				exitWithError("failed to connect to database", err)
 			}
 			defer db.Close()
 
-			err = db.Ping()
-			if err != nil {
-				exitWithError("failed to connect/ping database", err)
-			}
-
-			// Execute Query
-			rows, err := db.Query(query)
-			if err != nil {
-				exitWithError("failed to execute query", err)
-			}
-			defer rows.Close()
-
-			// Print Results (Generic)
-			cols, _ := rows.Columns()
-			fmt.Println("--------------------------------------------------")
-			// Print Header
-			for _, c := range cols {
-				fmt.Printf("%s\t", c)
-			}
-			fmt.Println("\n--------------------------------------------------")
-
-			// Scan Rows
-			rowValues := make([]interface{}, len(cols))
-			rowPointers := make([]interface{}, len(cols))
-			for i := range rowValues {
-				rowPointers[i] = &rowValues[i]
+			if err := runRDSStatements(db, rdsengine.MariaDB, database, query); err != nil {
+				os.Exit(1)
 			}
-
-			for rows.Next() {
-				err := rows.Scan(rowPointers...)
-				if err != nil {
-					fmt.Printf("Error scanning row: %v\n", err)
-					continue
-				}
-				for _, val := range rowValues {
-					if b, ok := val.([]byte); ok {
-						fmt.Printf("%s\t", string(b))
-					} else {
-						fmt.Printf("%v\t", val)
-					}
-				}
-				fmt.Println("")
-			}
-			fmt.Println("--------------------------------------------------")
 			return
 		}
 
@@ -198,7 +179,7 @@ Example:
 		cmdArgs, err := helper.GetConnectionCommand(
 			"rds-mariadb", // logic maps this to "mysql"
 			host,
-			strconv.Itoa(inst.DBPort),
+			strconv.Itoa(port),
 			database,
 			username,
 			password,
@@ -225,26 +206,19 @@ Example:
 		c.Stdout = os.Stdout
 		c.Stderr = os.Stderr
 
-		// Check for missing mysql client (MariaDB uses mysql client usually)
-		if _, err := exec.LookPath("mysql"); err != nil && len(args) == 0 {
-			// Fallback to Native REPL
-			fmt.Println("Notice: 'mysql' client not found in PATH. Falling back to built-in native shell.")
-
-			// Construct DSN
-			dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?tls=skip-verify&allowNativePasswords=true",
-				username, password, host, strconv.Itoa(inst.DBPort), database)
-
-			db, err := sql.Open("mysql", dsn)
+		// Fall back to the built-in shell when the mysql client is missing
+		native, _ := cmd.Flags().GetBool("native")
+		if _, err := exec.LookPath("mysql"); native || (err != nil && len(args) == 0) {
+			if !native {
+				fmt.Println("Notice: 'mysql' client not found in PATH. Falling back to built-in native shell.")
+			}
+			db, err := openRDSDatabase(ctx, rdsengine.MariaDB, target)
 			if err != nil {
-				exitWithError("failed to open database connection", err)
+				exitWithError("failed to connect to database", err)
 			}
 			defer db.Close()
 
-			if err := db.Ping(); err != nil {
-				exitWithError("failed to connect/ping database", err)
-			}
-
-			runNativeREPL(db, database, "mariadb")
+			runRDSShell(db, rdsengine.MariaDB, database)
 			return
 		}
 
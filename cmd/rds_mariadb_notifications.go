@@ -97,33 +97,6 @@ var mariadbDeleteNotificationGroupCmd = &cobra.Command{
 	},
 }
 
-// ============================================================================
-// Log Commands
-// ============================================================================
-
-var mariadbDescribeLogsCmd = &cobra.Command{
-	Use:   "describe-logs",
-	Short: "Describe log files for an instance",
-	Run: func(cmd *cobra.Command, args []string) {
-		client := newMariaDBClient()
-		instanceID, err := getResolvedMariaDBInstanceID(cmd, client)
-		if err != nil {
-			exitWithError("failed to resolve instance ID", err)
-		}
-
-		result, err := client.ListLogFiles(context.Background(), instanceID)
-		if err != nil {
-			exitWithError("failed to list log files", err)
-		}
-
-		if output == "json" {
-			mariadbPrintJSON(result)
-		} else {
-			mariadbPrintLogFileList(result)
-		}
-	},
-}
-
 // ============================================================================
 // Print Functions
 // ============================================================================
@@ -154,19 +127,6 @@ func mariadbPrintNotificationGroupDetail(result *mariadb.GetNotificationGroupRes
 	}
 }
 
-func mariadbPrintLogFileList(result *mariadb.ListLogFilesResponse) {
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintln(w, "FILE_NAME\tSIZE\tMODIFIED")
-	for _, log := range result.LogFiles {
-		fmt.Fprintf(w, "%s\t%d\t%s\n",
-			log.LogFileName,
-			log.LogFileSize,
-			log.ModifiedAt,
-		)
-	}
-	w.Flush()
-}
-
 func init() {
 	// Notification Group commands
 	rdsMariaDBCmd.AddCommand(mariadbDescribeNotificationGroupsCmd)
@@ -181,8 +141,4 @@ func init() {
 	mariadbCreateNotificationGroupCmd.Flags().StringSlice("notify-sms", nil, "Phone numbers for SMS notifications")
 
 	mariadbDeleteNotificationGroupCmd.Flags().String("notification-group-id", "", "Notification group ID (required)")
-
-	// Log commands
-	rdsMariaDBCmd.AddCommand(mariadbDescribeLogsCmd)
-	mariadbDescribeLogsCmd.Flags().String("db-instance-identifier", "", "DB instance identifier (required)")
 }
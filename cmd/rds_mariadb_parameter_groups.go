@@ -3,8 +3,6 @@ package cmd
 import (
 	"context"
 	"fmt"
-	"os"
-	"text/tabwriter"
 
 	"github.com/haung921209/nhn-cloud-sdk-go/nhncloud/database/mariadb"
 	"github.com/spf13/cobra"
@@ -14,39 +12,6 @@ import (
 // Parameter Group Commands
 // ============================================================================
 
-var describeMariaDBParameterGroupsCmd = &cobra.Command{
-	Use:   "describe-db-parameter-groups",
-	Short: "Describe MariaDB DB parameter groups",
-	Run: func(cmd *cobra.Command, args []string) {
-		client := newMariaDBClient()
-		groupID, _ := cmd.Flags().GetString("db-parameter-group-id")
-
-		if groupID != "" {
-			// Describe specific group
-			result, err := client.GetParameterGroup(context.Background(), groupID)
-			if err != nil {
-				exitWithError("failed to get parameter group", err)
-			}
-			if output == "json" {
-				mariadbPrintJSON(result)
-			} else {
-				mariadbPrintParameterGroupDetail(result)
-			}
-		} else {
-			// List all groups
-			result, err := client.ListParameterGroups(context.Background())
-			if err != nil {
-				exitWithError("failed to list parameter groups", err)
-			}
-			if output == "json" {
-				mariadbPrintJSON(result)
-			} else {
-				mariadbPrintParameterGroupList(result)
-			}
-		}
-	},
-}
-
 var createMariaDBParameterGroupCmd = &cobra.Command{
 	Use:   "create-db-parameter-group",
 	Short: "Create a MariaDB DB parameter group",
@@ -124,46 +89,11 @@ var resetMariaDBParameterGroupCmd = &cobra.Command{
 // Print Functions
 // ============================================================================
 
-func mariadbPrintParameterGroupList(result *mariadb.ListParameterGroupsResponse) {
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintln(w, "ID\tNAME\tVERSION\tDESCRIPTION")
-	for _, pg := range result.ParameterGroups {
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
-			pg.ParameterGroupID,
-			pg.ParameterGroupName,
-			pg.DBVersion,
-			pg.Description,
-		)
-	}
-	w.Flush()
-}
-
-func mariadbPrintParameterGroupDetail(result *mariadb.GetParameterGroupResponse) {
-	pg := result.ParameterGroup
-	fmt.Printf("ID: %s\n", pg.ParameterGroupID)
-	fmt.Printf("Name: %s\n", pg.ParameterGroupName)
-	fmt.Printf("Version: %s\n", pg.DBVersion)
-	fmt.Printf("Description: %s\n", pg.Description)
-
-	if len(pg.Parameters) > 0 {
-		fmt.Println("\nParameters:")
-		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-		fmt.Fprintln(w, "NAME\tVALUE")
-		for _, p := range pg.Parameters {
-			fmt.Fprintf(w, "%s\t%s\n", p.ParameterName, p.Value)
-		}
-		w.Flush()
-	}
-}
-
 func init() {
-	rdsMariaDBCmd.AddCommand(describeMariaDBParameterGroupsCmd)
 	rdsMariaDBCmd.AddCommand(createMariaDBParameterGroupCmd)
 	rdsMariaDBCmd.AddCommand(deleteMariaDBParameterGroupCmd)
 	rdsMariaDBCmd.AddCommand(resetMariaDBParameterGroupCmd)
 
-	describeMariaDBParameterGroupsCmd.Flags().String("db-parameter-group-id", "", "DB parameter group ID")
-
 	createMariaDBParameterGroupCmd.Flags().String("db-parameter-group-name", "", "Parameter group name (required)")
 	createMariaDBParameterGroupCmd.Flags().String("db-parameter-group-family", "", "DB parameter group family/version (required, e.g., '10.2')")
 	createMariaDBParameterGroupCmd.Flags().String("description", "", "Description")
@@ -3,8 +3,6 @@ package cmd
 import (
 	"context"
 	"fmt"
-	"os"
-	"text/tabwriter"
 
 	"github.com/haung921209/nhn-cloud-sdk-go/nhncloud/database/mariadb"
 	"github.com/spf13/cobra"
@@ -14,24 +12,6 @@ import (
 // Security Group Commands
 // ============================================================================
 
-var describeMariaDBSecurityGroupsCmd = &cobra.Command{
-	Use:   "describe-db-security-groups",
-	Short: "Describe MariaDB DB security groups",
-	Run: func(cmd *cobra.Command, args []string) {
-		client := newMariaDBClient()
-		result, err := client.ListSecurityGroups(context.Background())
-		if err != nil {
-			exitWithError("failed to list security groups", err)
-		}
-
-		if output == "json" {
-			mariadbPrintJSON(result)
-		} else {
-			mariadbPrintSecurityGroupList(result)
-		}
-	},
-}
-
 var createMariaDBSecurityGroupCmd = &cobra.Command{
 	Use:   "create-db-security-group",
 	Short: "Create a MariaDB DB security group",
@@ -172,21 +152,7 @@ var deleteMariaDBSecurityGroupCmd = &cobra.Command{
 // Print Functions
 // ============================================================================
 
-func mariadbPrintSecurityGroupList(result *mariadb.ListSecurityGroupsResponse) {
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintln(w, "ID\tNAME\tRULES")
-	for _, sg := range result.DBSecurityGroups {
-		fmt.Fprintf(w, "%s\t%s\t%d\n",
-			sg.DBSecurityGroupID,
-			sg.DBSecurityGroupName,
-			len(sg.Rules),
-		)
-	}
-	w.Flush()
-}
-
 func init() {
-	rdsMariaDBCmd.AddCommand(describeMariaDBSecurityGroupsCmd)
 	rdsMariaDBCmd.AddCommand(createMariaDBSecurityGroupCmd)
 	rdsMariaDBCmd.AddCommand(authorizeMariaDBSecurityGroupIngressCmd)
 	rdsMariaDBCmd.AddCommand(deleteMariaDBSecurityGroupCmd)
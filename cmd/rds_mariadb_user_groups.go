@@ -95,61 +95,6 @@ var mariadbDeleteUserGroupCmd = &cobra.Command{
 	},
 }
 
-// ============================================================================
-// Monitoring Commands
-// ============================================================================
-
-var mariadbDescribeMetricsCmd = &cobra.Command{
-	Use:   "describe-metrics",
-	Short: "Describe available monitoring metrics",
-	Run: func(cmd *cobra.Command, args []string) {
-		client := newMariaDBClient()
-
-		result, err := client.ListMetrics(context.Background())
-		if err != nil {
-			exitWithError("failed to list metrics", err)
-		}
-
-		if output == "json" {
-			mariadbPrintJSON(result)
-		} else {
-			mariadbPrintMetricList(result)
-		}
-	},
-}
-
-var mariadbGetMetricStatisticsCmd = &cobra.Command{
-	Use:   "get-metric-statistics",
-	Short: "Get metric statistics for an instance",
-	Run: func(cmd *cobra.Command, args []string) {
-		client := newMariaDBClient()
-
-		instanceID, err := getResolvedMariaDBInstanceID(cmd, client)
-		if err != nil {
-			exitWithError("failed to resolve instance ID", err)
-		}
-
-		from, _ := cmd.Flags().GetString("from")
-		to, _ := cmd.Flags().GetString("to")
-		interval, _ := cmd.Flags().GetInt("interval")
-
-		if from == "" || to == "" {
-			exitWithError("--from and --to are required (ISO8601 format)", nil)
-		}
-
-		result, err := client.GetMetricStatistics(context.Background(), instanceID, from, to, interval)
-		if err != nil {
-			exitWithError("failed to get metric statistics", err)
-		}
-
-		if output == "json" {
-			mariadbPrintJSON(result)
-		} else {
-			mariadbPrintMetricStatistics(result)
-		}
-	},
-}
-
 // ============================================================================
 // Print Functions
 // ============================================================================
@@ -181,28 +126,6 @@ func mariadbPrintUserGroupDetail(result *mariadb.GetUserGroupResponse) {
 	}
 }
 
-func mariadbPrintMetricList(result *mariadb.ListMetricsResponse) {
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintln(w, "METRIC_NAME\tUNIT")
-	for _, m := range result.Metrics {
-		fmt.Fprintf(w, "%s\t%s\n", m.MetricName, m.Unit)
-	}
-	w.Flush()
-}
-
-func mariadbPrintMetricStatistics(result *mariadb.GetMetricStatisticsResponse) {
-	for _, stat := range result.MetricStatistics {
-		fmt.Printf("Metric: %s (%s)\n", stat.MetricName, stat.Unit)
-		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-		fmt.Fprintln(w, "  TIMESTAMP\tVALUE")
-		for _, v := range stat.Values {
-			fmt.Fprintf(w, "  %s\t%.2f\n", v.Timestamp, v.Value)
-		}
-		w.Flush()
-		fmt.Println()
-	}
-}
-
 func init() {
 	// User Group commands
 	rdsMariaDBCmd.AddCommand(mariadbDescribeUserGroupsCmd)
@@ -216,13 +139,4 @@ func init() {
 	mariadbCreateUserGroupCmd.Flags().Bool("select-all", false, "Select all project members")
 
 	mariadbDeleteUserGroupCmd.Flags().String("user-group-id", "", "User group ID (required)")
-
-	// Monitoring commands
-	rdsMariaDBCmd.AddCommand(mariadbDescribeMetricsCmd)
-	rdsMariaDBCmd.AddCommand(mariadbGetMetricStatisticsCmd)
-
-	mariadbGetMetricStatisticsCmd.Flags().String("db-instance-identifier", "", "DB instance identifier (required)")
-	mariadbGetMetricStatisticsCmd.Flags().String("from", "", "Start time (ISO8601 format, required)")
-	mariadbGetMetricStatisticsCmd.Flags().String("to", "", "End time (ISO8601 format, required)")
-	mariadbGetMetricStatisticsCmd.Flags().Int("interval", 60, "Interval in seconds")
 }
@@ -14,29 +14,6 @@ import (
 // DB User Commands
 // ============================================================================
 
-var describeMariaDBUsersCmd = &cobra.Command{
-	Use:   "describe-db-users",
-	Short: "Describe MariaDB DB users",
-	Run: func(cmd *cobra.Command, args []string) {
-		client := newMariaDBClient()
-		instanceID, err := getResolvedMariaDBInstanceID(cmd, client)
-		if err != nil {
-			exitWithError("failed to resolve instance ID", err)
-		}
-
-		result, err := client.ListDBUsers(context.Background(), instanceID)
-		if err != nil {
-			exitWithError("failed to list DB users", err)
-		}
-
-		if output == "json" {
-			mariadbPrintJSON(result)
-		} else {
-			mariadbPrintUserList(result)
-		}
-	},
-}
-
 var createMariaDBUserCmd = &cobra.Command{
 	Use:   "create-db-user",
 	Short: "Create a MariaDB database user",
@@ -191,20 +168,6 @@ var deleteMariaDBSchemaCmd = &cobra.Command{
 // Print Functions
 // ============================================================================
 
-func mariadbPrintUserList(result *mariadb.ListDBUsersResponse) {
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintln(w, "ID\tUSERNAME\tHOST\tAUTHORITY")
-	for _, user := range result.DBUsers {
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
-			user.DBUserID,
-			user.DBUserName,
-			user.Host,
-			user.AuthorityType,
-		)
-	}
-	w.Flush()
-}
-
 func mariadbPrintSchemaList(result *mariadb.ListSchemasResponse) {
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
 	fmt.Fprintln(w, "ID\tNAME")
@@ -219,12 +182,9 @@ func mariadbPrintSchemaList(result *mariadb.ListSchemasResponse) {
 
 func init() {
 	// DB Users
-	rdsMariaDBCmd.AddCommand(describeMariaDBUsersCmd)
 	rdsMariaDBCmd.AddCommand(createMariaDBUserCmd)
 	rdsMariaDBCmd.AddCommand(deleteMariaDBUserCmd)
 
-	describeMariaDBUsersCmd.Flags().String("db-instance-identifier", "", "DB instance identifier (required)")
-
 	createMariaDBUserCmd.Flags().String("db-instance-identifier", "", "DB instance identifier (required)")
 	createMariaDBUserCmd.Flags().String("db-user-name", "", "Database username (required)")
 	createMariaDBUserCmd.Flags().String("db-password", "", "Database password, 4-16 chars (required)")
@@ -4,9 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"os"
-	"text/tabwriter"
 
+	"github.com/haung921209/nhn-cloud-cli/internal/rdsengine"
 	"github.com/haung921209/nhn-cloud-cli/pkg/auth"
 	"github.com/haung921209/nhn-cloud-sdk-go/nhncloud/database/mysql"
 	"github.com/spf13/cobra"
@@ -22,39 +21,6 @@ var rdsMySQLCmd = &cobra.Command{
 // Instance Commands
 // ============================================================================
 
-var describeDBInstancesCmd = &cobra.Command{
-	Use:   "describe-db-instances",
-	Short: "Describe MySQL DB instances",
-	Long: `Describes one or more MySQL DB instances.
-If --db-instance-identifier is specified, describes a specific instance.
-Otherwise, describes all instances.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		client := newMySQLClient()
-		instanceID, _ := cmd.Flags().GetString("db-instance-identifier")
-
-		if instanceID != "" {
-			// Describe specific instance
-			resolvedID, err := resolveInstanceIdentifier(client, instanceID)
-			if err != nil {
-				exitWithError("failed to resolve instance identifier", err)
-			}
-
-			result, err := client.GetInstance(context.Background(), resolvedID)
-			if err != nil {
-				exitWithError("failed to describe instance", err)
-			}
-			printInstanceDetail(result)
-		} else {
-			// List all instances
-			result, err := client.ListInstances(context.Background())
-			if err != nil {
-				exitWithError("failed to list instances", err)
-			}
-			printInstanceList(result)
-		}
-	},
-}
-
 var createDBInstanceCmd = &cobra.Command{
 	Use:   "create-db-instance",
 	Short: "Create a new MySQL DB instance",
@@ -69,8 +35,14 @@ Example:
     --master-user-password SecurePass123 \
     --allocated-storage 20 \
     --subnet-id <subnet-uuid> \
-    --availability-zone kr-pub-a`,
+    --availability-zone kr-pub-a
+
+  # Recreate an exported instance with its parameter group and DB security groups
+  nhncloud rds-mysql create-db-instance --from-spec spec.yaml --region jp1 --availability-zone jp-pub-a`,
 	Run: func(cmd *cobra.Command, args []string) {
+		if runRDSCreateFromSpec(cmd, rdsengine.MySQL, "db-instance-identifier", "master-username", "master-user-password") {
+			return
+		}
 		ctx := context.Background()
 		client := newMySQLClient()
 
@@ -296,41 +268,6 @@ var modifyDBInstanceCmd = &cobra.Command{
 	},
 }
 
-var deleteDBInstanceCmd = &cobra.Command{
-	Use:   "delete-db-instance",
-	Short: "Delete a MySQL DB instance",
-	Long: `Deletes a MySQL DB instance.
-
-By default this command requires --yes to proceed (non-interactive
-scriptable delete is the only supported mode — Phase D scenarios
-need scriptable delete).
-
-Example:
-  nhncloud rds-mysql delete-db-instance --db-instance-identifier mydb --yes
-
-Ref: docs/api-specs/database/rds-mysql-v4.0.md#db-인스턴스-삭제하기`,
-	Run: func(cmd *cobra.Command, args []string) {
-		dbInstanceID, err := getResolvedInstanceID(cmd, newMySQLClient())
-		if err != nil {
-			exitWithError("failed to resolve instance ID", err)
-		}
-
-		yes, _ := cmd.Flags().GetBool("yes")
-		if !yes {
-			exitWithError("refusing to delete without --yes (non-interactive confirmation)", nil)
-		}
-
-		client := newMySQLClient()
-		result, err := client.DeleteInstance(context.Background(), dbInstanceID, nil)
-		if err != nil {
-			exitWithError("failed to delete instance", err)
-		}
-
-		fmt.Printf("DB instance deletion initiated.\n")
-		fmt.Printf("Job ID: %s\n", result.JobID)
-	},
-}
-
 // ============================================================================
 // Helper Functions
 // ============================================================================
@@ -340,6 +277,7 @@ func newMySQLClient() *mysql.Client {
 	if err != nil {
 		exitWithError("failed to load MySQL credentials", err)
 	}
+	cfg.Region = rdsRegion(cfg.Region)
 
 	client, err := mysql.NewClient(cfg)
 	if err != nil {
@@ -353,64 +291,6 @@ func newMySQLClient() *mysql.Client {
 // Print Functions
 // ============================================================================
 
-func printInstanceList(result *mysql.ListInstancesResponse) {
-	if output == "json" {
-		printJSON(result)
-		return
-	}
-
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintln(w, "DB_INSTANCE_ID\tNAME\tSTATUS\tVERSION")
-	for _, inst := range result.DBInstances {
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
-			inst.DBInstanceID,
-			inst.DBInstanceName,
-			inst.DBInstanceStatus,
-			inst.DBVersion,
-		)
-	}
-	w.Flush()
-}
-
-func printInstanceDetail(result *mysql.GetInstanceResponse) {
-	if output == "json" {
-		printJSON(result)
-		return
-	}
-
-	inst := result.DatabaseInstance
-	fmt.Printf("DB Instance: %s\n", inst.DBInstanceName)
-	fmt.Printf("  ID: %s\n", inst.DBInstanceID)
-	if inst.DBInstanceGroupID != "" {
-		fmt.Printf("  Group ID: %s\n", inst.DBInstanceGroupID)
-	}
-	if inst.DBInstanceType != "" {
-		fmt.Printf("  Type: %s\n", inst.DBInstanceType)
-	}
-	fmt.Printf("  Status: %s\n", inst.DBInstanceStatus)
-	if inst.ProgressStatus != "" {
-		fmt.Printf("  Progress: %s\n", inst.ProgressStatus)
-	}
-	fmt.Printf("  Flavor: %s\n", inst.DBFlavorID)
-	fmt.Printf("  Version: %s\n", inst.DBVersion)
-	fmt.Printf("  Port: %d\n", inst.DBPort)
-	if inst.ParameterGroupID != "" {
-		fmt.Printf("  Parameter Group: %s\n", inst.ParameterGroupID)
-	}
-	if len(inst.DBSecurityGroupIDs) > 0 {
-		fmt.Printf("  Security Groups: %v\n", inst.DBSecurityGroupIDs)
-	}
-	if len(inst.NotificationGroupIDs) > 0 {
-		fmt.Printf("  Notification Groups: %v\n", inst.NotificationGroupIDs)
-	}
-	if inst.CreatedYmdt != "" {
-		fmt.Printf("  Created: %s\n", inst.CreatedYmdt)
-	}
-	if inst.UpdatedYmdt != "" {
-		fmt.Printf("  Updated: %s\n", inst.UpdatedYmdt)
-	}
-}
-
 func printJSON(v interface{}) {
 	b, _ := json.MarshalIndent(v, "", "  ")
 	fmt.Println(string(b))
@@ -424,13 +304,8 @@ func init() {
 	rootCmd.AddCommand(rdsMySQLCmd)
 
 	// Instance commands
-	rdsMySQLCmd.AddCommand(describeDBInstancesCmd)
 	rdsMySQLCmd.AddCommand(createDBInstanceCmd)
 	rdsMySQLCmd.AddCommand(modifyDBInstanceCmd)
-	rdsMySQLCmd.AddCommand(deleteDBInstanceCmd)
-
-	// describe-db-instances flags
-	describeDBInstancesCmd.Flags().String("db-instance-identifier", "", "DB instance identifier")
 
 	// create-db-instance flags (required)
 	createDBInstanceCmd.Flags().String("db-instance-identifier", "", "DB instance identifier (required)")
@@ -451,6 +326,7 @@ func init() {
 	createDBInstanceCmd.Flags().Bool("multi-az", false, "Enable multi-AZ deployment")
 	createDBInstanceCmd.Flags().Int("backup-retention-period", 0, "Backup retention period in days")
 	createDBInstanceCmd.Flags().String("backup-window", "00:00", "Backup window time (HH:MM)")
+	addRDSFromSpecFlags(createDBInstanceCmd)
 
 	// Storage auto-scale block (storage.storageAutoscale.*)
 	// Ref: docs/api-specs/database/rds-mysql-v4.0.md#db-인스턴스-생성하기
@@ -491,8 +367,4 @@ func init() {
 	modifyDBInstanceCmd.Flags().String("db-flavor-id", "", "New DB flavor ID")
 	modifyDBInstanceCmd.Flags().StringSlice("db-security-group-ids", nil, "New DB security group IDs (comma-separated)")
 	modifyDBInstanceCmd.Flags().Int("port", 0, "New database port")
-
-	// delete-db-instance flags
-	deleteDBInstanceCmd.Flags().String("db-instance-identifier", "", "DB instance identifier (required)")
-	deleteDBInstanceCmd.Flags().Bool("yes", false, "Confirm non-interactive delete (required)")
 }
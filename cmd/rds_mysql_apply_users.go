@@ -0,0 +1,512 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/haung921209/nhn-cloud-cli/internal/rdsengine"
+	"github.com/haung921209/nhn-cloud-sdk-go/nhncloud/database/mysql"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// ============================================================================
+// Declarative DB users, schemas and user groups (apply-users)
+// ============================================================================
+
+// rdsPasswordSource says where a password comes from; passwords are never
+// written in spec files
+type rdsPasswordSource struct {
+	Env              string `yaml:"env,omitempty" json:"env,omitempty"`
+	KeyManagerSecret string `yaml:"keyManagerSecret,omitempty" json:"keyManagerSecret,omitempty"`
+}
+
+func (s *rdsPasswordSource) isSet() bool {
+	return s != nil && (s.Env != "" || s.KeyManagerSecret != "")
+}
+
+func (s *rdsPasswordSource) String() string {
+	switch {
+	case s == nil:
+		return ""
+	case s.Env != "":
+		return "env " + s.Env
+	default:
+		return "Key Manager secret " + s.KeyManagerSecret
+	}
+}
+
+// resolve reads the password from the environment or Key Manager
+func (s *rdsPasswordSource) resolve(ctx context.Context) (string, error) {
+	switch {
+	case s == nil:
+		return "", fmt.Errorf("no password source")
+	case s.Env != "":
+		password := os.Getenv(s.Env)
+		if password == "" {
+			return "", fmt.Errorf("environment variable %s is not set", s.Env)
+		}
+		return password, nil
+	case s.KeyManagerSecret != "":
+		result, err := newKeyManagerClient().GetSecret(ctx, s.KeyManagerSecret)
+		if err != nil {
+			return "", err
+		}
+		if result.Body.Secret == "" {
+			return "", fmt.Errorf("Key Manager secret %s is empty", s.KeyManagerSecret)
+		}
+		return result.Body.Secret, nil
+	}
+	return "", fmt.Errorf("no password source")
+}
+
+// mysqlUsersSpec is the YAML file read by apply-users
+type mysqlUsersSpec struct {
+	Schemas    []string             `yaml:"schemas,omitempty"`
+	Users      []mysqlUserSpec      `yaml:"users,omitempty"`
+	UserGroups []mysqlUserGroupSpec `yaml:"userGroups,omitempty"`
+}
+
+type mysqlUserSpec struct {
+	Name                 string             `yaml:"name"`
+	Host                 string             `yaml:"host,omitempty"`
+	AuthorityType        string             `yaml:"authorityType"`
+	AuthenticationPlugin string             `yaml:"authenticationPlugin,omitempty"`
+	TLSOption            string             `yaml:"tlsOption,omitempty"`
+	Password             *rdsPasswordSource `yaml:"password,omitempty"`
+}
+
+type mysqlUserGroupSpec struct {
+	Name       string   `yaml:"name"`
+	Members    []string `yaml:"members,omitempty"`
+	AllMembers bool     `yaml:"allMembers,omitempty"`
+}
+
+// mysqlUserAction is one planned change of apply-users
+type mysqlUserAction struct {
+	Action string `json:"action" yaml:"action"`
+	Kind   string `json:"kind" yaml:"kind"`
+	Name   string `json:"name" yaml:"name"`
+	Detail string `json:"detail,omitempty" yaml:"detail,omitempty"`
+
+	id    string
+	user  *mysqlUserSpec
+	group *mysqlUserGroupSpec
+	// job is true for actions that run as an instance job
+	job         bool
+	setPassword bool
+}
+
+// loadMySQLUsersSpec reads and validates an apply-users file
+func loadMySQLUsersSpec(file string) (*mysqlUsersSpec, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var spec mysqlUsersSpec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", file, err)
+	}
+
+	seen := map[string]bool{}
+	for i := range spec.Users {
+		u := &spec.Users[i]
+		if u.Host == "" {
+			u.Host = "%"
+		}
+		u.AuthorityType = strings.ToUpper(u.AuthorityType)
+		if u.Name == "" || u.AuthorityType == "" {
+			return nil, fmt.Errorf("%s: users[%d] needs name and authorityType", file, i)
+		}
+		if u.Password != nil && u.Password.Env != "" && u.Password.KeyManagerSecret != "" {
+			return nil, fmt.Errorf("%s: user %s: set password.env or password.keyManagerSecret, not both", file, u.Name)
+		}
+		key := u.Name + "@" + u.Host
+		if seen[key] {
+			return nil, fmt.Errorf("%s: user %s is listed twice", file, key)
+		}
+		seen[key] = true
+	}
+	for i, g := range spec.UserGroups {
+		if g.Name == "" {
+			return nil, fmt.Errorf("%s: userGroups[%d] needs a name", file, i)
+		}
+	}
+	return &spec, nil
+}
+
+// planMySQLUsers diffs the spec against the instance and the project's user
+// groups. Users, schemas and groups missing from the spec are only deleted
+// with prune; passwords of existing users are only set with updatePasswords.
+func planMySQLUsers(ctx context.Context, client *mysql.Client, instanceID string, spec *mysqlUsersSpec, prune, updatePasswords bool) ([]mysqlUserAction, error) {
+	var plan []mysqlUserAction
+
+	schemas, err := client.ListSchemas(ctx, instanceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list schemas: %w", err)
+	}
+	existingSchemas := map[string]string{}
+	for _, s := range schemas.DBSchemas {
+		existingSchemas[s.DBSchemaName] = s.DBSchemaID
+	}
+	wantSchemas := map[string]bool{}
+	for _, name := range spec.Schemas {
+		wantSchemas[name] = true
+		if _, ok := existingSchemas[name]; !ok {
+			plan = append(plan, mysqlUserAction{Action: "create", Kind: "schema", Name: name, job: true})
+		}
+	}
+
+	users, err := client.ListDBUsers(ctx, instanceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list DB users: %w", err)
+	}
+	existingUsers := map[string]mysql.DBUser{}
+	for _, u := range users.DBUsers {
+		existingUsers[u.DBUserName+"@"+u.Host] = u
+	}
+	wantUsers := map[string]bool{}
+	for i := range spec.Users {
+		u := &spec.Users[i]
+		key := u.Name + "@" + u.Host
+		wantUsers[key] = true
+		current, ok := existingUsers[key]
+		if !ok {
+			if !u.Password.isSet() {
+				return nil, fmt.Errorf("user %s does not exist and has no password source", key)
+			}
+			plan = append(plan, mysqlUserAction{Action: "create", Kind: "user", Name: key,
+				Detail: fmt.Sprintf("authority %s, password from %s", u.AuthorityType, u.Password), user: u, job: true})
+			continue
+		}
+		var changes []string
+		if !strings.EqualFold(current.AuthorityType, u.AuthorityType) {
+			changes = append(changes, fmt.Sprintf("authority %s -> %s", current.AuthorityType, u.AuthorityType))
+		}
+		if u.AuthenticationPlugin != "" && !strings.EqualFold(current.AuthenticationPlugin, u.AuthenticationPlugin) {
+			changes = append(changes, fmt.Sprintf("plugin %s -> %s", current.AuthenticationPlugin, u.AuthenticationPlugin))
+		}
+		if u.TLSOption != "" && !strings.EqualFold(current.TLSOption, u.TLSOption) {
+			changes = append(changes, fmt.Sprintf("tls %s -> %s", current.TLSOption, u.TLSOption))
+		}
+		setPassword := updatePasswords && u.Password.isSet()
+		if setPassword {
+			changes = append(changes, "password from "+u.Password.String())
+		}
+		if len(changes) > 0 {
+			plan = append(plan, mysqlUserAction{Action: "update", Kind: "user", Name: key,
+				Detail: strings.Join(changes, ", "), id: current.DBUserID, user: u, job: true, setPassword: setPassword})
+		}
+	}
+
+	groups, err := client.ListUserGroups(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user groups: %w", err)
+	}
+	existingGroups := map[string]string{}
+	for _, g := range groups.UserGroups {
+		existingGroups[g.UserGroupName] = g.UserGroupID
+	}
+	wantGroups := map[string]bool{}
+	for i := range spec.UserGroups {
+		g := &spec.UserGroups[i]
+		wantGroups[g.Name] = true
+		id, ok := existingGroups[g.Name]
+		if !ok {
+			detail := fmt.Sprintf("%d member(s)", len(g.Members))
+			if g.AllMembers {
+				detail = "all project members"
+			}
+			plan = append(plan, mysqlUserAction{Action: "create", Kind: "user-group", Name: g.Name, Detail: detail, group: g})
+			continue
+		}
+		if g.AllMembers {
+			continue
+		}
+		current, err := client.GetUserGroup(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user group %s: %w", g.Name, err)
+		}
+		have := map[string]bool{}
+		for _, m := range current.Members {
+			have[m.MemberID] = true
+		}
+		added := 0
+		for _, m := range g.Members {
+			if !have[m] {
+				added++
+			}
+			delete(have, m)
+		}
+		removed := len(have)
+		if added > 0 || removed > 0 {
+			plan = append(plan, mysqlUserAction{Action: "update", Kind: "user-group", Name: g.Name,
+				Detail: fmt.Sprintf("+%d/-%d member(s)", added, removed), id: id, group: g})
+		}
+	}
+
+	// Sections left out of the file are not pruned, and neither is the
+	// administrator account created with the instance
+	if prune && spec.Users != nil {
+		admins := mysqlAdminUsers(users.DBUsers)
+		for _, key := range sortedKeys(existingUsers) {
+			if !wantUsers[key] && !admins[key] {
+				plan = append(plan, mysqlUserAction{Action: "delete", Kind: "user", Name: key, id: existingUsers[key].DBUserID, job: true})
+			}
+		}
+	}
+	if prune && spec.Schemas != nil {
+		for _, name := range sortedKeys(existingSchemas) {
+			if !wantSchemas[name] {
+				plan = append(plan, mysqlUserAction{Action: "delete", Kind: "schema", Name: name, id: existingSchemas[name], job: true})
+			}
+		}
+	}
+	if prune && spec.UserGroups != nil {
+		for _, name := range sortedKeys(existingGroups) {
+			if !wantGroups[name] {
+				plan = append(plan, mysqlUserAction{Action: "delete", Kind: "user-group", Name: name, id: existingGroups[name]})
+			}
+		}
+	}
+	return plan, nil
+}
+
+// mysqlAdminUsers returns the name@host of the administrator account created
+// with the instance: the oldest DB user. The API does not flag it, so when a
+// user has no creation time every DDL user is kept as a possible
+// administrator.
+func mysqlAdminUsers(users []mysql.DBUser) map[string]bool {
+	var admin string
+	var oldest time.Time
+	for _, u := range users {
+		created, ok := parseFilterDate(u.CreatedAt)
+		if !ok {
+			admins := map[string]bool{}
+			for _, u := range users {
+				if strings.EqualFold(u.AuthorityType, "DDL") {
+					admins[u.DBUserName+"@"+u.Host] = true
+				}
+			}
+			return admins
+		}
+		if admin == "" || created.Before(oldest) {
+			admin, oldest = u.DBUserName+"@"+u.Host, created
+		}
+	}
+	return map[string]bool{admin: true}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// executeMySQLUserAction performs one planned change
+func executeMySQLUserAction(ctx context.Context, client *mysql.Client, instanceID string, a mysqlUserAction) error {
+	switch a.Kind + " " + a.Action {
+	case "schema create":
+		_, err := client.CreateSchema(ctx, instanceID, &mysql.CreateSchemaRequest{DBSchemaName: a.Name})
+		return err
+	case "schema delete":
+		_, err := client.DeleteSchema(ctx, instanceID, a.id)
+		return err
+	case "user create":
+		password, err := a.user.Password.resolve(ctx)
+		if err != nil {
+			return err
+		}
+		_, err = client.CreateDBUser(ctx, instanceID, &mysql.CreateDBUserRequest{
+			DBUserName:           a.user.Name,
+			DBPassword:           password,
+			Host:                 a.user.Host,
+			AuthorityType:        a.user.AuthorityType,
+			AuthenticationPlugin: a.user.AuthenticationPlugin,
+			TLSOption:            a.user.TLSOption,
+		})
+		return err
+	case "user update":
+		req := &mysql.UpdateDBUserRequest{AuthorityType: &a.user.AuthorityType}
+		if a.user.AuthenticationPlugin != "" {
+			req.AuthenticationPlugin = &a.user.AuthenticationPlugin
+		}
+		if a.user.TLSOption != "" {
+			req.TLSOption = &a.user.TLSOption
+		}
+		if a.setPassword {
+			password, err := a.user.Password.resolve(ctx)
+			if err != nil {
+				return err
+			}
+			req.DBPassword = &password
+		}
+		_, err := client.UpdateDBUser(ctx, instanceID, a.id, req)
+		return err
+	case "user delete":
+		_, err := client.DeleteDBUser(ctx, instanceID, a.id)
+		return err
+	case "user-group create":
+		_, err := client.CreateUserGroup(ctx, &mysql.CreateUserGroupRequest{
+			UserGroupName: a.group.Name,
+			MemberIDs:     a.group.Members,
+			SelectAllYN:   a.group.AllMembers,
+		})
+		return err
+	case "user-group update":
+		_, err := client.UpdateUserGroup(ctx, a.id, &mysql.UpdateUserGroupRequest{MemberIDs: a.group.Members})
+		return err
+	case "user-group delete":
+		_, err := client.DeleteUserGroup(ctx, a.id)
+		return err
+	}
+	return fmt.Errorf("unsupported action %s %s", a.Action, a.Kind)
+}
+
+var mysqlApplyUsersCmd = &cobra.Command{
+	Use:   "apply-users",
+	Short: "Converge DB users, schemas and user groups to a YAML file",
+	Long: `Compares a YAML file of DB users, schemas and user groups with the
+instance (and the project's user groups), prints the plan and creates or
+updates what differs. Objects missing from the file are deleted only with
+--prune, and only for the sections (schemas, users, userGroups) the file
+contains. The administrator account created with the instance is never
+pruned. A plan with deletes is confirmed interactively unless --yes is
+given.
+
+Passwords are never written in the file: each user names an environment
+variable or a Key Manager secret. Existing users keep their password unless
+--update-passwords is given.
+
+  schemas:
+    - app
+  users:
+    - name: app
+      host: "%"                  # default "%"
+      authorityType: CRUD        # READ, CRUD or DDL
+      authenticationPlugin: caching_sha2_password
+      tlsOption: SSL
+      password:
+        env: APP_DB_PASSWORD     # or keyManagerSecret: <key-id>
+  userGroups:
+    - name: dba
+      members: [<member-uuid>]   # or allMembers: true
+
+Instance changes run one at a time; each waits for the instance's job to
+finish.
+
+Examples:
+  nhncloud rds-mysql apply-users --db-instance-identifier mydb -f users.yaml --dry-run
+  APP_DB_PASSWORD=... nhncloud rds-mysql apply-users --db-instance-identifier mydb -f users.yaml --prune --yes`,
+	Run: func(cmd *cobra.Command, args []string) {
+		file, _ := cmd.Flags().GetString("file")
+		prune, _ := cmd.Flags().GetBool("prune")
+		updatePasswords, _ := cmd.Flags().GetBool("update-passwords")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		yes, _ := cmd.Flags().GetBool("yes")
+		timeout := getDurationFlag(cmd, "timeout")
+		if file == "" {
+			exitWithError("--file is required", nil)
+		}
+		spec, err := loadMySQLUsersSpec(file)
+		if err != nil {
+			exitWithError("invalid users file", err)
+		}
+
+		client := newMySQLClient()
+		instanceID, err := getResolvedInstanceID(cmd, client)
+		if err != nil {
+			exitWithError("failed to resolve instance identifier", err)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		plan, err := planMySQLUsers(ctx, client, instanceID, spec, prune, updatePasswords)
+		if err != nil {
+			exitWithError("failed to plan changes", err)
+		}
+		structured := output == "json" || output == "yaml"
+		printResult := func() {
+			result := map[string]interface{}{
+				"dbInstanceId": instanceID,
+				"dryRun":       dryRun,
+				"changes":      plan,
+			}
+			if err := printOutput(result); err != nil {
+				exitWithError("failed to print result", err)
+			}
+		}
+		if len(plan) == 0 {
+			if structured {
+				printResult()
+			} else {
+				fmt.Println("Users, schemas and user groups are up to date")
+			}
+			return
+		}
+		if !structured {
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "ACTION\tKIND\tNAME\tDETAIL")
+			for _, a := range plan {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", a.Action, a.Kind, a.Name, a.Detail)
+			}
+			w.Flush()
+		}
+		if dryRun {
+			if structured {
+				printResult()
+			}
+			return
+		}
+		deletes := 0
+		for _, a := range plan {
+			if a.Action == "delete" {
+				deletes++
+			}
+		}
+		if deletes > 0 && !yes && !promptYesNo(fmt.Sprintf("Apply %d change(s), including %d delete(s)?", len(plan), deletes)) {
+			fmt.Fprintln(os.Stderr, "Aborted.")
+			return
+		}
+
+		e := newRDSEngine(rdsengine.MySQL)
+		for _, a := range plan {
+			if err := executeMySQLUserAction(ctx, client, instanceID, a); err != nil {
+				exitWithError(fmt.Sprintf("failed to %s %s %s", a.Action, a.Kind, a.Name), err)
+			}
+			if a.job {
+				if err := waitForRDSJob(ctx, e, instanceID, 5*time.Second, nil); err != nil {
+					exitWithError(fmt.Sprintf("%s %s %s did not finish", a.Action, a.Kind, a.Name), err)
+				}
+			}
+			if !structured {
+				fmt.Printf("%s %s %s: done\n", a.Action, a.Kind, a.Name)
+			}
+		}
+		if structured {
+			printResult()
+		} else {
+			fmt.Printf("Applied %d change(s)\n", len(plan))
+		}
+	},
+}
+
+func init() {
+	rdsMySQLCmd.AddCommand(mysqlApplyUsersCmd)
+
+	mysqlApplyUsersCmd.Flags().String("db-instance-identifier", "", "DB instance identifier (required)")
+	mysqlApplyUsersCmd.Flags().StringP("file", "f", "", "Users YAML file (required)")
+	mysqlApplyUsersCmd.Flags().Bool("prune", false, "Delete users, schemas and user groups missing from the file")
+	mysqlApplyUsersCmd.Flags().Bool("update-passwords", false, "Also set the passwords of existing users from their sources")
+	mysqlApplyUsersCmd.Flags().Bool("dry-run", false, "Show the plan without applying it")
+	mysqlApplyUsersCmd.Flags().Bool("yes", false, "Apply deletes without confirmation")
+	mysqlApplyUsersCmd.Flags().String("timeout", "30m", "Maximum time to apply the plan (Go duration)")
+}
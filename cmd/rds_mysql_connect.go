@@ -2,12 +2,13 @@ package cmd
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
 	"os"
 	"os/exec"
+	"strconv"
 
 	"github.com/haung921209/nhn-cloud-cli/internal/cert"
+	"github.com/haung921209/nhn-cloud-cli/internal/rdsengine"
 	"github.com/spf13/cobra"
 )
 
@@ -18,13 +19,23 @@ var connectMySQLCmd = &cobra.Command{
 Automatically configures SSL/TLS certificates if available in the managed store.
 
 Prerequisites:
-- 'mysql' client must be installed in PATH.
+- 'mysql' client in PATH (otherwise the built-in shell is used).
 - Certificates imported via 'nhncloud config ca import'.
-- Instance must be accessible (Public Access or VPN).
+- Instance must be accessible (Public Access, VPN, or --via-bastion).
+
+Without a 'mysql' client (or with --native) the built-in shell is used:
+verified TLS with the CA from the cert store, aligned tables, \G vertical
+output, line editing with history (~/.nhncloud/history), source <file>,
+\timing, \o <file> to CSV/JSON and Ctrl-C to cancel a running query.
+Type \? in the shell for all commands.
+
+With --via-bastion, the private endpoint is reached through an SSH tunnel
+on the given compute instance and the client is pointed at the tunnel.
 
 Example:
   nhncloud rds-mysql connect --db-instance-identifier <uuid> --username <user> --password <pass> --database <db>
-  nhncloud rds-mysql connect ... -- -e "SELECT 1;"`,
+  nhncloud rds-mysql connect ... -- -e "SELECT 1;"
+  nhncloud rds-mysql connect --db-instance-identifier mydb --username <user> --password <pass> --via-bastion bastion`,
 	Args: cobra.ArbitraryArgs,
 	Run: func(cmd *cobra.Command, args []string) {
 		dbInstanceID, err := getResolvedInstanceID(cmd, newMySQLClient())
@@ -79,14 +90,71 @@ Example:
 			}
 		}
 
-		if host == "" {
-			exitWithError(fmt.Sprintf("Unable to determine public host for instance '%s'. Only instances with Public Access enabled can be connected to via CLI.", inst.DBInstanceName), nil)
+		viaBastion, _ := cmd.Flags().GetString("via-bastion")
+		if host == "" && viaBastion == "" {
+			exitWithError(fmt.Sprintf("Unable to determine public host for instance '%s'. Only instances with Public Access enabled can be connected to via CLI (or use --via-bastion).", inst.DBInstanceName), nil)
 		}
 
 		port := fmt.Sprintf("%d", inst.DBPort)
+		serverName := host
+
+		// Private instances are reached through an SSH tunnel on a bastion
+		if viaBastion != "" {
+			var endpoints []rdsEndpoint
+			if netInfo, err := client.GetNetworkInfo(context.Background(), dbInstanceID); err == nil && netInfo != nil {
+				for _, ep := range netInfo.EndPoints {
+					endpoints = append(endpoints, rdsEndpoint{Type: ep.EndPointType, Domain: ep.Domain, IPAddress: ep.IPAddress})
+				}
+			}
+			var fallbacks []string
+			if inst.Network != nil {
+				fallbacks = append(fallbacks, inst.Network.DomainName, inst.Network.IPAddress)
+			}
+			privateHost := privateRDSHost(endpoints, fallbacks...)
+			if privateHost == "" {
+				exitWithError(fmt.Sprintf("Unable to determine private host for instance '%s'", inst.DBInstanceName), nil)
+			}
+
+			tunnel, err := openBastionTunnelFromFlags(cmd, privateHost, inst.DBPort)
+			if err != nil {
+				exitWithError("failed to open bastion tunnel", err)
+			}
+			defer tunnel.Close()
+
+			host = tunnel.LocalHost()
+			port = fmt.Sprintf("%d", tunnel.LocalPort())
+			serverName = privateHost
+		}
 
 		fmt.Printf("Connecting to %s (%s:%s)...\n", inst.DBInstanceName, host, port)
 
+		// Fall back to the built-in shell when the mysql client is missing
+		native, _ := cmd.Flags().GetBool("native")
+		if _, err := exec.LookPath("mysql"); native || (err != nil && len(args) == 0) {
+			if !native {
+				fmt.Println("Notice: 'mysql' client not found in PATH. Falling back to built-in native shell.")
+			}
+			portNum, _ := strconv.Atoi(port)
+			db, err := openRDSDatabase(context.Background(), rdsengine.MySQL, rdsSQLTarget{
+				Host:       host,
+				Port:       portNum,
+				ServerName: serverName,
+				Username:   username,
+				Password:   password,
+				Database:   database,
+				Region:     getRegion(),
+				InstanceID: dbInstanceID,
+				Version:    inst.DBVersion,
+			})
+			if err != nil {
+				exitWithError("failed to connect to database", err)
+			}
+			defer db.Close()
+
+			runRDSShell(db, rdsengine.MySQL, database)
+			return
+		}
+
 		authPlugin, _ := cmd.Flags().GetString("auth-plugin")
 
 		// Setup Cert Helper
@@ -142,34 +210,6 @@ Example:
 		c.Stdout = os.Stdout
 		c.Stderr = os.Stderr
 
-		// Check for missing mysql client
-		if _, err := exec.LookPath("mysql"); err != nil && len(args) == 0 {
-			// Fallback to Native REPL
-			fmt.Println("Notice: 'mysql' client not found in PATH. Falling back to built-in native shell.")
-
-			// Construct DSN
-			// TODO: Add SSL support if we want parity with strict mode,
-			// but for REPL fallback we'll try basic connection first or skip-verify.
-
-			// We need to register the CA if we want strict SSL.
-			// Currently simplified to skip-verify for fallback functionality to work.
-			dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?tls=skip-verify&allowNativePasswords=true",
-				username, password, host, port, database)
-
-			db, err := sql.Open("mysql", dsn)
-			if err != nil {
-				exitWithError("failed to open database connection", err)
-			}
-			defer db.Close()
-
-			if err := db.Ping(); err != nil {
-				exitWithError("failed to connect/ping database", err)
-			}
-
-			runNativeREPL(db, database, "mysql")
-			return
-		}
-
 		if err := c.Run(); err != nil {
 			exitWithError("connection failed", err)
 		}
@@ -183,4 +223,6 @@ func init() {
 	connectMySQLCmd.Flags().String("password", "", "Database password")
 	connectMySQLCmd.Flags().String("database", "", "Database name")
 	connectMySQLCmd.Flags().String("auth-plugin", "caching_sha2_password", "Authentication plugin (caching_sha2_password, mysql_native_password)")
+	connectMySQLCmd.Flags().Bool("native", false, "Use the built-in shell even if the mysql client is installed")
+	addBastionFlags(connectMySQLCmd)
 }
@@ -97,33 +97,6 @@ var mysqlDeleteNotificationGroupCmd = &cobra.Command{
 	},
 }
 
-// ============================================================================
-// Log Commands
-// ============================================================================
-
-var mysqlDescribeLogsCmd = &cobra.Command{
-	Use:   "describe-logs",
-	Short: "Describe log files for an instance",
-	Run: func(cmd *cobra.Command, args []string) {
-		client := newMySQLClient()
-		instanceID, err := getResolvedInstanceID(cmd, client)
-		if err != nil {
-			exitWithError("failed to resolve instance ID", err)
-		}
-
-		result, err := client.ListLogFiles(context.Background(), instanceID)
-		if err != nil {
-			exitWithError("failed to list log files", err)
-		}
-
-		if output == "json" {
-			printJSON(result)
-		} else {
-			mysqlPrintLogFileList(result)
-		}
-	},
-}
-
 // ============================================================================
 // Print Functions
 // ============================================================================
@@ -154,19 +127,6 @@ func mysqlPrintNotificationGroupDetail(result *mysql.GetNotificationGroupRespons
 	}
 }
 
-func mysqlPrintLogFileList(result *mysql.ListLogFilesResponse) {
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintln(w, "FILE_NAME\tSIZE\tMODIFIED")
-	for _, log := range result.LogFiles {
-		fmt.Fprintf(w, "%s\t%d\t%s\n",
-			log.LogFileName,
-			log.LogFileSize,
-			log.ModifiedAt,
-		)
-	}
-	w.Flush()
-}
-
 func init() {
 	// Notification Group commands
 	rdsMySQLCmd.AddCommand(mysqlDescribeNotificationGroupsCmd)
@@ -181,8 +141,4 @@ func init() {
 	mysqlCreateNotificationGroupCmd.Flags().StringSlice("notify-sms", nil, "Phone numbers for SMS notifications")
 
 	mysqlDeleteNotificationGroupCmd.Flags().String("notification-group-id", "", "Notification group ID (required)")
-
-	// Log commands
-	rdsMySQLCmd.AddCommand(mysqlDescribeLogsCmd)
-	mysqlDescribeLogsCmd.Flags().String("db-instance-identifier", "", "DB instance identifier (required)")
 }
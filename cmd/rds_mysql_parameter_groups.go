@@ -12,26 +12,6 @@ import (
 // Parameter Group Commands
 // ============================================================================
 
-var describeDBParameterGroupsCmd = &cobra.Command{
-	Use:   "describe-db-parameter-groups",
-	Short: "Describe MySQL DB parameter groups",
-	Run: func(cmd *cobra.Command, args []string) {
-		client := newMySQLClient()
-		result, err := client.ListParameterGroups(context.Background())
-		if err != nil {
-			exitWithError("failed to list parameter groups", err)
-		}
-
-		if output == "json" {
-			printJSON(result)
-		} else {
-			for _, pg := range result.ParameterGroups {
-				fmt.Printf("%s: %s\n", pg.ParameterGroupID, pg.ParameterGroupName)
-			}
-		}
-	},
-}
-
 var createDBParameterGroupCmd = &cobra.Command{
 	Use:   "create-db-parameter-group",
 	Short: "Create a DB parameter group",
@@ -118,7 +98,6 @@ var deleteDBParameterGroupCmd = &cobra.Command{
 // ============================================================================
 
 func init() {
-	rdsMySQLCmd.AddCommand(describeDBParameterGroupsCmd)
 	rdsMySQLCmd.AddCommand(createDBParameterGroupCmd)
 	rdsMySQLCmd.AddCommand(modifyDBParameterGroupCmd)
 	rdsMySQLCmd.AddCommand(deleteDBParameterGroupCmd)
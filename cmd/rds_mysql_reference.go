@@ -42,32 +42,6 @@ var describeDBInstanceClassesCmd = &cobra.Command{
 	},
 }
 
-var describeDBEngineVersionsCmd = &cobra.Command{
-	Use:   "describe-db-engine-versions",
-	Short: "Describe available MySQL engine versions",
-	Run: func(cmd *cobra.Command, args []string) {
-		client := newMySQLClient()
-		result, err := client.ListVersions(context.Background())
-		if err != nil {
-			exitWithError("failed to list versions", err)
-		}
-
-		if output == "json" {
-			printJSON(result)
-		} else {
-			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-			fmt.Fprintln(w, "VERSION_ID\tVERSION_NAME")
-			for _, version := range result.DBVersions {
-				fmt.Fprintf(w, "%s\t%s\n",
-					version.DBVersion,
-					version.DBVersionName,
-				)
-			}
-			w.Flush()
-		}
-	},
-}
-
 var describeSubnetsCmd = &cobra.Command{
 	Use:   "describe-subnets",
 	Short: "Describe available subnets",
@@ -124,7 +98,6 @@ var describeStorageTypesCmd = &cobra.Command{
 
 func init() {
 	rdsMySQLCmd.AddCommand(describeDBInstanceClassesCmd)
-	rdsMySQLCmd.AddCommand(describeDBEngineVersionsCmd)
 	rdsMySQLCmd.AddCommand(describeSubnetsCmd)
 	rdsMySQLCmd.AddCommand(describeStorageTypesCmd)
 }
@@ -12,26 +12,6 @@ import (
 // Security Group Commands
 // ============================================================================
 
-var describeDBSecurityGroupsCmd = &cobra.Command{
-	Use:   "describe-db-security-groups",
-	Short: "Describe MySQL DB security groups",
-	Run: func(cmd *cobra.Command, args []string) {
-		client := newMySQLClient()
-		result, err := client.ListSecurityGroups(context.Background())
-		if err != nil {
-			exitWithError("failed to list security groups", err)
-		}
-
-		if output == "json" {
-			printJSON(result)
-		} else {
-			for _, sg := range result.DBSecurityGroups {
-				fmt.Printf("%s: %s (%d rules)\n", sg.DBSecurityGroupID, sg.DBSecurityGroupName, len(sg.Rules))
-			}
-		}
-	},
-}
-
 var createDBSecurityGroupCmd = &cobra.Command{
 	Use:   "create-db-security-group",
 	Short: "Create a DB security group",
@@ -133,7 +113,6 @@ var deleteDBSecurityGroupCmd = &cobra.Command{
 // ============================================================================
 
 func init() {
-	rdsMySQLCmd.AddCommand(describeDBSecurityGroupsCmd)
 	rdsMySQLCmd.AddCommand(createDBSecurityGroupCmd)
 	rdsMySQLCmd.AddCommand(authorizeDBSecurityGroupIngressCmd)
 	rdsMySQLCmd.AddCommand(deleteDBSecurityGroupCmd)
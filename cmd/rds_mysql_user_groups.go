@@ -95,61 +95,6 @@ var mysqlDeleteUserGroupCmd = &cobra.Command{
 	},
 }
 
-// ============================================================================
-// Monitoring Commands
-// ============================================================================
-
-var mysqlDescribeMetricsCmd = &cobra.Command{
-	Use:   "describe-metrics",
-	Short: "Describe available monitoring metrics",
-	Run: func(cmd *cobra.Command, args []string) {
-		client := newMySQLClient()
-
-		result, err := client.ListMetrics(context.Background())
-		if err != nil {
-			exitWithError("failed to list metrics", err)
-		}
-
-		if output == "json" {
-			printJSON(result)
-		} else {
-			mysqlPrintMetricList(result)
-		}
-	},
-}
-
-var mysqlGetMetricStatisticsCmd = &cobra.Command{
-	Use:   "get-metric-statistics",
-	Short: "Get metric statistics for an instance",
-	Run: func(cmd *cobra.Command, args []string) {
-		client := newMySQLClient()
-
-		instanceID, err := getResolvedInstanceID(cmd, client)
-		if err != nil {
-			exitWithError("failed to resolve instance ID", err)
-		}
-
-		from, _ := cmd.Flags().GetString("from")
-		to, _ := cmd.Flags().GetString("to")
-		interval, _ := cmd.Flags().GetInt("interval")
-
-		if from == "" || to == "" {
-			exitWithError("--from and --to are required (ISO8601 format)", nil)
-		}
-
-		result, err := client.GetMetricStatistics(context.Background(), instanceID, from, to, interval)
-		if err != nil {
-			exitWithError("failed to get metric statistics", err)
-		}
-
-		if output == "json" {
-			printJSON(result)
-		} else {
-			mysqlPrintMetricStatistics(result)
-		}
-	},
-}
-
 // ============================================================================
 // Print Functions
 // ============================================================================
@@ -181,28 +126,6 @@ func mysqlPrintUserGroupDetail(result *mysql.GetUserGroupResponse) {
 	}
 }
 
-func mysqlPrintMetricList(result *mysql.ListMetricsResponse) {
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintln(w, "METRIC_NAME\tUNIT")
-	for _, m := range result.Metrics {
-		fmt.Fprintf(w, "%s\t%s\n", m.MetricName, m.Unit)
-	}
-	w.Flush()
-}
-
-func mysqlPrintMetricStatistics(result *mysql.GetMetricStatisticsResponse) {
-	for _, stat := range result.MetricStatistics {
-		fmt.Printf("Metric: %s (%s)\n", stat.MetricName, stat.Unit)
-		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-		fmt.Fprintln(w, "  TIMESTAMP\tVALUE")
-		for _, v := range stat.Values {
-			fmt.Fprintf(w, "  %s\t%.2f\n", v.Timestamp, v.Value)
-		}
-		w.Flush()
-		fmt.Println()
-	}
-}
-
 func init() {
 	// User Group commands
 	rdsMySQLCmd.AddCommand(mysqlDescribeUserGroupsCmd)
@@ -216,13 +139,4 @@ func init() {
 	mysqlCreateUserGroupCmd.Flags().Bool("select-all", false, "Select all project members")
 
 	mysqlDeleteUserGroupCmd.Flags().String("user-group-id", "", "User group ID (required)")
-
-	// Monitoring commands
-	rdsMySQLCmd.AddCommand(mysqlDescribeMetricsCmd)
-	rdsMySQLCmd.AddCommand(mysqlGetMetricStatisticsCmd)
-
-	mysqlGetMetricStatisticsCmd.Flags().String("db-instance-identifier", "", "DB instance identifier (required)")
-	mysqlGetMetricStatisticsCmd.Flags().String("from", "", "Start time (ISO8601 format, required)")
-	mysqlGetMetricStatisticsCmd.Flags().String("to", "", "End time (ISO8601 format, required)")
-	mysqlGetMetricStatisticsCmd.Flags().Int("interval", 60, "Interval in seconds (1, 5, 30, 60)")
 }
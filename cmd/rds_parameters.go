@@ -0,0 +1,518 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/haung921209/nhn-cloud-cli/internal/rdsengine"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// ============================================================================
+// Parameter editing, diff and YAML export/import
+// ============================================================================
+
+// rdsParameterGroupSpec is the YAML form of a parameter group
+type rdsParameterGroupSpec struct {
+	Engine      string            `yaml:"engine"`
+	Name        string            `yaml:"name"`
+	Description string            `yaml:"description,omitempty"`
+	DBVersion   string            `yaml:"dbVersion"`
+	Parameters  map[string]string `yaml:"parameters"`
+}
+
+// rdsParameterChange is one planned parameter modification
+type rdsParameterChange struct {
+	Name            string `json:"parameterName"`
+	ID              string `json:"parameterId"`
+	From            string `json:"from"`
+	To              string `json:"to"`
+	ApplyType       string `json:"applyType,omitempty"`
+	RequiresRestart bool   `json:"requiresRestart"`
+}
+
+// resolveRDSParameterGroup looks up a parameter group by ID or name and
+// returns it with its parameters
+func resolveRDSParameterGroup(ctx context.Context, e rdsengine.Engine, identifier string) *rdsengine.ParameterGroup {
+	if identifier == "" {
+		exitWithError("parameter group ID or name is required", nil)
+	}
+	groups, err := e.ListParameterGroups(ctx)
+	if err != nil {
+		exitWithError("failed to list parameter groups", err)
+	}
+	id := ""
+	for _, g := range groups {
+		if g.ID == identifier || g.Name == identifier {
+			if id != "" && id != g.ID {
+				exitWithError(fmt.Sprintf("parameter group name '%s' is ambiguous; use the ID", identifier), nil)
+			}
+			id = g.ID
+		}
+	}
+	if id == "" {
+		exitWithError(fmt.Sprintf("parameter group not found: %s", identifier), nil)
+	}
+	group, err := e.GetParameterGroup(ctx, id)
+	if err != nil {
+		exitWithError("failed to get parameter group", err)
+	}
+	return group
+}
+
+// parameterRequiresRestart reports whether changes to a parameter with the
+// given applyType only take effect after the instance restarts
+func parameterRequiresRestart(applyType string) bool {
+	t := strings.ToUpper(applyType)
+	return strings.Contains(t, "STATIC") || strings.Contains(t, "RESTART") || strings.Contains(t, "REBOOT")
+}
+
+// validateRDSParameter checks a value against the parameter's data type and
+// allowed values. allowedValues is a list ("ON,OFF", "{0|1|2}") and/or
+// numeric ranges ("1-100000", "[0-65535]").
+func validateRDSParameter(p rdsengine.Parameter, value string) error {
+	if !p.Modifiable {
+		return fmt.Errorf("%s is not modifiable", p.Name)
+	}
+
+	switch strings.ToUpper(p.DataType) {
+	case "INT", "INTEGER", "LONG", "BIGINT":
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return fmt.Errorf("%s must be an integer, got '%s'", p.Name, value)
+		}
+	case "FLOAT", "DOUBLE", "NUMERIC", "NUMBER", "REAL":
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Errorf("%s must be a number, got '%s'", p.Name, value)
+		}
+	case "BOOLEAN", "BOOL":
+		switch strings.ToUpper(value) {
+		case "ON", "OFF", "TRUE", "FALSE", "0", "1":
+		default:
+			return fmt.Errorf("%s must be a boolean (ON/OFF), got '%s'", p.Name, value)
+		}
+	}
+
+	allowed := strings.Trim(strings.TrimSpace(p.AllowedValues), "[]{}()")
+	if allowed == "" {
+		return nil
+	}
+	tokens := strings.FieldsFunc(allowed, func(r rune) bool { return r == ',' || r == '|' })
+	for _, token := range tokens {
+		token = strings.TrimSpace(token)
+		if strings.EqualFold(token, value) {
+			return nil
+		}
+		// a range is "<min>-<max>"; a leading '-' belongs to a negative minimum
+		if i := strings.Index(token[min(1, len(token)):], "-"); i >= 0 {
+			lo, errLo := strconv.ParseFloat(strings.TrimSpace(token[:i+1]), 64)
+			hi, errHi := strconv.ParseFloat(strings.TrimSpace(token[i+2:]), 64)
+			v, errV := strconv.ParseFloat(value, 64)
+			if errLo == nil && errHi == nil {
+				if errV == nil && v >= lo && v <= hi {
+					return nil
+				}
+				continue
+			}
+		}
+	}
+	return fmt.Errorf("%s: '%s' is not within allowed values %s", p.Name, value, p.AllowedValues)
+}
+
+// planRDSParameterChanges computes the modifications needed to apply values
+// (keyed by parameter name) to group. Unchanged values are skipped.
+func planRDSParameterChanges(group *rdsengine.ParameterGroup, values map[string]string, force bool) ([]rdsParameterChange, error) {
+	byName := map[string]rdsengine.Parameter{}
+	for _, p := range group.Parameters {
+		byName[p.Name] = p
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var changes []rdsParameterChange
+	var errs []string
+	for _, name := range names {
+		value := values[name]
+		p, ok := byName[name]
+		if !ok {
+			errs = append(errs, fmt.Sprintf("unknown parameter '%s'", name))
+			continue
+		}
+		if p.Value == value {
+			continue
+		}
+		if !force {
+			if err := validateRDSParameter(p, value); err != nil {
+				errs = append(errs, err.Error())
+				continue
+			}
+		}
+		changes = append(changes, rdsParameterChange{
+			Name:            name,
+			ID:              p.ID,
+			From:            p.Value,
+			To:              value,
+			ApplyType:       p.ApplyType,
+			RequiresRestart: parameterRequiresRestart(p.ApplyType),
+		})
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return changes, nil
+}
+
+// applyRDSParameterChanges prints the plan and applies it unless dryRun
+func applyRDSParameterChanges(ctx context.Context, e rdsengine.Engine, group *rdsengine.ParameterGroup, changes []rdsParameterChange, dryRun bool) {
+	if output == "json" {
+		defer printJSON(map[string]interface{}{
+			"parameterGroupId": group.ID,
+			"dryRun":           dryRun,
+			"changes":          changes,
+		})
+	}
+
+	if len(changes) == 0 {
+		if output != "json" {
+			fmt.Printf("Parameter group %s is up to date\n", group.Name)
+		}
+		return
+	}
+
+	if output != "json" {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "PARAMETER\tFROM\tTO\tAPPLY")
+		for _, c := range changes {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", c.Name, c.From, c.To, c.ApplyType)
+		}
+		w.Flush()
+	}
+	if dryRun {
+		return
+	}
+
+	values := map[string]string{}
+	for _, c := range changes {
+		values[c.ID] = c.To
+	}
+	if err := e.ModifyParameters(ctx, group.ID, values); err != nil {
+		exitWithError("failed to modify parameters", err)
+	}
+	if output == "json" {
+		return
+	}
+	fmt.Printf("Modified %d parameter(s) in %s\n", len(changes), group.Name)
+
+	var restart []string
+	for _, c := range changes {
+		if c.RequiresRestart {
+			restart = append(restart, c.Name)
+		}
+	}
+	if len(restart) == 0 {
+		return
+	}
+	fmt.Printf("\nNote: %s only take effect after a restart.\n", strings.Join(restart, ", "))
+	if instances, err := e.ListInstances(ctx); err == nil {
+		for _, inst := range instances {
+			if inst.ParameterGroupID == group.ID {
+				fmt.Printf("  restart with: reboot-db-instance --db-instance-identifier %s\n", inst.Name)
+			}
+		}
+	}
+}
+
+// parseParameterSets parses repeated name=value flags
+func parseParameterSets(sets []string) map[string]string {
+	values := map[string]string{}
+	for _, s := range sets {
+		name, value, ok := strings.Cut(s, "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			exitWithError(fmt.Sprintf("invalid --set '%s', expected name=value", s), nil)
+		}
+		values[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+	return values
+}
+
+func newRDSParameterCommands(engineFor func() rdsengine.Engine) []*cobra.Command {
+	modify := &cobra.Command{
+		Use:   "modify-db-parameters",
+		Short: "Modify parameter values in a DB parameter group",
+		Long: `Sets parameter values in a parameter group. Values are validated against
+each parameter's data type and allowed values before anything is sent
+(--force skips validation). Parameters that only take effect after a
+restart are listed together with the instances using the group.
+
+Examples:
+  nhncloud rds-mysql modify-db-parameters --db-parameter-group-id my-params \
+    --set max_connections=500 --set slow_query_log=ON
+  nhncloud rds --engine postgresql modify-db-parameters --db-parameter-group-id <id> --set work_mem=8192 --dry-run`,
+		Run: func(cmd *cobra.Command, args []string) {
+			e := engineFor()
+			ctx := context.Background()
+
+			identifier, _ := cmd.Flags().GetString("db-parameter-group-id")
+			sets, _ := cmd.Flags().GetStringArray("set")
+			force, _ := cmd.Flags().GetBool("force")
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			if len(sets) == 0 {
+				exitWithError("at least one --set name=value is required", nil)
+			}
+
+			group := resolveRDSParameterGroup(ctx, e, identifier)
+			changes, err := planRDSParameterChanges(group, parseParameterSets(sets), force)
+			if err != nil {
+				exitWithError("invalid parameters", err)
+			}
+			applyRDSParameterChanges(ctx, e, group, changes, dryRun)
+		},
+	}
+	modify.Flags().String("db-parameter-group-id", "", "Parameter group ID or name (required)")
+	modify.Flags().StringArray("set", nil, "Parameter to set: name=value (repeatable)")
+	modify.Flags().Bool("force", false, "Skip type and allowed-value validation")
+	modify.Flags().Bool("dry-run", false, "Show the changes without applying them")
+
+	diff := &cobra.Command{
+		Use:   "diff-db-parameter-groups <group-a> [group-b]",
+		Short: "Show parameters that differ between two parameter groups",
+		Long: `Compares parameter values of two parameter groups (IDs or names), or of
+one group against the engine defaults with --against-defaults.
+
+Examples:
+  nhncloud rds-mysql diff-db-parameter-groups prod-params staging-params
+  nhncloud rds-mariadb diff-db-parameter-groups prod-params --against-defaults`,
+		Args: cobra.RangeArgs(1, 2),
+		Run: func(cmd *cobra.Command, args []string) {
+			e := engineFor()
+			ctx := context.Background()
+			againstDefaults, _ := cmd.Flags().GetBool("against-defaults")
+
+			a := resolveRDSParameterGroup(ctx, e, args[0])
+			aValues := map[string]string{}
+			bValues := map[string]string{}
+			bName := "DEFAULT"
+			for _, p := range a.Parameters {
+				aValues[p.Name] = p.Value
+				if againstDefaults {
+					bValues[p.Name] = p.DefaultValue
+				}
+			}
+			switch {
+			case againstDefaults && len(args) == 2:
+				exitWithError("--against-defaults takes a single parameter group", nil)
+			case !againstDefaults && len(args) != 2:
+				exitWithError("two parameter groups are required (or use --against-defaults)", nil)
+			case !againstDefaults:
+				b := resolveRDSParameterGroup(ctx, e, args[1])
+				bName = b.Name
+				for _, p := range b.Parameters {
+					bValues[p.Name] = p.Value
+				}
+			}
+
+			type paramDiff struct {
+				Name string  `json:"parameterName"`
+				A    *string `json:"a"`
+				B    *string `json:"b"`
+			}
+			names := map[string]bool{}
+			for n := range aValues {
+				names[n] = true
+			}
+			for n := range bValues {
+				names[n] = true
+			}
+			sorted := make([]string, 0, len(names))
+			for n := range names {
+				sorted = append(sorted, n)
+			}
+			sort.Strings(sorted)
+
+			diffs := []paramDiff{}
+			for _, n := range sorted {
+				av, aok := aValues[n]
+				bv, bok := bValues[n]
+				if aok && bok && av == bv {
+					continue
+				}
+				d := paramDiff{Name: n}
+				if aok {
+					d.A = &av
+				}
+				if bok {
+					d.B = &bv
+				}
+				diffs = append(diffs, d)
+			}
+
+			if output == "json" {
+				printJSON(diffs)
+				return
+			}
+			if len(diffs) == 0 {
+				fmt.Println("No differences")
+				return
+			}
+			show := func(v *string) string {
+				if v == nil {
+					return "-"
+				}
+				return *v
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintf(w, "PARAMETER\t%s\t%s\n", a.Name, bName)
+			for _, d := range diffs {
+				fmt.Fprintf(w, "%s\t%s\t%s\n", d.Name, show(d.A), show(d.B))
+			}
+			w.Flush()
+		},
+	}
+	diff.Flags().Bool("against-defaults", false, "Compare the group against the engine default values")
+
+	export := &cobra.Command{
+		Use:   "export-db-parameter-group",
+		Short: "Export a DB parameter group to YAML",
+		Long: `Writes a parameter group as YAML (sorted by parameter name) for review in
+git. The file can be applied with import-db-parameter-group.
+
+Examples:
+  nhncloud rds-mysql export-db-parameter-group --db-parameter-group-id prod-params --file prod-params.yaml
+  nhncloud rds-mysql export-db-parameter-group --db-parameter-group-id prod-params --changed-only`,
+		Run: func(cmd *cobra.Command, args []string) {
+			e := engineFor()
+			identifier, _ := cmd.Flags().GetString("db-parameter-group-id")
+			file, _ := cmd.Flags().GetString("file")
+			changedOnly, _ := cmd.Flags().GetBool("changed-only")
+
+			group := resolveRDSParameterGroup(context.Background(), e, identifier)
+			spec := rdsParameterGroupSpec{
+				Engine:      e.Name(),
+				Name:        group.Name,
+				Description: group.Description,
+				DBVersion:   group.Version,
+				Parameters:  map[string]string{},
+			}
+			for _, p := range group.Parameters {
+				if changedOnly && p.Value == p.DefaultValue {
+					continue
+				}
+				spec.Parameters[p.Name] = p.Value
+			}
+
+			data, err := yaml.Marshal(spec)
+			if err != nil {
+				exitWithError("failed to encode parameter group", err)
+			}
+			if file == "" {
+				os.Stdout.Write(data)
+				return
+			}
+			if err := os.WriteFile(file, data, 0644); err != nil {
+				exitWithError("failed to write file", err)
+			}
+			fmt.Printf("Exported %d parameter(s) of %s to %s\n", len(spec.Parameters), group.Name, file)
+		},
+	}
+	export.Flags().String("db-parameter-group-id", "", "Parameter group ID or name (required)")
+	export.Flags().String("file", "", "Output file (default: stdout)")
+	export.Flags().Bool("changed-only", false, "Only export parameters that differ from the defaults")
+
+	importCmd := &cobra.Command{
+		Use:   "import-db-parameter-group",
+		Short: "Apply a YAML parameter group file",
+		Long: `Applies a file written by export-db-parameter-group. The target group is
+--db-parameter-group-id, or the group named in the file; it is created with
+the file's dbVersion when it does not exist. Only differing values are
+modified.
+
+Examples:
+  nhncloud rds-mysql import-db-parameter-group -f prod-params.yaml --dry-run
+  nhncloud rds-mysql import-db-parameter-group -f prod-params.yaml --db-parameter-group-id staging-params`,
+		Run: func(cmd *cobra.Command, args []string) {
+			e := engineFor()
+			ctx := context.Background()
+
+			file, _ := cmd.Flags().GetString("file")
+			identifier, _ := cmd.Flags().GetString("db-parameter-group-id")
+			force, _ := cmd.Flags().GetBool("force")
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			if file == "" {
+				exitWithError("--file is required", nil)
+			}
+
+			data, err := os.ReadFile(file)
+			if err != nil {
+				exitWithError("failed to read file", err)
+			}
+			var spec rdsParameterGroupSpec
+			if err := yaml.Unmarshal(data, &spec); err != nil {
+				exitWithError(fmt.Sprintf("failed to parse %s", file), err)
+			}
+			if spec.Engine != "" {
+				engine, err := rdsengine.NormalizeName(spec.Engine)
+				if err != nil {
+					exitWithError(fmt.Sprintf("invalid engine in %s", file), err)
+				}
+				if engine != e.Name() {
+					exitWithError(fmt.Sprintf("%s is a %s parameter group, not %s", file, engine, e.Name()), nil)
+				}
+			}
+
+			if identifier == "" {
+				if spec.Name == "" {
+					exitWithError("--db-parameter-group-id is required when the file has no name", nil)
+				}
+				groups, err := e.ListParameterGroups(ctx)
+				if err != nil {
+					exitWithError("failed to list parameter groups", err)
+				}
+				for _, g := range groups {
+					if g.Name == spec.Name {
+						identifier = g.ID
+						break
+					}
+				}
+				if identifier == "" {
+					if dryRun {
+						fmt.Printf("Would create parameter group %s (%s) and set %d parameter(s)\n", spec.Name, spec.DBVersion, len(spec.Parameters))
+						return
+					}
+					if spec.DBVersion == "" {
+						exitWithError(fmt.Sprintf("%s has no dbVersion; cannot create parameter group %s", file, spec.Name), nil)
+					}
+					id, err := e.CreateParameterGroup(ctx, spec.Name, spec.Description, spec.DBVersion)
+					if err != nil {
+						exitWithError("failed to create parameter group", err)
+					}
+					if output != "json" {
+						fmt.Printf("Created parameter group %s: %s\n", spec.Name, id)
+					}
+					identifier = id
+				}
+			}
+
+			group := resolveRDSParameterGroup(ctx, e, identifier)
+			changes, err := planRDSParameterChanges(group, spec.Parameters, force)
+			if err != nil {
+				exitWithError("invalid parameters", err)
+			}
+			applyRDSParameterChanges(ctx, e, group, changes, dryRun)
+		},
+	}
+	importCmd.Flags().StringP("file", "f", "", "YAML file written by export-db-parameter-group (required)")
+	importCmd.Flags().String("db-parameter-group-id", "", "Target parameter group ID or name (default: the name in the file)")
+	importCmd.Flags().Bool("force", false, "Skip type and allowed-value validation")
+	importCmd.Flags().Bool("dry-run", false, "Show the changes without applying them")
+
+	return []*cobra.Command{modify, diff, export, importCmd}
+}
@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"text/tabwriter"
 
 	"github.com/haung921209/nhn-cloud-cli/pkg/auth"
 	"github.com/haung921209/nhn-cloud-sdk-go/nhncloud/database/postgresql"
@@ -38,6 +37,7 @@ func newPostgreSQLClient() *postgresql.Client {
 	if err != nil {
 		exitWithError("failed to get PostgreSQL config", err)
 	}
+	cfg.Region = rdsRegion(cfg.Region)
 
 	client, err := postgresql.NewClient(cfg)
 	if err != nil {
@@ -89,201 +89,3 @@ func postgresqlPrintJSON(v interface{}) {
 	enc.SetIndent("", "  ")
 	enc.Encode(v)
 }
-
-func postgresqlPrintInstanceList(result *postgresql.ListInstancesResponse) {
-	if output == "json" {
-		postgresqlPrintJSON(result)
-		return
-	}
-
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintln(w, "DB_INSTANCE_ID\tNAME\tSTATUS\tTYPE\tVERSION")
-	for _, inst := range result.DBInstances {
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
-			inst.DBInstanceID,
-			inst.DBInstanceName,
-			inst.DBInstanceStatus,
-			inst.DBInstanceType,
-			inst.DBVersion,
-		)
-	}
-	w.Flush()
-}
-
-func postgresqlPrintInstanceDetail(result *postgresql.GetInstanceResponse) {
-	if output == "json" {
-		postgresqlPrintJSON(result)
-		return
-	}
-
-	inst := result.DatabaseInstance
-	fmt.Printf("DB Instance ID: %s\n", inst.DBInstanceID)
-	fmt.Printf("Name: %s\n", inst.DBInstanceName)
-	fmt.Printf("Status: %s\n", inst.DBInstanceStatus)
-	fmt.Printf("Type: %s\n", inst.DBInstanceType)
-	fmt.Printf("Version: %s\n", inst.DBVersion)
-	fmt.Printf("Port: %d\n", inst.DBPort)
-	fmt.Printf("Flavor: %s\n", inst.DBFlavorName)
-	fmt.Printf("Created: %s\n", inst.CreatedAt)
-	fmt.Printf("Updated: %s\n", inst.UpdatedAt)
-
-	if inst.Network.SubnetID != "" {
-		fmt.Printf("\nNetwork:\n")
-		fmt.Printf("  Subnet: %s\n", inst.Network.SubnetName)
-		fmt.Printf("  Availability Zone: %s\n", inst.Network.AvailabilityZone)
-		fmt.Printf("  Public Access: %v\n", inst.Network.UsePublicAccess)
-		if inst.Network.IPAddress != "" {
-			fmt.Printf("  IP Address: %s\n", inst.Network.IPAddress)
-		}
-	}
-
-	if inst.HighAvailability != nil && inst.HighAvailability.Use {
-		fmt.Printf("\nHigh Availability:\n")
-		fmt.Printf("  Enabled: %v\n", inst.HighAvailability.Use)
-		fmt.Printf("  Availability Zone: %s\n", inst.HighAvailability.AvailabilityZone)
-	}
-}
-
-// ============================================================================
-// Instance Commands
-// ============================================================================
-
-var describePostgreSQLInstancesCmd = &cobra.Command{
-	Use:   "describe-db-instances",
-	Short: "Describe PostgreSQL DB instances",
-	Run: func(cmd *cobra.Command, args []string) {
-		client := newPostgreSQLClient()
-		identifier, _ := cmd.Flags().GetString("db-instance-identifier")
-
-		if identifier != "" {
-			instanceID, err := resolvePostgreSQLInstanceIdentifier(client, identifier)
-			if err != nil {
-				exitWithError("failed to resolve instance identifier", err)
-			}
-
-			result, err := client.GetInstance(context.Background(), instanceID)
-			if err != nil {
-				exitWithError("failed to get instance", err)
-			}
-			postgresqlPrintInstanceDetail(result)
-		} else {
-			result, err := client.ListInstances(context.Background())
-			if err != nil {
-				exitWithError("failed to list instances", err)
-			}
-			postgresqlPrintInstanceList(result)
-		}
-	},
-}
-
-var deletePostgreSQLInstanceCmd = &cobra.Command{
-	Use:   "delete-db-instance",
-	Short: "Delete a PostgreSQL DB instance",
-	Run: func(cmd *cobra.Command, args []string) {
-		client := newPostgreSQLClient()
-		instanceID, err := getResolvedPostgreSQLInstanceID(cmd, client)
-		if err != nil {
-			exitWithError("failed to resolve instance ID", err)
-		}
-
-		result, err := client.DeleteInstance(context.Background(), instanceID)
-		if err != nil {
-			exitWithError("failed to delete instance", err)
-		}
-
-		fmt.Printf("Instance deletion initiated.\n")
-		fmt.Printf("Job ID: %s\n", result.JobID)
-	},
-}
-
-var startPostgreSQLInstanceCmd = &cobra.Command{
-	Use:   "start-db-instance",
-	Short: "Start a stopped PostgreSQL DB instance",
-	Run: func(cmd *cobra.Command, args []string) {
-		client := newPostgreSQLClient()
-		instanceID, err := getResolvedPostgreSQLInstanceID(cmd, client)
-		if err != nil {
-			exitWithError("failed to resolve instance ID", err)
-		}
-
-		result, err := client.StartInstance(context.Background(), instanceID)
-		if err != nil {
-			exitWithError("failed to start instance", err)
-		}
-
-		fmt.Printf("Instance start initiated.\n")
-		fmt.Printf("Job ID: %s\n", result.JobID)
-	},
-}
-
-var stopPostgreSQLInstanceCmd = &cobra.Command{
-	Use:   "stop-db-instance",
-	Short: "Stop a running PostgreSQL DB instance",
-	Run: func(cmd *cobra.Command, args []string) {
-		client := newPostgreSQLClient()
-		instanceID, err := getResolvedPostgreSQLInstanceID(cmd, client)
-		if err != nil {
-			exitWithError("failed to resolve instance ID", err)
-		}
-
-		result, err := client.StopInstance(context.Background(), instanceID)
-		if err != nil {
-			exitWithError("failed to stop instance", err)
-		}
-
-		fmt.Printf("Instance stop initiated.\n")
-		fmt.Printf("Job ID: %s\n", result.JobID)
-	},
-}
-
-var rebootPostgreSQLInstanceCmd = &cobra.Command{
-	Use:   "reboot-db-instance",
-	Short: "Reboot a PostgreSQL DB instance",
-	Run: func(cmd *cobra.Command, args []string) {
-		client := newPostgreSQLClient()
-		instanceID, err := getResolvedPostgreSQLInstanceID(cmd, client)
-		if err != nil {
-			exitWithError("failed to resolve instance ID", err)
-		}
-
-		useOnlineFailover, _ := cmd.Flags().GetBool("use-online-failover")
-		executeBackup, _ := cmd.Flags().GetBool("execute-backup")
-
-		req := &postgresql.RestartInstanceRequest{}
-		if cmd.Flags().Changed("use-online-failover") {
-			req.UseOnlineFailover = &useOnlineFailover
-		}
-		if cmd.Flags().Changed("execute-backup") {
-			req.ExecuteBackup = &executeBackup
-		}
-
-		result, err := client.RestartInstance(context.Background(), instanceID, req)
-		if err != nil {
-			exitWithError("failed to reboot instance", err)
-		}
-
-		fmt.Printf("Instance reboot initiated.\n")
-		fmt.Printf("Job ID: %s\n", result.JobID)
-	},
-}
-
-func init() {
-	// Instance commands
-	rdsPostgreSQLCmd.AddCommand(describePostgreSQLInstancesCmd)
-	rdsPostgreSQLCmd.AddCommand(deletePostgreSQLInstanceCmd)
-	rdsPostgreSQLCmd.AddCommand(startPostgreSQLInstanceCmd)
-	rdsPostgreSQLCmd.AddCommand(stopPostgreSQLInstanceCmd)
-	rdsPostgreSQLCmd.AddCommand(rebootPostgreSQLInstanceCmd)
-
-	describePostgreSQLInstancesCmd.Flags().String("db-instance-identifier", "", "DB instance identifier")
-
-	deletePostgreSQLInstanceCmd.Flags().String("db-instance-identifier", "", "DB instance identifier (required)")
-
-	startPostgreSQLInstanceCmd.Flags().String("db-instance-identifier", "", "DB instance identifier (required)")
-
-	stopPostgreSQLInstanceCmd.Flags().String("db-instance-identifier", "", "DB instance identifier (required)")
-
-	rebootPostgreSQLInstanceCmd.Flags().String("db-instance-identifier", "", "DB instance identifier (required)")
-	rebootPostgreSQLInstanceCmd.Flags().Bool("use-online-failover", false, "Use online failover during reboot (HA only)")
-	rebootPostgreSQLInstanceCmd.Flags().Bool("execute-backup", false, "Execute backup before reboot")
-}
@@ -2,16 +2,14 @@ package cmd
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
 	"os"
 	"os/exec"
 	"strconv"
 
 	"github.com/haung921209/nhn-cloud-cli/internal/cert"
+	"github.com/haung921209/nhn-cloud-cli/internal/rdsengine"
 	"github.com/spf13/cobra"
-
-	_ "github.com/lib/pq"
 )
 
 func init() {
@@ -26,6 +24,9 @@ func init() {
 
 	// Native execution flag
 	connectPostgreSQLCmd.Flags().StringP("execute", "e", "", "Execute query and exit (Uses built-in driver, no external dependency)")
+	connectPostgreSQLCmd.Flags().Bool("native", false, "Use the built-in shell even if psql is installed")
+
+	addBastionFlags(connectPostgreSQLCmd)
 
 	connectPostgreSQLCmd.MarkFlagRequired("db-instance-identifier")
 	connectPostgreSQLCmd.MarkFlagRequired("username")
@@ -37,12 +38,20 @@ var connectPostgreSQLCmd = &cobra.Command{
 	Short: "Connect to a PostgreSQL DB instance",
 	Long: `Connects to a PostgreSQL DB instance.
 Modes:
-1. Interactive: Launches 'psql' client (Requires local installation).
-2. Execute: Runs a query using the built-in Go driver (No external dependency).
+1. Interactive: Launches the 'psql' client, or the built-in shell when it
+   is not installed (or with --native). Type \? in the built-in shell for
+   its commands (\l, \dt, \d, \du, \x, \copy, ...).
+2. Execute: Runs statements using the built-in Go driver (No external dependency).
+
+The built-in driver verifies the server with the CA from the cert store.
+
+Instances without Public Access can be reached with --via-bastion, which
+opens an SSH tunnel through the given compute instance.
 
 Example:
   Interactive: nhncloud rds-postgresql connect --db-instance-identifier mydb -u user -p pass
-  Execute:     nhncloud rds-postgresql connect --db-instance-identifier mydb -u user -p pass -e "SELECT version();"`,
+  Execute:     nhncloud rds-postgresql connect --db-instance-identifier mydb -u user -p pass -e "SELECT version();"
+  Private:     nhncloud rds-postgresql connect --db-instance-identifier mydb -u user -p pass --via-bastion bastion`,
 	Run: func(cmd *cobra.Command, args []string) {
 		client := newPostgreSQLClient()
 
@@ -95,71 +104,85 @@ Example:
 			}
 		}
 
-		if host == "" {
-			exitWithError(fmt.Sprintf("Unable to determine public host for instance '%s'. Ensure Public Access is enabled.", inst.DBInstanceName), nil)
-		}
-
-		fmt.Printf("Connecting to %s (%s:%d)...\n", inst.DBInstanceName, host, inst.DBPort)
-
-		// Initialize Helper
-		helper, err := cert.NewHelper()
-		if err != nil {
-			exitWithError("failed to initialize certificate helper", err)
+		viaBastion, _ := cmd.Flags().GetString("via-bastion")
+		if host == "" && viaBastion == "" {
+			exitWithError(fmt.Sprintf("Unable to determine public host for instance '%s'. Ensure Public Access is enabled (or use --via-bastion).", inst.DBInstanceName), nil)
 		}
 
-		// Check if we need Native Connection (either -e flag OR psql missing)
-		useNative := false
-		_, err = exec.LookPath("psql")
-		if query != "" {
-			useNative = true
-		} else if err != nil {
-			// psql not found, fallback to native REPL
-			useNative = true
-			fmt.Println("Notice: 'psql' client not found in PATH. Falling back to built-in native shell.")
-		}
+		// Private instances are reached through an SSH tunnel on a bastion
+		port := inst.DBPort
+		serverName := host
+		if viaBastion != "" {
+			var endpoints []rdsEndpoint
+			if netInfo, err := client.GetNetworkInfo(ctx, dbInstanceID); err == nil && netInfo != nil {
+				for _, ep := range netInfo.EndPoints {
+					endpoints = append(endpoints, rdsEndpoint{Type: ep.EndPointType, Domain: ep.Domain, IPAddress: ep.IPAddress})
+				}
+			}
+			privateHost := privateRDSHost(endpoints, inst.Network.DomainName, inst.Network.IPAddress)
+			if privateHost == "" {
+				exitWithError(fmt.Sprintf("Unable to determine private host for instance '%s'", inst.DBInstanceName), nil)
+			}
 
-		if useNative {
-			// Get CA Path for SSL
-			caPathReal, err := helper.GetCertificateForDatabase("rds-postgresql", region, dbInstanceID, inst.DBVersion, true, caPath, "CA")
+			tunnel, err := openBastionTunnelFromFlags(cmd, privateHost, inst.DBPort)
 			if err != nil {
-				fmt.Printf("Warning: Could not locate CA cert: %v. Connection may fail if SSL required.\n", err)
+				exitWithError("failed to open bastion tunnel", err)
 			}
+			defer tunnel.Close()
 
-			// Construct Connection String (lib/pq format)
-			connStr := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=disable",
-				host, inst.DBPort, username, password, database)
+			host = tunnel.LocalHost()
+			port = tunnel.LocalPort()
+			serverName = privateHost
+		}
 
-			if caPathReal != "" {
-				// We removed sslrootcert because we use sslmode=disable, but if we revert to prefer/require:
-				// connStr += fmt.Sprintf(" sslrootcert=%s", caPathReal)
-			}
+		fmt.Printf("Connecting to %s (%s:%d)...\n", inst.DBInstanceName, host, port)
+
+		target := rdsSQLTarget{
+			Host:       host,
+			Port:       port,
+			ServerName: serverName,
+			Username:   username,
+			Password:   password,
+			Database:   database,
+			Region:     region,
+			InstanceID: dbInstanceID,
+			Version:    inst.DBVersion,
+			CAPath:     caPath,
+		}
 
-			db, err := sql.Open("postgres", connStr)
-			if err != nil {
-				exitWithError("failed to open database connection", err)
+		// Use the built-in driver for -e, --native, or when psql is missing
+		native, _ := cmd.Flags().GetBool("native")
+		_, lookErr := exec.LookPath("psql")
+		if query != "" || native || (lookErr != nil && len(args) == 0) {
+			if query == "" && !native {
+				fmt.Println("Notice: 'psql' client not found in PATH. Falling back to built-in native shell.")
 			}
-			defer db.Close()
-
-			err = db.Ping()
+			db, err := openRDSDatabase(ctx, rdsengine.PostgreSQL, target)
 			if err != nil {
-				exitWithError("failed to connect/ping database", err)
+				exitWithError("failed to connect to database", err)
 			}
+			defer db.Close()
 
 			if query != "" {
-				// Single shot
-				executeNativeQuery(db, query)
-			} else {
-				// REPL
-				runNativeREPL(db, database, database)
+				if err := runRDSStatements(db, rdsengine.PostgreSQL, database, query); err != nil {
+					os.Exit(1)
+				}
+				return
 			}
+			runRDSShell(db, rdsengine.PostgreSQL, database)
 			return
 		}
 
+		helper, err := cert.NewHelper()
+		if err != nil {
+			exitWithError("failed to initialize certificate helper", err)
+		}
+
 		// Interactive Mode (using psql)
 		cmdArgs, err := helper.GetConnectionCommand(
 			"rds-postgresql",
 			host,
-			strconv.Itoa(inst.DBPort),
+			strconv.Itoa(port),
 			database,
 			username,
 			password,
@@ -173,6 +196,10 @@ Example:
 			exitWithError("failed to generate connection command", err)
 		}
 
+		if viaBastion != "" {
+			cmdArgs = tunnelSafeConnectionArgs(cmdArgs)
+		}
+
 		// Append extra args
 		if len(args) > 0 {
 			cmdArgs = append(cmdArgs, args...)
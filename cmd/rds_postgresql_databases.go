@@ -103,29 +103,6 @@ var deletePostgreSQLDatabaseCmd = &cobra.Command{
 // User Commands
 // ============================================================================
 
-var describePostgreSQLUsersCmd = &cobra.Command{
-	Use:   "describe-db-users",
-	Short: "Describe PostgreSQL DB users",
-	Run: func(cmd *cobra.Command, args []string) {
-		client := newPostgreSQLClient()
-		instanceID, err := getResolvedPostgreSQLInstanceID(cmd, client)
-		if err != nil {
-			exitWithError("failed to resolve instance ID", err)
-		}
-
-		result, err := client.ListDBUsers(context.Background(), instanceID)
-		if err != nil {
-			exitWithError("failed to list DB users", err)
-		}
-
-		if output == "json" {
-			postgresqlPrintJSON(result)
-		} else {
-			postgresqlPrintUserList(result)
-		}
-	},
-}
-
 var createPostgreSQLUserCmd = &cobra.Command{
 	Use:   "create-db-user",
 	Short: "Create a PostgreSQL database user",
@@ -204,20 +181,6 @@ func postgresqlPrintDatabaseList(result *postgresql.ListDatabasesResponse) {
 	w.Flush()
 }
 
-func postgresqlPrintUserList(result *postgresql.ListDBUsersResponse) {
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintln(w, "USER_ID\tUSERNAME\tSUPERUSER\tCREATEDB")
-	for _, user := range result.DBUsers {
-		fmt.Fprintf(w, "%s\t%s\t%v\t%v\n",
-			user.DBUserID,
-			user.DBUserName,
-			user.IsSuperuser,
-			user.CanCreateDB,
-		)
-	}
-	w.Flush()
-}
-
 func init() {
 	// Database commands
 	rdsPostgreSQLCmd.AddCommand(describePostgreSQLDatabasesCmd)
@@ -235,12 +198,9 @@ func init() {
 	deletePostgreSQLDatabaseCmd.Flags().String("database-id", "", "Database ID (required)")
 
 	// User commands
-	rdsPostgreSQLCmd.AddCommand(describePostgreSQLUsersCmd)
 	rdsPostgreSQLCmd.AddCommand(createPostgreSQLUserCmd)
 	rdsPostgreSQLCmd.AddCommand(deletePostgreSQLUserCmd)
 
-	describePostgreSQLUsersCmd.Flags().String("db-instance-identifier", "", "DB instance identifier (required)")
-
 	createPostgreSQLUserCmd.Flags().String("db-instance-identifier", "", "DB instance identifier (required)")
 	createPostgreSQLUserCmd.Flags().String("db-user-name", "", "Database username (required)")
 	createPostgreSQLUserCmd.Flags().String("db-password", "", "Database password (required)")
@@ -0,0 +1,356 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/haung921209/nhn-cloud-cli/internal/rdsengine"
+	"github.com/haung921209/nhn-cloud-sdk-go/nhncloud/core"
+	"github.com/haung921209/nhn-cloud-sdk-go/nhncloud/database/postgresql"
+	"github.com/spf13/cobra"
+)
+
+// ============================================================================
+// Extension Commands (PostgreSQL-specific)
+// ============================================================================
+
+// Extensions are managed per instance group (the master and its replicas).
+// The SDK's Extension type lists installed databases by name only, without
+// the per-database IDs DeleteExtension needs, so extensions are listed
+// through the raw API.
+
+// pgExtension is an extension of an instance group and the databases it is
+// installed in
+type pgExtension struct {
+	ID        string                `json:"extensionId"`
+	Name      string                `json:"extensionName"`
+	Status    string                `json:"extensionStatus"`
+	Databases []pgExtensionDatabase `json:"databases"`
+}
+
+// pgExtensionDatabase is an extension installed in one database
+type pgExtensionDatabase struct {
+	ID           string `json:"dbInstanceGroupExtensionId,omitempty"`
+	DatabaseID   string `json:"databaseId,omitempty"`
+	DatabaseName string `json:"databaseName"`
+	SchemaName   string `json:"schemaName,omitempty"`
+	Status       string `json:"extensionStatus,omitempty"`
+}
+
+// UnmarshalJSON also accepts a bare database name
+func (d *pgExtensionDatabase) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*d = pgExtensionDatabase{DatabaseName: name}
+		return nil
+	}
+	type plain pgExtensionDatabase
+	return json.Unmarshal(data, (*plain)(d))
+}
+
+type pgExtensionList struct {
+	Header        core.ResponseHeader `json:"header"`
+	Extensions    []pgExtension       `json:"extensions"`
+	IsNeedToApply bool                `json:"isNeedToApply"`
+}
+
+// GetHeader implements core.WithHeader
+func (r *pgExtensionList) GetHeader() *core.ResponseHeader {
+	return &r.Header
+}
+
+type pgInstanceGroupResponse struct {
+	Header            core.ResponseHeader `json:"header"`
+	DBInstanceGroupID string              `json:"dbInstanceGroupId"`
+}
+
+// GetHeader implements core.WithHeader
+func (r *pgInstanceGroupResponse) GetHeader() *core.ResponseHeader {
+	return &r.Header
+}
+
+// resolvePostgreSQLInstanceGroup returns the instance ID and its instance
+// group ID
+func resolvePostgreSQLInstanceGroup(ctx context.Context, cmd *cobra.Command, client *postgresql.Client) (string, string) {
+	instanceID, err := getResolvedPostgreSQLInstanceID(cmd, client)
+	if err != nil {
+		exitWithError("failed to resolve instance ID", err)
+	}
+	var result pgInstanceGroupResponse
+	if err := newRDSAPI(rdsengine.PostgreSQL).Do(ctx, "GET", "/db-instances/"+instanceID, nil, &result); err != nil {
+		exitWithError("failed to get instance", err)
+	}
+	if result.DBInstanceGroupID == "" {
+		exitWithError(fmt.Sprintf("instance %s has no instance group", instanceID), nil)
+	}
+	return instanceID, result.DBInstanceGroupID
+}
+
+func listPostgreSQLExtensions(ctx context.Context, groupID string) *pgExtensionList {
+	var result pgExtensionList
+	if err := newRDSAPI(rdsengine.PostgreSQL).Do(ctx, "GET", "/db-instance-groups/"+groupID+"/extensions", nil, &result); err != nil {
+		exitWithError("failed to list extensions", err)
+	}
+	return &result
+}
+
+// findPostgreSQLExtension looks an extension up by name
+func findPostgreSQLExtension(list *pgExtensionList, name string) *pgExtension {
+	for i := range list.Extensions {
+		if strings.EqualFold(list.Extensions[i].Name, name) {
+			return &list.Extensions[i]
+		}
+	}
+	exitWithError(fmt.Sprintf("extension not found: %s", name), nil)
+	return nil
+}
+
+var describeExtensionsCmd = &cobra.Command{
+	Use:   "describe-extensions",
+	Short: "Describe available and installed extensions",
+	Long: `Lists the extensions available to the instance group and the databases
+each one is installed in. Installed and deleted extensions take effect after
+apply-extensions.
+
+Examples:
+  nhncloud rds-postgresql describe-extensions --db-instance-identifier my-pg
+  nhncloud rds-postgresql describe-extensions --db-instance-identifier my-pg --database app --installed`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client := newPostgreSQLClient()
+		ctx := context.Background()
+		_, groupID := resolvePostgreSQLInstanceGroup(ctx, cmd, client)
+		database, _ := cmd.Flags().GetString("database")
+		installedOnly, _ := cmd.Flags().GetBool("installed")
+
+		list := listPostgreSQLExtensions(ctx, groupID)
+		if database != "" || installedOnly {
+			var filtered []pgExtension
+			for _, ext := range list.Extensions {
+				var dbs []pgExtensionDatabase
+				for _, db := range ext.Databases {
+					if database == "" || db.DatabaseName == database {
+						dbs = append(dbs, db)
+					}
+				}
+				if len(dbs) == 0 && installedOnly {
+					continue
+				}
+				ext.Databases = dbs
+				filtered = append(filtered, ext)
+			}
+			list.Extensions = filtered
+		}
+
+		if output == "json" {
+			postgresqlPrintJSON(list)
+			return
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "EXTENSION\tSTATUS\tDATABASE\tSCHEMA\tDB_STATUS")
+		for _, ext := range list.Extensions {
+			if len(ext.Databases) == 0 {
+				fmt.Fprintf(w, "%s\t%s\t-\t-\t-\n", ext.Name, ext.Status)
+				continue
+			}
+			for _, db := range ext.Databases {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", ext.Name, ext.Status, db.DatabaseName,
+					firstNonEmpty(db.SchemaName, "-"), firstNonEmpty(db.Status, "-"))
+			}
+		}
+		w.Flush()
+		if list.IsNeedToApply {
+			fmt.Println("\nThere are pending changes; run apply-extensions to apply them.")
+		}
+	},
+}
+
+var installExtensionCmd = &cobra.Command{
+	Use:   "install-extension",
+	Short: "Install an extension in a database",
+	Long: `Installs an extension in a database. The change is pending until
+apply-extensions.
+
+Examples:
+  nhncloud rds-postgresql install-extension --db-instance-identifier my-pg --database app --name pg_stat_statements
+  nhncloud rds-postgresql install-extension --db-instance-identifier my-pg --database app --name postgis --schema gis --cascade`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client := newPostgreSQLClient()
+		ctx := context.Background()
+		database, _ := cmd.Flags().GetString("database")
+		name, _ := cmd.Flags().GetString("name")
+		schema, _ := cmd.Flags().GetString("schema")
+		cascade, _ := cmd.Flags().GetBool("cascade")
+		if database == "" || name == "" {
+			exitWithError("--database and --name are required", nil)
+		}
+		instanceID, groupID := resolvePostgreSQLInstanceGroup(ctx, cmd, client)
+
+		databases, err := client.ListDatabases(ctx, instanceID)
+		if err != nil {
+			exitWithError("failed to list databases", err)
+		}
+		databaseID := ""
+		for _, d := range databases.Databases {
+			if d.DatabaseName == database || d.DatabaseID == database {
+				databaseID = d.DatabaseID
+				break
+			}
+		}
+		if databaseID == "" {
+			exitWithError(fmt.Sprintf("database not found: %s", database), nil)
+		}
+
+		ext := findPostgreSQLExtension(listPostgreSQLExtensions(ctx, groupID), name)
+		for _, db := range ext.Databases {
+			if db.DatabaseName == database {
+				fmt.Printf("Extension %s is already installed in %s.\n", ext.Name, database)
+				return
+			}
+		}
+
+		req := &postgresql.InstallExtensionRequest{DatabaseID: databaseID, SchemaName: schema}
+		if cascade {
+			req.WithCascade = &cascade
+		}
+		result, err := client.InstallExtension(ctx, groupID, ext.ID, req)
+		if err != nil {
+			exitWithError("failed to install extension", err)
+		}
+		if output == "json" {
+			postgresqlPrintJSON(result)
+			return
+		}
+		fmt.Printf("Extension %s installed in %s (pending apply-extensions).\n", ext.Name, database)
+		if result.JobID != "" {
+			fmt.Printf("Job ID: %s\n", result.JobID)
+		}
+	},
+}
+
+var deleteExtensionCmd = &cobra.Command{
+	Use:   "delete-extension",
+	Short: "Delete an extension from a database",
+	Long: `Deletes an extension from a database. The change is pending until
+apply-extensions.
+
+Examples:
+  nhncloud rds-postgresql delete-extension --db-instance-identifier my-pg --database app --name pg_stat_statements`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client := newPostgreSQLClient()
+		ctx := context.Background()
+		database, _ := cmd.Flags().GetString("database")
+		name, _ := cmd.Flags().GetString("name")
+		cascade, _ := cmd.Flags().GetBool("cascade")
+		if database == "" || name == "" {
+			exitWithError("--database and --name are required", nil)
+		}
+		_, groupID := resolvePostgreSQLInstanceGroup(ctx, cmd, client)
+
+		ext := findPostgreSQLExtension(listPostgreSQLExtensions(ctx, groupID), name)
+		installID := ""
+		for _, db := range ext.Databases {
+			if db.DatabaseName == database {
+				installID = db.ID
+				break
+			}
+		}
+		if installID == "" {
+			exitWithError(fmt.Sprintf("extension %s is not installed in %s", ext.Name, database), nil)
+		}
+
+		result, err := client.DeleteExtension(ctx, groupID, installID, cascade)
+		if err != nil {
+			exitWithError("failed to delete extension", err)
+		}
+		if output == "json" {
+			postgresqlPrintJSON(result)
+			return
+		}
+		fmt.Printf("Extension %s deleted from %s (pending apply-extensions).\n", ext.Name, database)
+		if result.JobID != "" {
+			fmt.Printf("Job ID: %s\n", result.JobID)
+		}
+	},
+}
+
+var applyExtensionsCmd = &cobra.Command{
+	Use:   "apply-extensions",
+	Short: "Apply pending extension changes",
+	Long: `Applies pending extension installs and deletions to the instance group.
+With --sync the extension state is first synchronized with the databases.
+
+Examples:
+  nhncloud rds-postgresql apply-extensions --db-instance-identifier my-pg --wait`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client := newPostgreSQLClient()
+		wait, _ := cmd.Flags().GetBool("wait")
+		sync, _ := cmd.Flags().GetBool("sync")
+		timeout := getDurationFlag(cmd, "timeout")
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		instanceID, groupID := resolvePostgreSQLInstanceGroup(ctx, cmd, client)
+		e := newRDSEngine(rdsengine.PostgreSQL)
+
+		if sync {
+			result, err := client.SyncExtensions(ctx, groupID)
+			if err != nil {
+				exitWithError("failed to sync extensions", err)
+			}
+			if err := waitForRDSJob(ctx, e, instanceID, 5*time.Second, nil); err != nil {
+				exitWithError(fmt.Sprintf("extension sync %s did not finish", result.JobID), err)
+			}
+		}
+
+		result, err := client.ApplyExtensions(ctx, groupID)
+		if err != nil {
+			exitWithError("failed to apply extensions", err)
+		}
+		if output != "json" {
+			fmt.Printf("Extension changes application initiated.\n")
+			fmt.Printf("Job ID: %s\n", result.JobID)
+		}
+		if wait {
+			if err := waitForRDSJob(ctx, e, instanceID, 5*time.Second, os.Stderr); err != nil {
+				exitWithError("extension changes were not applied", err)
+			}
+			if output != "json" {
+				fmt.Println("Extension changes applied.")
+			}
+		}
+		if output == "json" {
+			postgresqlPrintJSON(result)
+		}
+	},
+}
+
+func init() {
+	rdsPostgreSQLCmd.AddCommand(describeExtensionsCmd)
+	rdsPostgreSQLCmd.AddCommand(installExtensionCmd)
+	rdsPostgreSQLCmd.AddCommand(deleteExtensionCmd)
+	rdsPostgreSQLCmd.AddCommand(applyExtensionsCmd)
+
+	describeExtensionsCmd.Flags().String("db-instance-identifier", "", "DB instance identifier (required)")
+	describeExtensionsCmd.Flags().String("database", "", "Only show this database")
+	describeExtensionsCmd.Flags().Bool("installed", false, "Only show installed extensions")
+
+	installExtensionCmd.Flags().String("db-instance-identifier", "", "DB instance identifier (required)")
+	installExtensionCmd.Flags().String("database", "", "Database name (required)")
+	installExtensionCmd.Flags().String("name", "", "Extension name (required)")
+	installExtensionCmd.Flags().String("schema", "public", "Schema to install the extension in")
+	installExtensionCmd.Flags().Bool("cascade", false, "Also install the extensions it depends on")
+
+	deleteExtensionCmd.Flags().String("db-instance-identifier", "", "DB instance identifier (required)")
+	deleteExtensionCmd.Flags().String("database", "", "Database name (required)")
+	deleteExtensionCmd.Flags().String("name", "", "Extension name (required)")
+	deleteExtensionCmd.Flags().Bool("cascade", false, "Also delete the objects that depend on it")
+
+	applyExtensionsCmd.Flags().String("db-instance-identifier", "", "DB instance identifier (required)")
+	applyExtensionsCmd.Flags().Bool("sync", false, "Synchronize the extension state with the databases first")
+	applyExtensionsCmd.Flags().Bool("wait", false, "Wait until the changes are applied")
+	applyExtensionsCmd.Flags().String("timeout", "30m", "Maximum time to wait (Go duration)")
+}
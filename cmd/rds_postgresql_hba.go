@@ -3,9 +3,14 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"net/netip"
 	"os"
+	"sort"
 	"text/tabwriter"
+	"time"
 
+	"github.com/haung921209/nhn-cloud-cli/internal/pghba"
+	"github.com/haung921209/nhn-cloud-cli/internal/rdsengine"
 	"github.com/haung921209/nhn-cloud-sdk-go/nhncloud/database/postgresql"
 	"github.com/spf13/cobra"
 )
@@ -122,24 +127,315 @@ var deleteHBARuleCmd = &cobra.Command{
 var applyHBARulesCmd = &cobra.Command{
 	Use:   "apply-hba-rules",
 	Short: "Apply HBA rules to PostgreSQL instance",
-	Long:  `Apply pending HBA rule changes to the running PostgreSQL instance.`,
+	Long: `Apply pending HBA rule changes to the running PostgreSQL instance.
+
+With -f the rules are first reconciled with a YAML file that lists them in
+match order: rules equal to one in the file are kept, the others are
+deleted, missing rules are created and the list is reordered to the file's
+order before the rules are applied. Databases and users are given by name;
+leaving them out matches all.
+
+  rules:
+    - connectionType: HOSTSSL    # HOST (default), HOSTSSL, HOSTNOSSL
+      databases: [app]
+      users: [app]
+      address: 10.0.0.0/16
+      authMethod: SCRAM_SHA_256  # SCRAM_SHA_256, MD5, TRUST
+    - address: 0.0.0.0/0
+      authMethod: SCRAM_SHA_256
+
+Examples:
+  nhncloud rds-postgresql apply-hba-rules --db-instance-identifier my-pg
+  nhncloud rds-postgresql apply-hba-rules --db-instance-identifier my-pg -f hba.yaml --dry-run
+  nhncloud rds-postgresql apply-hba-rules --db-instance-identifier my-pg -f hba.yaml --wait`,
 	Run: func(cmd *cobra.Command, args []string) {
 		client := newPostgreSQLClient()
 		instanceID, err := getResolvedPostgreSQLInstanceID(cmd, client)
 		if err != nil {
 			exitWithError("failed to resolve instance ID", err)
 		}
+		file, _ := cmd.Flags().GetString("file")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		wait, _ := cmd.Flags().GetBool("wait")
+		timeout := getDurationFlag(cmd, "timeout")
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		if file != "" {
+			spec, err := pghba.Load(file)
+			if err != nil {
+				exitWithError("invalid HBA rule file", err)
+			}
+			changed := reconcileHBARules(ctx, client, instanceID, spec.Rules, dryRun)
+			if dryRun {
+				return
+			}
+			if !changed {
+				fmt.Println("HBA rules are up to date.")
+			}
+		} else if dryRun {
+			exitWithError("--dry-run requires -f", nil)
+		}
 
-		result, err := client.ApplyHBARules(context.Background(), instanceID)
+		result, err := client.ApplyHBARules(ctx, instanceID)
 		if err != nil {
 			exitWithError("failed to apply HBA rules", err)
 		}
 
 		fmt.Printf("HBA rules application initiated.\n")
 		fmt.Printf("Job ID: %s\n", result.JobID)
+		if wait {
+			if err := waitForRDSJob(ctx, newRDSEngine(rdsengine.PostgreSQL), instanceID, 5*time.Second, os.Stderr); err != nil {
+				exitWithError("HBA rules were not applied", err)
+			}
+			fmt.Println("HBA rules applied.")
+		}
+	},
+}
+
+var testHBACmd = &cobra.Command{
+	Use:   "test-hba",
+	Short: "Show which HBA rule a connection would match",
+	Long: `Evaluates the instance's HBA rules (or the rules of a YAML file given with
+-f) locally, top to bottom like PostgreSQL, and reports the first rule that
+matches the connection. A connection that matches no rule is rejected.
+
+Examples:
+  nhncloud rds-postgresql test-hba --db-instance-identifier my-pg --user app --database app --address 10.0.3.4 --ssl
+  nhncloud rds-postgresql test-hba -f hba.yaml --user app --database app --address 192.168.1.10`,
+	Run: func(cmd *cobra.Command, args []string) {
+		file, _ := cmd.Flags().GetString("file")
+		user, _ := cmd.Flags().GetString("user")
+		database, _ := cmd.Flags().GetString("database")
+		address, _ := cmd.Flags().GetString("address")
+		ssl, _ := cmd.Flags().GetBool("ssl")
+		if user == "" || database == "" || address == "" {
+			exitWithError("--user, --database and --address are required", nil)
+		}
+		addr, err := netip.ParseAddr(address)
+		if err != nil {
+			exitWithError(fmt.Sprintf("invalid --address %s", address), err)
+		}
+
+		var rules []pghba.Rule
+		if file != "" {
+			spec, err := pghba.Load(file)
+			if err != nil {
+				exitWithError("invalid HBA rule file", err)
+			}
+			rules = spec.Rules
+		} else {
+			client := newPostgreSQLClient()
+			instanceID, err := getResolvedPostgreSQLInstanceID(cmd, client)
+			if err != nil {
+				exitWithError("failed to resolve instance ID", err)
+			}
+			rules, _ = currentHBARules(context.Background(), client, instanceID)
+		}
+
+		conn := pghba.Connection{User: user, Database: database, Address: addr, SSL: ssl}
+		i := pghba.FirstMatch(rules, conn)
+		if output == "json" {
+			result := map[string]interface{}{"matched": i >= 0}
+			if i >= 0 {
+				result["order"] = i + 1
+				result["rule"] = rules[i]
+			}
+			postgresqlPrintJSON(result)
+			return
+		}
+		if i < 0 {
+			fmt.Printf("No rule matches; the connection is rejected (%d rule(s) checked).\n", len(rules))
+			return
+		}
+		fmt.Printf("Matched rule #%d: %s\n", i+1, rules[i])
+		if rules[i].ID != "" {
+			fmt.Printf("Rule ID: %s\n", rules[i].ID)
+		}
+		if rules[i].AuthMethod == "TRUST" {
+			fmt.Println("Authentication: none (TRUST)")
+		}
 	},
 }
 
+// hbaNames maps database and user IDs to names and back
+type hbaNames struct {
+	databases, users map[string]string
+}
+
+func (n hbaNames) lookup(m map[string]string, keys []string) []string {
+	out := make([]string, len(keys))
+	for i, k := range keys {
+		out[i] = firstNonEmpty(m[k], k)
+	}
+	return out
+}
+
+// currentHBARules lists the instance's HBA rules in match order, with
+// databases and users by name
+func currentHBARules(ctx context.Context, client *postgresql.Client, instanceID string) ([]pghba.Rule, hbaNames) {
+	result, err := client.ListHBARules(ctx, instanceID)
+	if err != nil {
+		exitWithError("failed to list HBA rules", err)
+	}
+	databases, err := client.ListDatabases(ctx, instanceID)
+	if err != nil {
+		exitWithError("failed to list databases", err)
+	}
+	users, err := client.ListDBUsers(ctx, instanceID)
+	if err != nil {
+		exitWithError("failed to list DB users", err)
+	}
+	byID := hbaNames{databases: map[string]string{}, users: map[string]string{}}
+	byName := hbaNames{databases: map[string]string{}, users: map[string]string{}}
+	for _, d := range databases.Databases {
+		byID.databases[d.DatabaseID] = d.DatabaseName
+		byName.databases[d.DatabaseName] = d.DatabaseID
+	}
+	for _, u := range users.DBUsers {
+		byID.users[u.DBUserID] = u.DBUserName
+		byName.users[u.DBUserName] = u.DBUserID
+	}
+
+	ordered := append([]postgresql.HBARule(nil), result.HBARules...)
+	sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].Order < ordered[j].Order })
+	rules := make([]pghba.Rule, len(ordered))
+	for i, r := range ordered {
+		rules[i] = pghba.Rule{
+			ID:             r.HBARuleID,
+			ConnectionType: string(r.ConnectionType),
+			Address:        r.Address,
+			AuthMethod:     string(r.AuthMethod),
+		}
+		if r.DatabaseApplyType == postgresql.HBARuleApplyTypeSelected {
+			rules[i].Databases = byID.lookup(byID.databases, r.DatabaseIDs)
+		}
+		if r.DBUserApplyType == postgresql.HBARuleUserApplyTypeUserCustom {
+			rules[i].Users = byID.lookup(byID.users, r.DBUserIDs)
+		}
+		rules[i].Normalize()
+	}
+	return rules, byName
+}
+
+// reconcileHBARules deletes, creates and reorders rules so that the
+// instance has exactly want, in order. It prints the plan and reports
+// whether anything changed.
+func reconcileHBARules(ctx context.Context, client *postgresql.Client, instanceID string, want []pghba.Rule, dryRun bool) bool {
+	current, ids := currentHBARules(ctx, client, instanceID)
+
+	used := make([]bool, len(current))
+	matched := make([]int, len(want))
+	for i, w := range want {
+		matched[i] = -1
+		for j, c := range current {
+			if !used[j] && c.Equal(w) {
+				used[j], matched[i] = true, j
+				break
+			}
+		}
+		for _, name := range w.Databases {
+			if ids.databases[name] == "" && matched[i] < 0 {
+				exitWithError(fmt.Sprintf("rule #%d: database %s does not exist", i+1, name), nil)
+			}
+		}
+		for _, name := range w.Users {
+			if ids.users[name] == "" && matched[i] < 0 {
+				exitWithError(fmt.Sprintf("rule #%d: user %s does not exist", i+1, name), nil)
+			}
+		}
+	}
+
+	// The kept rules need a reorder when new rules must be placed or the
+	// kept ones are out of order
+	creates, deletes, reorder := 0, 0, false
+	last := -1
+	for _, j := range matched {
+		if j < 0 {
+			creates++
+			reorder = true
+			continue
+		}
+		if j < last {
+			reorder = true
+		}
+		last = j
+	}
+	for j := range current {
+		if !used[j] {
+			deletes++
+		}
+	}
+
+	if output != "json" {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ACTION\tORDER\tRULE")
+		for i, r := range want {
+			action := "keep"
+			if matched[i] < 0 {
+				action = "create"
+			}
+			fmt.Fprintf(w, "%s\t%d\t%s\n", action, i+1, r)
+		}
+		for j, r := range current {
+			if !used[j] {
+				fmt.Fprintf(w, "delete\t-\t%s\n", r)
+			}
+		}
+		w.Flush()
+		fmt.Printf("\n%d to create, %d to delete, reorder: %v\n", creates, deletes, reorder)
+	}
+	if dryRun || (creates == 0 && deletes == 0 && !reorder) {
+		return creates > 0 || deletes > 0 || reorder
+	}
+
+	for j, r := range current {
+		if used[j] {
+			continue
+		}
+		if _, err := client.DeleteHBARule(ctx, instanceID, r.ID); err != nil {
+			exitWithError(fmt.Sprintf("failed to delete HBA rule %s", r.ID), err)
+		}
+	}
+	order := make([]string, len(want))
+	for i, r := range want {
+		if matched[i] >= 0 {
+			order[i] = current[matched[i]].ID
+			continue
+		}
+		req := &postgresql.CreateHBARuleRequest{
+			ConnectionType:    r.ConnectionType,
+			DatabaseApplyType: string(postgresql.HBARuleApplyTypeEntire),
+			DBUserApplyType:   string(postgresql.HBARuleUserApplyTypeEntire),
+			Address:           r.Address,
+			AuthMethod:        r.AuthMethod,
+		}
+		if len(r.Databases) > 0 {
+			req.DatabaseApplyType = string(postgresql.HBARuleApplyTypeSelected)
+			for _, name := range r.Databases {
+				req.DatabaseIDs = append(req.DatabaseIDs, ids.databases[name])
+			}
+		}
+		if len(r.Users) > 0 {
+			req.DBUserApplyType = string(postgresql.HBARuleUserApplyTypeUserCustom)
+			for _, name := range r.Users {
+				req.DBUserIDs = append(req.DBUserIDs, ids.users[name])
+			}
+		}
+		result, err := client.CreateHBARule(ctx, instanceID, req)
+		if err != nil {
+			exitWithError(fmt.Sprintf("failed to create HBA rule #%d", i+1), err)
+		}
+		order[i] = result.HBARuleID
+	}
+	if reorder && len(order) > 0 {
+		if _, err := client.ReorderHBARules(ctx, instanceID, &postgresql.ReorderHBARulesRequest{HBARuleIDs: order}); err != nil {
+			exitWithError("failed to reorder HBA rules", err)
+		}
+	}
+	return true
+}
+
 // ============================================================================
 // Print Functions
 // ============================================================================
@@ -179,4 +475,16 @@ func init() {
 	deleteHBARuleCmd.Flags().String("hba-rule-id", "", "HBA rule ID (required)")
 
 	applyHBARulesCmd.Flags().String("db-instance-identifier", "", "DB instance identifier (required)")
+	applyHBARulesCmd.Flags().StringP("file", "f", "", "Reconcile the rules with this YAML file before applying")
+	applyHBARulesCmd.Flags().Bool("dry-run", false, "Show the changes for -f without making them")
+	applyHBARulesCmd.Flags().Bool("wait", false, "Wait until the rules are applied")
+	applyHBARulesCmd.Flags().String("timeout", "30m", "Maximum time to wait (Go duration)")
+
+	rdsPostgreSQLCmd.AddCommand(testHBACmd)
+	testHBACmd.Flags().String("db-instance-identifier", "", "DB instance identifier (required without -f)")
+	testHBACmd.Flags().StringP("file", "f", "", "Evaluate the rules of this YAML file instead of the instance's")
+	testHBACmd.Flags().String("user", "", "Connecting user (required)")
+	testHBACmd.Flags().String("database", "", "Database (required)")
+	testHBACmd.Flags().String("address", "", "Client IP address (required)")
+	testHBACmd.Flags().Bool("ssl", false, "The connection uses SSL")
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/haung921209/nhn-cloud-cli/internal/rdsengine"
 	"github.com/haung921209/nhn-cloud-sdk-go/nhncloud/database/postgresql"
 	"github.com/spf13/cobra"
 )
@@ -11,7 +12,18 @@ import (
 var createPostgreSQLInstanceCmd = &cobra.Command{
 	Use:   "create-db-instance",
 	Short: "Create a PostgreSQL DB instance",
+	Long: `Creates a PostgreSQL DB instance.
+
+With --from-spec the instance is created from a spec written by
+export-db-instance-spec; its parameter group and DB security groups are
+created first unless groups of the same name exist.
+
+Examples:
+  nhncloud rds-postgresql create-db-instance --from-spec spec.yaml --region jp1 --availability-zone jp-pub-a`,
 	Run: func(cmd *cobra.Command, args []string) {
+		if runRDSCreateFromSpec(cmd, rdsengine.PostgreSQL, "db-instance-name", "db-user-name", "db-password") {
+			return
+		}
 		client := newPostgreSQLClient()
 
 		port, _ := cmd.Flags().GetInt("port")
@@ -138,5 +150,6 @@ func init() {
 
 	createPostgreSQLInstanceCmd.Flags().Int("backup-retention-period", 0, "Backup retention period (days)")
 	createPostgreSQLInstanceCmd.Flags().String("backup-start-time", "01:00", "Backup window start time (HH:MM)")
+	addRDSFromSpecFlags(createPostgreSQLInstanceCmd)
 	createPostgreSQLInstanceCmd.Flags().String("backup-duration", "ONE_HOUR", "Backup window duration (ONE_HOUR, TWO_HOURS, etc.)")
 }
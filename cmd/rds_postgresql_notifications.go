@@ -97,33 +97,6 @@ var postgresqlDeleteNotificationGroupCmd = &cobra.Command{
 	},
 }
 
-// ============================================================================
-// Log Commands
-// ============================================================================
-
-var postgresqlDescribeLogsCmd = &cobra.Command{
-	Use:   "describe-logs",
-	Short: "Describe log files for an instance",
-	Run: func(cmd *cobra.Command, args []string) {
-		client := newPostgreSQLClient()
-		instanceID, err := getResolvedPostgreSQLInstanceID(cmd, client)
-		if err != nil {
-			exitWithError("failed to resolve instance ID", err)
-		}
-
-		result, err := client.ListLogFiles(context.Background(), instanceID)
-		if err != nil {
-			exitWithError("failed to list log files", err)
-		}
-
-		if output == "json" {
-			postgresqlPrintJSON(result)
-		} else {
-			postgresqlPrintLogFileList(result)
-		}
-	},
-}
-
 // ============================================================================
 // Print Functions
 // ============================================================================
@@ -154,19 +127,6 @@ func postgresqlPrintNotificationGroupDetail(result *postgresql.GetNotificationGr
 	}
 }
 
-func postgresqlPrintLogFileList(result *postgresql.ListLogFilesResponse) {
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintln(w, "FILE_NAME\tSIZE\tMODIFIED")
-	for _, log := range result.LogFiles {
-		fmt.Fprintf(w, "%s\t%d\t%s\n",
-			log.LogFileName,
-			log.LogFileSize,
-			log.ModifiedAt,
-		)
-	}
-	w.Flush()
-}
-
 func init() {
 	// Notification Group commands
 	rdsPostgreSQLCmd.AddCommand(postgresqlDescribeNotificationGroupsCmd)
@@ -181,8 +141,4 @@ func init() {
 	postgresqlCreateNotificationGroupCmd.Flags().StringSlice("notify-sms", nil, "Phone numbers for SMS notifications")
 
 	postgresqlDeleteNotificationGroupCmd.Flags().String("notification-group-id", "", "Notification group ID (required)")
-
-	// Log commands
-	rdsPostgreSQLCmd.AddCommand(postgresqlDescribeLogsCmd)
-	postgresqlDescribeLogsCmd.Flags().String("db-instance-identifier", "", "DB instance identifier (required)")
 }
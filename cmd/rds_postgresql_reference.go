@@ -1,109 +0,0 @@
-package cmd
-
-import (
-	"context"
-	"fmt"
-	"os"
-	"text/tabwriter"
-
-	"github.com/haung921209/nhn-cloud-sdk-go/nhncloud/database/postgresql"
-	"github.com/spf13/cobra"
-)
-
-var describePostgreSQLFlavorsCmd = &cobra.Command{
-	Use:   "describe-db-flavors",
-	Short: "Describe PostgreSQL DB flavors",
-	Run: func(cmd *cobra.Command, args []string) {
-		client := newPostgreSQLClient()
-
-		result, err := client.ListFlavors(context.Background())
-		if err != nil {
-			exitWithError("failed to list flavors", err)
-		}
-
-		if output == "json" {
-			postgresqlPrintJSON(result)
-		} else {
-			postgresqlPrintFlavorList(result)
-		}
-	},
-}
-
-var describePostgreSQLEngineVersionsCmd = &cobra.Command{
-	Use:   "describe-db-engine-versions",
-	Short: "Describe PostgreSQL DB engine versions",
-	Run: func(cmd *cobra.Command, args []string) {
-		client := newPostgreSQLClient()
-
-		result, err := client.ListVersions(context.Background())
-		if err != nil {
-			exitWithError("failed to list versions", err)
-		}
-
-		if output == "json" {
-			postgresqlPrintJSON(result)
-		} else {
-			postgresqlPrintVersionList(result)
-		}
-	},
-}
-
-var describePostgreSQLStorageTypesCmd = &cobra.Command{
-	Use:   "describe-db-storage-types",
-	Short: "Describe PostgreSQL storage types",
-	Run: func(cmd *cobra.Command, args []string) {
-		client := newPostgreSQLClient()
-
-		result, err := client.ListStorageTypes(context.Background())
-		if err != nil {
-			exitWithError("failed to list storage types", err)
-		}
-
-		if output == "json" {
-			postgresqlPrintJSON(result)
-		} else {
-			postgresqlPrintStorageTypeList(result)
-		}
-	},
-}
-
-func postgresqlPrintFlavorList(result *postgresql.ListFlavorsResponse) {
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintln(w, "FLAVOR_ID\tNAME\tVCPUS\tRAM(MB)")
-	for _, f := range result.DBFlavors {
-		fmt.Fprintf(w, "%s\t%s\t%d\t%d\n",
-			f.DBFlavorID,
-			f.DBFlavorName,
-			f.Vcpus,
-			f.Ram,
-		)
-	}
-	w.Flush()
-}
-
-func postgresqlPrintVersionList(result *postgresql.ListVersionsResponse) {
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintln(w, "VERSION\tNAME")
-	for _, v := range result.DBVersions {
-		fmt.Fprintf(w, "%s\t%s\n",
-			v.DBVersion,
-			v.DBVersionName,
-		)
-	}
-	w.Flush()
-}
-
-func postgresqlPrintStorageTypeList(result *postgresql.ListStorageTypesResponse) {
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintln(w, "TYPE\tNAME")
-	for _, st := range result.StorageTypes {
-		fmt.Fprintf(w, "%s\t%s\n", st.StorageType, st.StorageTypeName)
-	}
-	w.Flush()
-}
-
-func init() {
-	rdsPostgreSQLCmd.AddCommand(describePostgreSQLFlavorsCmd)
-	rdsPostgreSQLCmd.AddCommand(describePostgreSQLEngineVersionsCmd)
-	rdsPostgreSQLCmd.AddCommand(describePostgreSQLStorageTypesCmd)
-}
@@ -3,16 +3,12 @@ package cmd
 import (
 	"context"
 	"fmt"
-	"os"
-	"text/tabwriter"
 
 	"github.com/haung921209/nhn-cloud-sdk-go/nhncloud/database/postgresql"
 	"github.com/spf13/cobra"
 )
 
 func init() {
-	rdsPostgreSQLCmd.AddCommand(describePostgreSQLSecurityGroupsCmd)
-
 	rdsPostgreSQLCmd.AddCommand(getPostgreSQLSecurityGroupCmd)
 	getPostgreSQLSecurityGroupCmd.Flags().String("db-security-group-identifier", "", "DB security group identifier (Required)")
 	getPostgreSQLSecurityGroupCmd.MarkFlagRequired("db-security-group-identifier")
@@ -40,34 +36,6 @@ func init() {
 	deletePostgreSQLSecurityGroupCmd.MarkFlagRequired("db-security-group-identifier")
 }
 
-var describePostgreSQLSecurityGroupsCmd = &cobra.Command{
-	Use:   "describe-db-security-groups",
-	Short: "Describe PostgreSQL DB security groups",
-	Run: func(cmd *cobra.Command, args []string) {
-		client := newPostgreSQLClient()
-
-		result, err := client.ListSecurityGroups(context.Background())
-		if err != nil {
-			exitWithError("failed to list security groups", err)
-		}
-
-		if output == "json" {
-			postgresqlPrintJSON(result)
-			return
-		}
-
-		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-		fmt.Fprintln(w, "ID\tNAME")
-		for _, sg := range result.DBSecurityGroups {
-			fmt.Fprintf(w, "%s\t%s\n",
-				sg.DBSecurityGroupID,
-				sg.DBSecurityGroupName,
-			)
-		}
-		w.Flush()
-	},
-}
-
 var getPostgreSQLSecurityGroupCmd = &cobra.Command{
 	Use:   "get-db-security-group",
 	Short: "Get details of a PostgreSQL DB security group",
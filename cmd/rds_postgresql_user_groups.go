@@ -95,61 +95,6 @@ var postgresqlDeleteUserGroupCmd = &cobra.Command{
 	},
 }
 
-// ============================================================================
-// Monitoring Commands
-// ============================================================================
-
-var postgresqlDescribeMetricsCmd = &cobra.Command{
-	Use:   "describe-metrics",
-	Short: "Describe available monitoring metrics",
-	Run: func(cmd *cobra.Command, args []string) {
-		client := newPostgreSQLClient()
-
-		result, err := client.ListMetrics(context.Background())
-		if err != nil {
-			exitWithError("failed to list metrics", err)
-		}
-
-		if output == "json" {
-			postgresqlPrintJSON(result)
-		} else {
-			postgresqlPrintMetricList(result)
-		}
-	},
-}
-
-var postgresqlGetMetricStatisticsCmd = &cobra.Command{
-	Use:   "get-metric-statistics",
-	Short: "Get metric statistics for an instance",
-	Run: func(cmd *cobra.Command, args []string) {
-		client := newPostgreSQLClient()
-
-		instanceID, err := getResolvedPostgreSQLInstanceID(cmd, client)
-		if err != nil {
-			exitWithError("failed to resolve instance ID", err)
-		}
-
-		from, _ := cmd.Flags().GetString("from")
-		to, _ := cmd.Flags().GetString("to")
-		interval, _ := cmd.Flags().GetInt("interval")
-
-		if from == "" || to == "" {
-			exitWithError("--from and --to are required (ISO8601 format)", nil)
-		}
-
-		result, err := client.GetMetricStatistics(context.Background(), instanceID, from, to, interval)
-		if err != nil {
-			exitWithError("failed to get metric statistics", err)
-		}
-
-		if output == "json" {
-			postgresqlPrintJSON(result)
-		} else {
-			postgresqlPrintMetricStatistics(result)
-		}
-	},
-}
-
 // ============================================================================
 // Print Functions
 // ============================================================================
@@ -181,28 +126,6 @@ func postgresqlPrintUserGroupDetail(result *postgresql.GetUserGroupResponse) {
 	}
 }
 
-func postgresqlPrintMetricList(result *postgresql.ListMetricsResponse) {
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintln(w, "METRIC_NAME\tUNIT")
-	for _, m := range result.Metrics {
-		fmt.Fprintf(w, "%s\t%s\n", m.MetricName, m.Unit)
-	}
-	w.Flush()
-}
-
-func postgresqlPrintMetricStatistics(result *postgresql.GetMetricStatisticsResponse) {
-	for _, stat := range result.MetricStatistics {
-		fmt.Printf("Metric: %s (%s)\n", stat.MetricName, stat.Unit)
-		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-		fmt.Fprintln(w, "  TIMESTAMP\tVALUE")
-		for _, v := range stat.Values {
-			fmt.Fprintf(w, "  %s\t%.2f\n", v.Timestamp, v.Value)
-		}
-		w.Flush()
-		fmt.Println()
-	}
-}
-
 func init() {
 	// User Group commands
 	rdsPostgreSQLCmd.AddCommand(postgresqlDescribeUserGroupsCmd)
@@ -216,13 +139,4 @@ func init() {
 	postgresqlCreateUserGroupCmd.Flags().Bool("select-all", false, "Select all project members")
 
 	postgresqlDeleteUserGroupCmd.Flags().String("user-group-id", "", "User group ID (required)")
-
-	// Monitoring commands
-	rdsPostgreSQLCmd.AddCommand(postgresqlDescribeMetricsCmd)
-	rdsPostgreSQLCmd.AddCommand(postgresqlGetMetricStatisticsCmd)
-
-	postgresqlGetMetricStatisticsCmd.Flags().String("db-instance-identifier", "", "DB instance identifier (required)")
-	postgresqlGetMetricStatisticsCmd.Flags().String("from", "", "Start time (ISO8601 format, required)")
-	postgresqlGetMetricStatisticsCmd.Flags().String("to", "", "End time (ISO8601 format, required)")
-	postgresqlGetMetricStatisticsCmd.Flags().Int("interval", 60, "Interval in seconds")
 }
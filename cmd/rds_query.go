@@ -0,0 +1,276 @@
+package cmd
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/haung921209/nhn-cloud-cli/internal/rdsengine"
+	"github.com/haung921209/nhn-cloud-cli/internal/sqlshell"
+	"github.com/spf13/cobra"
+)
+
+// ============================================================================
+// Non-interactive SQL (query / exec-sql)
+// ============================================================================
+
+// rdsQuerySQLErrorExitCode is the exit code of query when a statement fails;
+// connection and usage errors exit with 1 like every other command
+const rdsQuerySQLErrorExitCode = 2
+
+// rdsQueryResult is the outcome of one statement in json/yaml output when
+// more than one statement ran
+type rdsQueryResult struct {
+	Statement    int            `json:"statement" yaml:"statement"`
+	SQL          string         `json:"sql" yaml:"sql"`
+	Rows         []sqlshell.Row `json:"rows,omitempty" yaml:"rows,omitempty"`
+	RowsAffected *int64         `json:"rowsAffected,omitempty" yaml:"rowsAffected,omitempty"`
+	Error        string         `json:"error,omitempty" yaml:"error,omitempty"`
+
+	result *sqlshell.Result
+}
+
+// rdsSQLExecutor is implemented by *sql.Conn and *sql.Tx
+type rdsSQLExecutor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+func newRDSQueryCmd(engineFor func() rdsengine.Engine) *cobra.Command {
+	c := &cobra.Command{
+		Use:     "query",
+		Aliases: []string{"exec-sql"},
+		Short:   "Run SQL statements on a DB instance and print the results",
+		Long: `Runs SQL statements on a DB instance with the built-in driver (no mysql or
+psql client needed) and prints the results in the --output format: table,
+json, yaml or csv.
+
+The endpoint is resolved like show-db-endpoint (EXTERNAL first); use
+--via-bastion for private instances. The server certificate is verified with
+the CA from the cert store ('nhncloud config ca import').
+
+Statements come from --sql or --file ('-' reads stdin) and run in order on
+one session, stopping at the first error. With --single-transaction they run
+in one transaction that is rolled back on error.
+
+In json/yaml output a single statement prints its rows; several statements
+print one entry per statement with its rows or rowsAffected.
+
+Exit codes: 0 success, 1 connection or usage error, 2 SQL error.
+
+Examples:
+  nhncloud rds-mysql query --db-instance-identifier mydb --username admin \
+    --sql "SELECT id, name FROM users LIMIT 10" -o json
+
+  # Migration in one transaction, password from the environment
+  NHN_CLOUD_RDS_PASSWORD=... nhncloud rds-postgresql exec-sql \
+    --db-instance-identifier mypg --username admin --database app \
+    --file migrate.sql --single-transaction
+
+  nhncloud rds --engine mariadb query --db-instance-identifier mydb \
+    --username admin --sql "SHOW PROCESSLIST" -o csv > processlist.csv`,
+		Run: func(cmd *cobra.Command, args []string) {
+			sqlText, _ := cmd.Flags().GetString("sql")
+			file, _ := cmd.Flags().GetString("file")
+			singleTx, _ := cmd.Flags().GetBool("single-transaction")
+			timeout := getDurationFlag(cmd, "timeout")
+
+			switch {
+			case sqlText != "" && file != "":
+				exitWithError("--sql and --file cannot be used together", nil)
+			case file == "-":
+				data, err := io.ReadAll(os.Stdin)
+				if err != nil {
+					exitWithError("failed to read stdin", err)
+				}
+				sqlText = string(data)
+			case file != "":
+				data, err := os.ReadFile(file)
+				if err != nil {
+					exitWithError("failed to read SQL file", err)
+				}
+				sqlText = string(data)
+			case sqlText == "":
+				exitWithError("--sql or --file is required", nil)
+			}
+			if output != "table" && output != "json" && output != "yaml" && output != "csv" {
+				exitWithError(fmt.Sprintf("unsupported output format '%s' (table, json, yaml, csv)", output), nil)
+			}
+
+			e := engineFor()
+			syntax := rdsSQLDialect(e.Name()).Syntax()
+			stmts, rest, _ := sqlshell.Split(sqlText+"\n", syntax, ";")
+			if !sqlshell.IsBlank(rest, syntax) {
+				stmts = append(stmts, sqlshell.Statement{SQL: strings.TrimSpace(rest)})
+			}
+			if len(stmts) == 0 {
+				exitWithError("no SQL statements to run", nil)
+			}
+
+			conn := openRDSSQLConnection(cmd, e)
+			defer conn.Close()
+
+			ctx := context.Background()
+			if timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, timeout)
+				defer cancel()
+			}
+			results, err := runRDSQuery(ctx, conn.DB, stmts, singleTx)
+			printRDSQueryResults(results)
+			if err != nil {
+				if n := len(results); n > 0 && results[n-1].Error != "" {
+					fmt.Fprintf(os.Stderr, "Error: statement %d failed: %v\n", n, err)
+				} else {
+					fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				}
+				if singleTx {
+					fmt.Fprintln(os.Stderr, "Transaction rolled back.")
+				}
+				conn.Close()
+				os.Exit(rdsQuerySQLErrorExitCode)
+			}
+		},
+	}
+
+	addRDSSQLFlags(c)
+	c.Flags().String("sql", "", "SQL statement(s) to run, separated by ';'")
+	c.Flags().StringP("file", "f", "", "File with SQL statements ('-' for stdin)")
+	c.Flags().Bool("single-transaction", false, "Run all statements in one transaction (rolled back on error)")
+	c.Flags().String("timeout", "0", "Cancel the statements after this long (e.g. 30s; 0 = no limit)")
+	return c
+}
+
+// runRDSQuery runs stmts on one session. It returns the results of the
+// statements run so far; the last one carries the error, if any.
+func runRDSQuery(ctx context.Context, db *sql.DB, stmts []sqlshell.Statement, singleTx bool) ([]*rdsQueryResult, error) {
+	session, err := db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer session.Close()
+
+	var exec rdsSQLExecutor = session
+	var tx *sql.Tx
+	if singleTx {
+		if tx, err = session.BeginTx(ctx, nil); err != nil {
+			return nil, err
+		}
+		exec = tx
+	}
+
+	var results []*rdsQueryResult
+	for i, stmt := range stmts {
+		res := &rdsQueryResult{Statement: i + 1, SQL: stmt.SQL}
+		results = append(results, res)
+		start := time.Now()
+
+		if sqlshell.ReturnsRows(stmt.SQL) {
+			var rows *sql.Rows
+			rows, err = exec.QueryContext(ctx, stmt.SQL)
+			if err == nil {
+				var sets []*sqlshell.Result
+				sets, err = sqlshell.ReadResults(rows)
+				rows.Close()
+				if len(sets) > 0 {
+					res.result = sets[0]
+					res.Rows = sets[0].OrderedRows()
+				}
+			}
+		} else {
+			var r sql.Result
+			r, err = exec.ExecContext(ctx, stmt.SQL)
+			if err == nil {
+				affected, _ := r.RowsAffected()
+				res.RowsAffected = &affected
+				res.result = &sqlshell.Result{RowsAffected: affected}
+			}
+		}
+		if res.result != nil {
+			res.result.Elapsed = time.Since(start)
+		}
+		if err != nil {
+			res.Error = err.Error()
+			if tx != nil {
+				tx.Rollback()
+			}
+			return results, err
+		}
+	}
+
+	if tx != nil {
+		if err := tx.Commit(); err != nil {
+			return results, err
+		}
+	}
+	return results, nil
+}
+
+func printRDSQueryResults(results []*rdsQueryResult) {
+	switch output {
+	case "json", "yaml":
+		var data interface{} = results
+		if len(results) == 1 && results[0].Error == "" {
+			if res := results[0]; res.RowsAffected != nil {
+				data = map[string]int64{"rowsAffected": *res.RowsAffected}
+			} else {
+				data = res.Rows
+				if res.Rows == nil {
+					data = []sqlshell.Row{}
+				}
+			}
+		}
+		if err := printOutput(data); err != nil {
+			exitWithError("failed to print results", err)
+		}
+	case "csv":
+		first := true
+		for _, res := range results {
+			if res.result == nil || !res.result.HasRows {
+				continue
+			}
+			if !first {
+				fmt.Println()
+			}
+			first = false
+			if err := sqlshell.WriteCSV(os.Stdout, res.result); err != nil {
+				exitWithError("failed to write CSV", err)
+			}
+		}
+	default:
+		for i, res := range results {
+			if res.result == nil {
+				continue
+			}
+			if i > 0 {
+				fmt.Println()
+			}
+			if !res.result.HasRows {
+				fmt.Printf("OK, %d row(s) affected (%.3f sec)\n", res.result.RowsAffected, res.result.Elapsed.Seconds())
+				continue
+			}
+			if len(res.result.Rows) == 0 {
+				fmt.Println("No rows returned.")
+				continue
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, strings.Join(res.result.Columns, "\t"))
+			for _, row := range res.result.Rows {
+				cells := make([]string, len(row))
+				for j, v := range row {
+					cells[j] = "NULL"
+					if v != nil {
+						cells[j] = strings.NewReplacer("\t", " ", "\n", " ").Replace(*v)
+					}
+				}
+				fmt.Fprintln(w, strings.Join(cells, "\t"))
+			}
+			w.Flush()
+		}
+	}
+}
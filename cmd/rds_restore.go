@@ -0,0 +1,673 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/haung921209/nhn-cloud-cli/internal/rdsengine"
+	"github.com/haung921209/nhn-cloud-cli/pkg/auth"
+	sdkauth "github.com/haung921209/nhn-cloud-sdk-go/nhncloud/auth"
+	"github.com/haung921209/nhn-cloud-sdk-go/nhncloud/core"
+	"github.com/haung921209/nhn-cloud-sdk-go/nhncloud/storage/object"
+	"github.com/spf13/cobra"
+)
+
+// ============================================================================
+// Raw RDS API
+//
+// Point-in-time restore (POST /db-instances/{id}/restore) is not wrapped by
+// the SDK database clients. rdsAPI authenticates the way they do (bearer
+// token issued from the User Access Key) and calls it directly.
+// ============================================================================
+
+type rdsAPI struct {
+	client  *core.Client
+	version string
+	region  string
+}
+
+// rdsJobResponse is the response of asynchronous RDS operations
+type rdsJobResponse struct {
+	Header core.ResponseHeader `json:"header"`
+	JobID  string              `json:"jobId"`
+}
+
+// GetHeader implements core.WithHeader
+func (r *rdsJobResponse) GetHeader() *core.ResponseHeader {
+	return &r.Header
+}
+
+func newRDSAPI(engine string) *rdsAPI {
+	var region, appKey, accessKey, secretKey, service, version string
+	var err error
+	switch engine {
+	case rdsengine.MySQL:
+		cfg, e := auth.GetMySQLConfig()
+		region, appKey, accessKey, secretKey, err = cfg.Region, cfg.AppKey, cfg.AccessKey, cfg.SecretKey, e
+		service, version = "rds-mysql", "v4.0"
+	case rdsengine.MariaDB:
+		cfg, e := auth.GetMariaDBConfig()
+		region, appKey, accessKey, secretKey, err = cfg.Region, cfg.AppKey, cfg.AccessKey, cfg.SecretKey, e
+		service, version = "rds-mariadb", "v3.0"
+	case rdsengine.PostgreSQL:
+		cfg, e := auth.GetPostgreSQLConfig()
+		region, appKey, accessKey, secretKey, err = cfg.Region, cfg.AppKey, cfg.AccessKey, cfg.SecretKey, e
+		service, version = "rds-postgres", "v1.0"
+	default:
+		exitWithError(fmt.Sprintf("unknown engine '%s'", engine), nil)
+	}
+	if err != nil {
+		exitWithError("failed to load RDS credentials", err)
+	}
+	region = rdsRegion(region)
+
+	host := fmt.Sprintf("%s-%s.api.nhncloudservice.com", region, service)
+	return &rdsAPI{
+		client:  core.NewClient(host, sdkauth.NewBearerAuthWithAutoRefresh(appKey, accessKey, secretKey), nil),
+		version: version,
+		region:  region,
+	}
+}
+
+// rdsRegion returns the --region flag if it was given on the command line,
+// otherwise the region of the RDS credentials. $NHN_CLOUD_REGION, the
+// flag's default, does not override the RDS region.
+func rdsRegion(configured string) string {
+	if rootCmd.PersistentFlags().Changed("region") {
+		return strings.ToLower(region)
+	}
+	return configured
+}
+
+// Do calls path (relative to the API version) with an optional JSON body and
+// decodes the response into result
+func (a *rdsAPI) Do(ctx context.Context, method, path string, body, result interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, "/"+a.version+path, reader)
+	if err != nil {
+		return err
+	}
+	resp, err := a.client.Do(ctx, req)
+	if err != nil {
+		return err
+	}
+	return core.ParseResponse(resp, result)
+}
+
+// rdsPointInTimeRestoreRequest creates a new instance from the backups and
+// logs of a source instance
+type rdsPointInTimeRestoreRequest struct {
+	Restore            rdsRestoreTarget  `json:"restore"`
+	DBInstanceName     string            `json:"dbInstanceName"`
+	Description        string            `json:"description,omitempty"`
+	DBFlavorID         string            `json:"dbFlavorId"`
+	DBPort             int               `json:"dbPort,omitempty"`
+	ParameterGroupID   string            `json:"parameterGroupId,omitempty"`
+	DBSecurityGroupIDs []string          `json:"dbSecurityGroupIds,omitempty"`
+	Network            rdsRestoreNetwork `json:"network"`
+	Storage            rdsRestoreStorage `json:"storage"`
+	Backup             rdsRestoreBackup  `json:"backup"`
+}
+
+type rdsRestoreTarget struct {
+	RestoreType string `json:"restoreType"`
+	RestoreYmdt string `json:"restoreYmdt"`
+}
+
+type rdsRestoreNetwork struct {
+	SubnetID         string `json:"subnetId"`
+	UsePublicAccess  bool   `json:"usePublicAccess"`
+	AvailabilityZone string `json:"availabilityZone,omitempty"`
+}
+
+type rdsRestoreStorage struct {
+	StorageType string `json:"storageType,omitempty"`
+	StorageSize int    `json:"storageSize,omitempty"`
+}
+
+type rdsRestoreBackup struct {
+	BackupPeriod    int                `json:"backupPeriod"`
+	BackupSchedules []rdsBackupWindows `json:"backupSchedules,omitempty"`
+}
+
+type rdsBackupWindows struct {
+	BackupWndBgnTime  string `json:"backupWndBgnTime" yaml:"backupWndBgnTime"`
+	BackupWndDuration string `json:"backupWndDuration" yaml:"backupWndDuration"`
+}
+
+// ============================================================================
+// Backup to Object Storage / point-in-time restore
+// ============================================================================
+
+// rdsRestorePoint is a snapshot, or the window covered by binary logs (WAL
+// for PostgreSQL) in which any time can be restored
+type rdsRestorePoint struct {
+	Type       string `json:"type" yaml:"type"`
+	BackupID   string `json:"backupId,omitempty" yaml:"backupId,omitempty"`
+	BackupName string `json:"backupName,omitempty" yaml:"backupName,omitempty"`
+	BackupType string `json:"backupType,omitempty" yaml:"backupType,omitempty"`
+	Size       int64  `json:"backupSize,omitempty" yaml:"backupSize,omitempty"`
+	Time       string `json:"time,omitempty" yaml:"time,omitempty"`
+	From       string `json:"from,omitempty" yaml:"from,omitempty"`
+	To         string `json:"to,omitempty" yaml:"to,omitempty"`
+
+	at time.Time
+}
+
+// rdsRestorationInfo is the point-in-time restore range the service reports
+// for an instance
+type rdsRestorationInfo struct {
+	Header          core.ResponseHeader `json:"header"`
+	RestorableTimes []rdsRestorableTime `json:"restorableTimes"`
+}
+
+// GetHeader implements core.WithHeader
+func (r *rdsRestorationInfo) GetHeader() *core.ResponseHeader {
+	return &r.Header
+}
+
+// rdsRestorableTime is one continuous range the service can restore to, with
+// the backup it restores from
+type rdsRestorableTime struct {
+	BeginYmdt string               `json:"restorableBeginYmdt"`
+	EndYmdt   string               `json:"restorableEndYmdt"`
+	Backup    *rdsRestorableBackup `json:"backup,omitempty"`
+
+	begin, end time.Time
+}
+
+type rdsRestorableBackup struct {
+	BackupID   string `json:"backupId"`
+	BackupName string `json:"backupName"`
+}
+
+// rdsRestorableTimes returns the ranges the service can restore an instance
+// to, oldest first
+func rdsRestorableTimes(ctx context.Context, engine, instanceID string) ([]rdsRestorableTime, error) {
+	var info rdsRestorationInfo
+	if err := newRDSAPI(engine).Do(ctx, http.MethodGet, "/db-instances/"+instanceID+"/restoration-info", nil, &info); err != nil {
+		return nil, err
+	}
+	var ranges []rdsRestorableTime
+	for _, r := range info.RestorableTimes {
+		begin, ok := parseFilterDate(r.BeginYmdt)
+		if !ok {
+			return nil, fmt.Errorf("unexpected restorable time %q", r.BeginYmdt)
+		}
+		end, ok := parseFilterDate(r.EndYmdt)
+		if !ok {
+			return nil, fmt.Errorf("unexpected restorable time %q", r.EndYmdt)
+		}
+		r.begin, r.end = begin, end
+		ranges = append(ranges, r)
+	}
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i].begin.Before(ranges[j].begin) })
+	return ranges, nil
+}
+
+// rdsBaseBackup is a completed backup with its parsed completion time
+type rdsBaseBackup struct {
+	rdsengine.Backup
+	completed time.Time
+}
+
+// rdsCompletedBackups returns the completed backups of an instance, oldest
+// first
+func rdsCompletedBackups(ctx context.Context, e rdsengine.Engine, instanceID string) ([]rdsBaseBackup, error) {
+	backups, err := e.ListBackups(ctx, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	var completed []rdsBaseBackup
+	for _, b := range backups {
+		if !strings.EqualFold(b.Status, "COMPLETED") {
+			continue
+		}
+		t, ok := parseFilterDate(firstNonEmpty(b.CompletedAt, b.CreatedAt))
+		if !ok {
+			continue
+		}
+		completed = append(completed, rdsBaseBackup{Backup: b, completed: t})
+	}
+	sort.Slice(completed, func(i, j int) bool { return completed[i].completed.Before(completed[j].completed) })
+	return completed, nil
+}
+
+// rdsLogName names the logs replayed on top of a backup
+func rdsLogName(engine string) string {
+	if engine == rdsengine.PostgreSQL {
+		return "WAL"
+	}
+	return "BINLOG"
+}
+
+func newRDSRestoreCommands(engineFor func() rdsengine.Engine) []*cobra.Command {
+	backupToOBS := &cobra.Command{
+		Use:   "backup-db-to-object-storage",
+		Short: "Back up a DB instance directly into Object Storage",
+		Long: `Backs up a DB instance into an Object Storage container (a physical backup
+made by the RDS service, unlike the logical 'dump').
+
+The Object Storage credentials are the tenant ID, NHN Cloud ID and API
+password of the CLI configuration (--tenant-id, --username, --password or
+NHN_CLOUD_TENANT_ID, NHN_CLOUD_USERNAME, NHN_CLOUD_PASSWORD).
+
+With --wait the command returns once the instance has finished the backup
+and the backup files are in the container.
+
+Examples:
+  nhncloud rds-mysql backup-db-to-object-storage --db-instance-identifier mydb \
+    --to obs://backups/mydb/2025-01-01/ --wait
+
+  nhncloud rds --engine postgresql backup-db-to-object-storage \
+    --db-instance-identifier mypg --to obs://backups/mypg/`,
+		Run: func(cmd *cobra.Command, args []string) {
+			to, _ := cmd.Flags().GetString("to")
+			wait, _ := cmd.Flags().GetBool("wait")
+			timeout := getDurationFlag(cmd, "timeout")
+
+			path, err := parseOBSPath(to)
+			if err != nil || !path.IsRemote {
+				exitWithError("--to must be an Object Storage path (obs://container/path/)", err)
+			}
+			target := rdsengine.ObjectStorageTarget{
+				TenantID:   getTenantID(),
+				Username:   getUsername(),
+				Password:   getPassword(),
+				Container:  path.Container,
+				ObjectPath: path.Object,
+			}
+			if target.TenantID == "" || target.Username == "" || target.Password == "" {
+				exitWithError("Object Storage credentials are required: set --tenant-id, --username and --password (or NHN_CLOUD_TENANT_ID, NHN_CLOUD_USERNAME, NHN_CLOUD_PASSWORD)", nil)
+			}
+
+			e := engineFor()
+			id := resolveRDSInstanceID(cmd, e)
+			ctx := context.Background()
+			// Objects already under the path belong to earlier backups
+			var existing map[string]string
+			if wait {
+				if existing, err = listRDSBackupObjects(ctx, path); err != nil {
+					exitWithError("failed to list objects", err)
+				}
+			}
+			jobID, err := e.BackupToObjectStorage(ctx, id, target)
+			if err != nil {
+				exitWithError("failed to start backup to Object Storage", err)
+			}
+			fmt.Printf("Backup to %s initiated.\n", to)
+			fmt.Printf("Job ID: %s\n", jobID)
+			if !wait {
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			objects, err := waitForRDSObjectStorageBackup(ctx, e, id, path, existing, os.Stderr)
+			if err != nil {
+				exitWithError("backup did not complete", err)
+			}
+			var size int64
+			for _, o := range objects {
+				size += o.Bytes
+			}
+			fmt.Printf("Backup complete: %d object(s), %d bytes in %s\n", len(objects), size, to)
+		},
+	}
+	backupToOBS.Flags().String("db-instance-identifier", "", "DB instance identifier (name or ID)")
+	backupToOBS.Flags().String("to", "", "Destination (obs://container/path/) (required)")
+	backupToOBS.Flags().Bool("wait", false, "Wait until the backup is complete")
+	backupToOBS.Flags().String("timeout", "2h", "Max time to wait with --wait (Go duration)")
+
+	describeRestorePoints := &cobra.Command{
+		Use:   "describe-db-restore-points",
+		Short: "Describe the snapshots and restorable time range of a DB instance",
+		Long: `Lists the times a DB instance can be restored to: every completed snapshot
+(backup), and the ranges the service reports as restorable to any point in
+time with restore-db-instance-to-point-in-time, by replaying binary logs
+(WAL for PostgreSQL) on top of a backup.
+
+Examples:
+  nhncloud rds-mysql describe-db-restore-points --db-instance-identifier mydb
+  nhncloud rds --engine postgresql describe-db-restore-points --db-instance-identifier mypg -o json`,
+		Run: func(cmd *cobra.Command, args []string) {
+			e := engineFor()
+			ctx := context.Background()
+			id := resolveRDSInstanceID(cmd, e)
+			backups, err := rdsCompletedBackups(ctx, e, id)
+			if err != nil {
+				exitWithError("failed to list backups", err)
+			}
+			ranges, err := rdsRestorableTimes(ctx, e.Name(), id)
+			if err != nil {
+				exitWithError("failed to get restorable time range", err)
+			}
+
+			var points []rdsRestorePoint
+			for _, b := range backups {
+				points = append(points, rdsRestorePoint{
+					Type:       "SNAPSHOT",
+					BackupID:   b.ID,
+					BackupName: b.Name,
+					BackupType: b.Type,
+					Size:       b.Size,
+					Time:       b.completed.Format(time.RFC3339),
+					at:         b.completed,
+				})
+			}
+			for _, r := range ranges {
+				p := rdsRestorePoint{Type: rdsLogName(e.Name()), From: r.BeginYmdt, To: r.EndYmdt, at: r.begin}
+				if r.Backup != nil {
+					p.BackupID, p.BackupName = r.Backup.BackupID, r.Backup.BackupName
+				}
+				points = append(points, p)
+			}
+			sort.SliceStable(points, func(i, j int) bool { return points[i].at.Before(points[j].at) })
+
+			if output == "json" || output == "yaml" {
+				if points == nil {
+					points = []rdsRestorePoint{}
+				}
+				data := map[string]interface{}{
+					"dbInstanceId":  id,
+					"restorePoints": points,
+				}
+				if len(ranges) > 0 {
+					data["earliestRestorableTime"] = ranges[0].BeginYmdt
+					data["latestRestorableTime"] = ranges[len(ranges)-1].EndYmdt
+				}
+				if err := printOutput(data); err != nil {
+					exitWithError("failed to print restore points", err)
+				}
+				return
+			}
+			if len(points) == 0 {
+				fmt.Println("No completed backups; the instance cannot be restored yet.")
+				return
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "TYPE\tTIME\tBACKUP_ID\tNAME\tBACKUP_TYPE\tSIZE")
+			for _, p := range points {
+				if p.Time == "" {
+					fmt.Fprintf(w, "%s\t%s ~ %s\t%s\t%s\t-\t-\n", p.Type, p.From, p.To, firstNonEmpty(p.BackupID, "-"), firstNonEmpty(p.BackupName, "-"))
+					continue
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\n", p.Type, p.Time, p.BackupID, p.BackupName, p.BackupType, p.Size)
+			}
+			w.Flush()
+			if len(ranges) == 0 {
+				fmt.Println("\nThe service reports no point-in-time restorable range.")
+				return
+			}
+			fmt.Printf("\nRestorable: %s ~ %s\n", ranges[0].BeginYmdt, ranges[len(ranges)-1].EndYmdt)
+		},
+	}
+	describeRestorePoints.Flags().String("db-instance-identifier", "", "DB instance identifier (name or ID)")
+
+	restorePITR := &cobra.Command{
+		Use:   "restore-db-instance-to-point-in-time",
+		Short: "Create a new DB instance restored to a point in time",
+		Long: `Creates a new DB instance from a source instance as it was at --restore-time.
+The time must fall in a range the service reports as restorable (see
+describe-db-restore-points); the service picks the backup to restore and
+replays the logs up to that time. --use-latest-restorable-time restores to
+the end of the latest range.
+
+The new instance gets the source's flavor, port, parameter group, DB
+security groups, subnet, availability zone, public access and storage
+unless overridden. Run describe-db-restore-points for the restorable range;
+--dry-run prints the plan without restoring.
+
+Examples:
+  nhncloud rds-mysql restore-db-instance-to-point-in-time \
+    --db-instance-identifier mydb --target-db-instance-identifier mydb-restored \
+    --restore-time 2025-10-15T03:10:00Z --wait
+
+  nhncloud rds --engine postgresql restore-db-instance-to-point-in-time \
+    --db-instance-identifier mypg --target-db-instance-identifier mypg-latest \
+    --use-latest-restorable-time --db-flavor-id <flavor-id>`,
+		Run: func(cmd *cobra.Command, args []string) {
+			targetName, _ := cmd.Flags().GetString("target-db-instance-identifier")
+			restoreTime, _ := cmd.Flags().GetString("restore-time")
+			useLatest, _ := cmd.Flags().GetBool("use-latest-restorable-time")
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			wait, _ := cmd.Flags().GetBool("wait")
+			timeout := getDurationFlag(cmd, "timeout")
+
+			if targetName == "" {
+				exitWithError("--target-db-instance-identifier is required", nil)
+			}
+			if (restoreTime == "") == !useLatest {
+				exitWithError("exactly one of --restore-time and --use-latest-restorable-time is required", nil)
+			}
+			var at time.Time
+			if restoreTime != "" {
+				var err error
+				if at, err = time.Parse(time.RFC3339, restoreTime); err != nil {
+					exitWithError("invalid --restore-time (RFC 3339, e.g. 2025-10-15T03:10:00Z)", err)
+				}
+			}
+
+			e := engineFor()
+			ctx := context.Background()
+			id := resolveRDSInstanceID(cmd, e)
+			source, err := e.GetInstance(ctx, id)
+			if err != nil {
+				exitWithError("failed to get source instance", err)
+			}
+			ranges, err := rdsRestorableTimes(ctx, e.Name(), id)
+			if err != nil {
+				exitWithError("failed to get restorable time range", err)
+			}
+			if len(ranges) == 0 {
+				exitWithError(fmt.Sprintf("the service reports no restorable time for instance %s", source.Name), nil)
+			}
+			var match *rdsRestorableTime
+			if useLatest {
+				match = &ranges[len(ranges)-1]
+				at = match.end
+			} else {
+				for i := range ranges {
+					if !at.Before(ranges[i].begin) && !at.After(ranges[i].end) {
+						match = &ranges[i]
+					}
+				}
+			}
+			if match == nil {
+				var spans []string
+				for _, r := range ranges {
+					spans = append(spans, r.BeginYmdt+" ~ "+r.EndYmdt)
+				}
+				exitWithError(fmt.Sprintf("--restore-time is outside the restorable time range (%s)", strings.Join(spans, ", ")), nil)
+			}
+
+			req := rdsPointInTimeRestoreRequest{
+				Restore:            rdsRestoreTarget{RestoreType: "TIMESTAMP", RestoreYmdt: at.Format(time.RFC3339)},
+				DBInstanceName:     targetName,
+				Description:        fmt.Sprintf("Restored from %s to %s", source.Name, at.Format(time.RFC3339)),
+				DBFlavorID:         source.FlavorID,
+				DBPort:             source.Port,
+				ParameterGroupID:   source.ParameterGroupID,
+				DBSecurityGroupIDs: source.SecurityGroupIDs,
+				Network: rdsRestoreNetwork{
+					SubnetID:         source.SubnetID,
+					UsePublicAccess:  source.PublicAccess,
+					AvailabilityZone: source.AvailabilityZone,
+				},
+				Storage: rdsRestoreStorage{StorageType: source.StorageType, StorageSize: source.StorageSize},
+			}
+			if v, _ := cmd.Flags().GetString("db-flavor-id"); v != "" {
+				req.DBFlavorID = v
+			}
+			if v, _ := cmd.Flags().GetString("parameter-group-id"); v != "" {
+				req.ParameterGroupID = v
+			}
+			if v, _ := cmd.Flags().GetStringSlice("db-security-group-ids"); len(v) > 0 {
+				req.DBSecurityGroupIDs = v
+			}
+			if v, _ := cmd.Flags().GetString("subnet-id"); v != "" {
+				req.Network.SubnetID = v
+			}
+			if v, _ := cmd.Flags().GetString("availability-zone"); v != "" {
+				req.Network.AvailabilityZone = v
+			}
+			req.Backup.BackupPeriod, _ = cmd.Flags().GetInt("backup-period")
+			windowStart, _ := cmd.Flags().GetString("backup-window-start")
+			windowDuration, _ := cmd.Flags().GetString("backup-window-duration")
+			if req.Backup.BackupPeriod > 0 {
+				req.Backup.BackupSchedules = []rdsBackupWindows{{BackupWndBgnTime: windowStart, BackupWndDuration: windowDuration}}
+			}
+
+			fmt.Printf("Source:       %s (%s)\n", source.Name, source.ID)
+			fmt.Printf("Restore time: %s\n", req.Restore.RestoreYmdt)
+			fmt.Printf("Restorable:   %s ~ %s\n", match.BeginYmdt, match.EndYmdt)
+			if match.Backup != nil {
+				fmt.Printf("Base backup:  %s (%s) + %s\n", match.Backup.BackupName, match.Backup.BackupID, rdsLogName(e.Name()))
+			}
+			fmt.Printf("New instance: %s (flavor %s, port %d, subnet %s, az %s)\n",
+				req.DBInstanceName, req.DBFlavorID, req.DBPort, req.Network.SubnetID, firstNonEmpty(req.Network.AvailabilityZone, "-"))
+			if dryRun {
+				fmt.Println("Dry run: no instance created.")
+				return
+			}
+
+			var resp rdsJobResponse
+			if err := newRDSAPI(e.Name()).Do(ctx, http.MethodPost, "/db-instances/"+id+"/restore", req, &resp); err != nil {
+				exitWithError("failed to restore to point in time", err)
+			}
+			fmt.Printf("DB instance restore initiated.\n")
+			fmt.Printf("Job ID: %s\n", resp.JobID)
+			if !wait {
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			inst, err := waitForRDSInstanceByName(ctx, e, targetName, "AVAILABLE", os.Stderr)
+			if err != nil {
+				exitWithError("restore did not complete", err)
+			}
+			fmt.Printf("DB instance %s (%s) is %s\n", inst.Name, inst.ID, inst.Status)
+		},
+	}
+	restorePITR.Flags().String("db-instance-identifier", "", "Source DB instance identifier (name or ID)")
+	restorePITR.Flags().String("target-db-instance-identifier", "", "Name of the new DB instance (required)")
+	restorePITR.Flags().String("restore-time", "", "Time to restore to (RFC 3339, e.g. 2025-10-15T03:10:00Z)")
+	restorePITR.Flags().Bool("use-latest-restorable-time", false, "Restore to the end of the latest range the service reports as restorable")
+	restorePITR.Flags().String("db-flavor-id", "", "Flavor ID (default: source's)")
+	restorePITR.Flags().String("parameter-group-id", "", "Parameter group ID (default: source's)")
+	restorePITR.Flags().StringSlice("db-security-group-ids", nil, "DB security group IDs (default: source's)")
+	restorePITR.Flags().String("subnet-id", "", "Subnet ID (default: source's)")
+	restorePITR.Flags().String("availability-zone", "", "Availability zone (default: source's)")
+	restorePITR.Flags().Int("backup-period", 1, "Backup retention period of the new instance in days (0 disables backups)")
+	restorePITR.Flags().String("backup-window-start", "00:00:00", "Backup window start time (HH:MM:SS)")
+	restorePITR.Flags().String("backup-window-duration", "ONE_HOUR", "Backup window duration (ONE_HOUR, TWO_HOURS, etc.)")
+	restorePITR.Flags().Bool("dry-run", false, "Print the restore plan without restoring")
+	restorePITR.Flags().Bool("wait", false, "Wait until the new instance is AVAILABLE")
+	restorePITR.Flags().String("timeout", "2h", "Max time to wait with --wait (Go duration)")
+
+	return []*cobra.Command{backupToOBS, describeRestorePoints, restorePITR}
+}
+
+// listRDSBackupObjects returns the objects under an Object Storage path,
+// keyed by name, with their hash and modification time as the value
+func listRDSBackupObjects(ctx context.Context, path *OBSPath) (map[string]string, error) {
+	out, err := getObjectStorageClient().ListObjects(ctx, path.Container, &object.ListObjectsInput{Prefix: path.Object})
+	if err != nil {
+		return nil, err
+	}
+	objects := map[string]string{}
+	for _, o := range out.Objects {
+		objects[o.Name] = o.Hash + " " + o.LastModified
+	}
+	return objects, nil
+}
+
+// waitForRDSObjectStorageBackup waits until an instance has finished a
+// backup to Object Storage and returns the objects it wrote: those not in
+// existing, or changed since. The backup is done when the instance's
+// progress status, having been busy, is back to NONE, or is idle while new
+// objects already exist under the path.
+func waitForRDSObjectStorageBackup(ctx context.Context, e rdsengine.Engine, instanceID string, path *OBSPath, existing map[string]string, progress io.Writer) ([]object.Object, error) {
+	client := getObjectStorageClient()
+	newObjects := func() ([]object.Object, error) {
+		out, err := client.ListObjects(ctx, path.Container, &object.ListObjectsInput{Prefix: path.Object})
+		if err != nil {
+			return nil, err
+		}
+		var written []object.Object
+		for _, o := range out.Objects {
+			if v, ok := existing[o.Name]; !ok || v != o.Hash+" "+o.LastModified {
+				written = append(written, o)
+			}
+		}
+		return written, nil
+	}
+
+	busySeen := false
+	for {
+		inst, err := e.GetInstance(ctx, instanceID)
+		if err == nil {
+			if strings.Contains(strings.ToUpper(inst.Status), "FAIL") {
+				return nil, fmt.Errorf("instance %s entered state %s", instanceID, inst.Status)
+			}
+			busy := inst.ProgressStatus != "" && !strings.EqualFold(inst.ProgressStatus, "NONE")
+			if busy {
+				busySeen = true
+				fmt.Fprintf(progress, "instance %s progress=%s — waiting...\n", instanceID, inst.ProgressStatus)
+			} else {
+				objects, lerr := newObjects()
+				if lerr == nil && (busySeen || len(objects) > 0) {
+					if len(objects) == 0 {
+						return nil, fmt.Errorf("backup finished but no new objects were found under %s", path.RawPath)
+					}
+					return objects, nil
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("timed out waiting for the backup")
+		case <-time.After(15 * time.Second):
+		}
+	}
+}
+
+// waitForRDSInstanceByName waits for an instance that is being created (and
+// has no ID yet) to appear and reach the status want
+func waitForRDSInstanceByName(ctx context.Context, e rdsengine.Engine, name, want string, progress io.Writer) (*rdsengine.Instance, error) {
+	for {
+		instances, err := e.ListInstances(ctx)
+		if err == nil {
+			for _, inst := range instances {
+				if inst.Name == name {
+					return waitForRDSInstance(ctx, e, inst.ID, want, 15*time.Second, progress)
+				}
+			}
+			fmt.Fprintf(progress, "instance %s not created yet — waiting...\n", name)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("timed out waiting for instance %s to appear", name)
+		case <-time.After(15 * time.Second):
+		}
+	}
+}
@@ -0,0 +1,326 @@
+package cmd
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/haung921209/nhn-cloud-cli/internal/cert"
+	"github.com/haung921209/nhn-cloud-cli/internal/rdsengine"
+	"github.com/haung921209/nhn-cloud-cli/internal/sqlshell"
+	"github.com/lib/pq"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// ============================================================================
+// Built-in SQL connections (native shells, query, dump)
+// ============================================================================
+
+// rdsSQLTarget describes where and how the built-in drivers connect
+type rdsSQLTarget struct {
+	Host string
+	Port int
+	// ServerName is the host name the server certificate is checked
+	// against; it differs from Host when connecting through a tunnel
+	ServerName string
+
+	Username string
+	Password string
+	Database string
+
+	// Region, InstanceID, Version and CAPath select the certificates in
+	// the cert store ('nhncloud config ca import')
+	Region     string
+	InstanceID string
+	Version    string
+	CAPath     string
+}
+
+// openRDSDatabase connects with the built-in driver of engine. TLS uses the
+// CA (and client certificate) from the cert store and verifies the server;
+// without a stored CA the connection is encrypted when the server supports
+// it but not verified, and a warning is printed.
+func openRDSDatabase(ctx context.Context, engine string, t rdsSQLTarget) (*sql.DB, error) {
+	helper, err := cert.NewHelper()
+	if err != nil {
+		return nil, err
+	}
+	serviceType := "rds-" + engine
+	caPath := helper.CAPath(serviceType, t.Region, t.InstanceID, t.Version, t.CAPath)
+	if caPath == "" {
+		fmt.Fprintf(os.Stderr, "Warning: no CA certificate for %s in region %s; the server certificate is NOT verified.\n", serviceType, t.Region)
+		fmt.Fprintf(os.Stderr, "  Import one with: nhncloud config ca import --service %s --region %s --file <ca.pem>\n", serviceType, t.Region)
+	}
+
+	var db *sql.DB
+	switch engine {
+	case rdsengine.MySQL, rdsengine.MariaDB:
+		tlsConfig, err := helper.TLSConfig(serviceType, t.Region, t.InstanceID, t.Version, t.CAPath, t.ServerName)
+		if err != nil {
+			return nil, err
+		}
+		cfg := mysql.NewConfig()
+		cfg.User = t.Username
+		cfg.Passwd = t.Password
+		cfg.Net = "tcp"
+		cfg.Addr = net.JoinHostPort(t.Host, strconv.Itoa(t.Port))
+		cfg.DBName = t.Database
+		cfg.AllowNativePasswords = true
+		if tlsConfig != nil {
+			cfg.TLS = tlsConfig
+		} else {
+			cfg.TLSConfig = "preferred"
+		}
+		connector, err := mysql.NewConnector(cfg)
+		if err != nil {
+			return nil, err
+		}
+		db = sql.OpenDB(connector)
+	case rdsengine.PostgreSQL:
+		params := map[string]string{
+			"host":     t.Host,
+			"port":     strconv.Itoa(t.Port),
+			"user":     t.Username,
+			"password": t.Password,
+			"dbname":   firstNonEmpty(t.Database, "postgres"),
+			"sslmode":  "require",
+		}
+		if caPath != "" {
+			// The host name can only be checked when connecting to it
+			// directly (not through a tunnel or by IP address)
+			params["sslmode"] = "verify-ca"
+			if t.ServerName == t.Host && net.ParseIP(t.Host) == nil {
+				params["sslmode"] = "verify-full"
+			}
+			params["sslrootcert"] = caPath
+			certPath, _ := helper.GetCertificateForDatabase(serviceType, t.Region, t.InstanceID, t.Version, true, "", "CLIENT-CERT")
+			keyPath, _ := helper.GetCertificateForDatabase(serviceType, t.Region, t.InstanceID, t.Version, true, "", "CLIENT-KEY")
+			if certPath != "" && keyPath != "" {
+				params["sslcert"], params["sslkey"] = certPath, keyPath
+			}
+		}
+		db, err = openPostgreSQL(ctx, params)
+		if err != nil && params["sslmode"] == "require" && errors.Is(err, pq.ErrSSLNotSupported) {
+			// Like psql's default sslmode=prefer
+			fmt.Fprintln(os.Stderr, "Warning: the server does not support SSL; connecting without encryption.")
+			params["sslmode"] = "disable"
+			db, err = openPostgreSQL(ctx, params)
+		}
+		return db, err
+	default:
+		return nil, fmt.Errorf("the built-in driver does not support %s", engine)
+	}
+
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// openPostgreSQL connects with a lib/pq key/value connection string
+func openPostgreSQL(ctx context.Context, params map[string]string) (*sql.DB, error) {
+	var parts []string
+	for _, key := range []string{"host", "port", "user", "password", "dbname", "sslmode", "sslrootcert", "sslcert", "sslkey"} {
+		if value, ok := params[key]; ok {
+			value = strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value)
+			parts = append(parts, fmt.Sprintf("%s='%s'", key, value))
+		}
+	}
+	connector, err := pq.NewConnector(strings.Join(parts, " "))
+	if err != nil {
+		return nil, err
+	}
+	db := sql.OpenDB(connector)
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// rdsSQLDialect returns the built-in shell dialect of engine
+func rdsSQLDialect(engine string) sqlshell.Dialect {
+	switch engine {
+	case rdsengine.MySQL:
+		return &sqlshell.MySQL{Label: "mysql"}
+	case rdsengine.MariaDB:
+		return &sqlshell.MySQL{Label: "MariaDB"}
+	case rdsengine.PostgreSQL:
+		return &sqlshell.PostgreSQL{}
+	}
+	exitWithError(fmt.Sprintf("no built-in shell for %s", engine), nil)
+	return nil
+}
+
+// newRDSShell opens a built-in shell session on db
+func newRDSShell(ctx context.Context, db *sql.DB, engine, database string) *sqlshell.Shell {
+	shell, err := sqlshell.New(ctx, db, rdsSQLDialect(engine), database)
+	if err != nil {
+		exitWithError("failed to open session", err)
+	}
+	return shell
+}
+
+// runRDSStatements executes the statements in text like the shell would and
+// returns the first error
+func runRDSStatements(db *sql.DB, engine, database, text string) error {
+	ctx := context.Background()
+	shell := newRDSShell(ctx, db, engine, database)
+	defer shell.Close()
+
+	stmts, rest, _ := sqlshell.Split(text+"\n", shell.Dialect.Syntax(), ";")
+	if !sqlshell.IsBlank(rest, shell.Dialect.Syntax()) {
+		stmts = append(stmts, sqlshell.Statement{SQL: strings.TrimSpace(rest)})
+	}
+	for _, stmt := range stmts {
+		if err := shell.Exec(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runRDSShell runs the built-in interactive shell on db
+func runRDSShell(db *sql.DB, engine, database string) {
+	ctx := context.Background()
+	shell := newRDSShell(ctx, db, engine, database)
+	defer shell.Close()
+	shell.Timing = true
+
+	fmt.Println("Built-in shell. Type '\\?' for help, '\\q' to quit.")
+	if err := shell.Run(ctx); err != nil {
+		exitWithError("shell error", err)
+	}
+}
+
+// addRDSSQLFlags registers the connection flags of the commands that run SQL
+// through the built-in drivers
+func addRDSSQLFlags(c *cobra.Command) {
+	c.Flags().String("db-instance-identifier", "", "DB instance identifier (name or ID)")
+	c.Flags().String("username", os.Getenv("NHN_CLOUD_RDS_USERNAME"), "Database username (default: $NHN_CLOUD_RDS_USERNAME)")
+	c.Flags().String("password", "", "Database password (default: $NHN_CLOUD_RDS_PASSWORD, or prompted)")
+	c.Flags().String("database", "", "Database name")
+	c.Flags().String("ca-path", "", "Explicit path to CA certificate (default: from the cert store)")
+	addBastionFlags(c)
+}
+
+// rdsSQLPassword returns --password, $NHN_CLOUD_RDS_PASSWORD, or prompts for
+// the password on the terminal
+func rdsSQLPassword(cmd *cobra.Command, username string) string {
+	if password, _ := cmd.Flags().GetString("password"); password != "" {
+		return password
+	}
+	if password := os.Getenv("NHN_CLOUD_RDS_PASSWORD"); password != "" {
+		return password
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		exitWithError("--password or NHN_CLOUD_RDS_PASSWORD is required", nil)
+	}
+	fmt.Fprintf(os.Stderr, "Password for %s: ", username)
+	password, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		exitWithError("failed to read password", err)
+	}
+	return string(password)
+}
+
+// rdsSQLConnection is a built-in driver connection opened from the
+// addRDSSQLFlags flags
+type rdsSQLConnection struct {
+	DB       *sql.DB
+	Engine   string
+	Instance *rdsengine.Instance
+	Target   rdsSQLTarget
+
+	tunnel *bastionTunnel
+}
+
+// Close closes the database and the bastion tunnel, if any
+func (c *rdsSQLConnection) Close() {
+	if c.DB != nil {
+		c.DB.Close()
+	}
+	if c.tunnel != nil {
+		c.tunnel.Close()
+	}
+}
+
+// openRDSSQLConnection resolves the instance endpoint the way
+// show-db-endpoint does (or a private endpoint through --via-bastion) and
+// connects with the built-in driver
+func openRDSSQLConnection(cmd *cobra.Command, e rdsengine.Engine) *rdsSQLConnection {
+	conn := resolveRDSSQLConnection(cmd, e)
+	var err error
+	conn.DB, err = openRDSDatabase(context.Background(), conn.Engine, conn.Target)
+	if err != nil {
+		if conn.tunnel != nil {
+			conn.tunnel.Close()
+		}
+		exitWithError("failed to connect to database", err)
+	}
+	return conn
+}
+
+// resolveRDSSQLConnection is openRDSSQLConnection without connecting: DB is
+// nil and Target is ready for openRDSDatabase
+func resolveRDSSQLConnection(cmd *cobra.Command, e rdsengine.Engine) *rdsSQLConnection {
+	ctx := context.Background()
+	id := resolveRDSInstanceID(cmd, e)
+	inst, err := e.GetInstance(ctx, id)
+	if err != nil {
+		exitWithError("failed to get instance details", err)
+	}
+	endpoints, _ := e.GetEndpoints(ctx, id)
+
+	username, _ := cmd.Flags().GetString("username")
+	if username == "" {
+		exitWithError("--username (or NHN_CLOUD_RDS_USERNAME) is required", nil)
+	}
+	database, _ := cmd.Flags().GetString("database")
+	caPath, _ := cmd.Flags().GetString("ca-path")
+
+	conn := &rdsSQLConnection{Engine: e.Name(), Instance: inst}
+	host, port := rdsEndpointHost(inst, endpoints), inst.Port
+	serverName := host
+	if viaBastion, _ := cmd.Flags().GetString("via-bastion"); viaBastion != "" {
+		var private []rdsEndpoint
+		for _, ep := range endpoints {
+			private = append(private, rdsEndpoint{Type: ep.Type, Domain: ep.Domain, IPAddress: ep.IPAddress})
+		}
+		privateHost := privateRDSHost(private, inst.Domain, inst.IPAddress)
+		if privateHost == "" {
+			exitWithError(fmt.Sprintf("unable to determine private host for %q", inst.Name), nil)
+		}
+		conn.tunnel, err = openBastionTunnelFromFlags(cmd, privateHost, inst.Port)
+		if err != nil {
+			exitWithError("failed to open bastion tunnel", err)
+		}
+		host, port, serverName = conn.tunnel.LocalHost(), conn.tunnel.LocalPort(), privateHost
+	}
+	if host == "" {
+		exitWithError(fmt.Sprintf("unable to determine endpoint host for %q — enable public access or use --via-bastion", inst.Name), nil)
+	}
+
+	conn.Target = rdsSQLTarget{
+		Host:       host,
+		Port:       port,
+		ServerName: serverName,
+		Username:   username,
+		Password:   rdsSQLPassword(cmd, username),
+		Database:   database,
+		Region:     getRegion(),
+		InstanceID: inst.ID,
+		Version:    inst.Version,
+		CAPath:     caPath,
+	}
+	return conn
+}
@@ -0,0 +1,358 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/haung921209/nhn-cloud-cli/internal/rdsengine"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// ============================================================================
+// rds top - live metrics dashboard
+// ============================================================================
+
+// rdsTopPanel is one row of the dashboard. The measure names reported by the
+// metric API differ slightly between engines, so a panel matches the first
+// metric whose name contains all of the keywords of one of its patterns.
+type rdsTopPanel struct {
+	Key      string
+	Title    string
+	Patterns [][]string
+	Warn     float64
+	Crit     float64
+	Percent  bool
+}
+
+var rdsTopPanels = []rdsTopPanel{
+	{Key: "cpu", Title: "CPU", Patterns: [][]string{{"CPU", "USAGE"}, {"CPU", "UTIL"}, {"CPU"}}, Warn: 70, Crit: 90, Percent: true},
+	{Key: "memory", Title: "Memory", Patterns: [][]string{{"MEMORY", "USAGE"}, {"MEM", "USAGE"}, {"MEMORY"}}, Warn: 80, Crit: 95, Percent: true},
+	{Key: "connections", Title: "Connections", Patterns: [][]string{{"CONNECTION"}, {"THREADS", "CONNECTED"}, {"SESSION"}}},
+	{Key: "qps", Title: "QPS", Patterns: [][]string{{"QPS"}, {"QUERIES"}, {"QUERY"}}},
+	{Key: "replication-lag", Title: "Repl lag", Patterns: [][]string{{"REPLICATION", "DELAY"}, {"REPLICATION", "LAG"}, {"SECONDS", "BEHIND"}}, Warn: 10, Crit: 60},
+	{Key: "storage", Title: "Storage", Patterns: [][]string{{"STORAGE", "USAGE"}, {"DISK", "USAGE"}, {"STORAGE"}}, Warn: 80, Crit: 90, Percent: true},
+}
+
+// rdsTopMetric is the JSON shape of one panel of one instance
+type rdsTopMetric struct {
+	Panel   string    `json:"panel"`
+	Measure string    `json:"measureName,omitempty"`
+	Unit    string    `json:"unit,omitempty"`
+	Latest  *float64  `json:"latest"`
+	Min     float64   `json:"min"`
+	Max     float64   `json:"max"`
+	Avg     float64   `json:"avg"`
+	Level   string    `json:"level"`
+	Values  []float64 `json:"values"`
+}
+
+// rdsTopInstance is the JSON shape of one instance
+type rdsTopInstance struct {
+	ID      string         `json:"dbInstanceId"`
+	Name    string         `json:"dbInstanceName"`
+	Status  string         `json:"dbInstanceStatus"`
+	Metrics []rdsTopMetric `json:"metrics"`
+	Error   string         `json:"error,omitempty"`
+}
+
+func newRDSTopCmd(engineFor func() rdsengine.Engine) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "top",
+		Short: "Live metrics dashboard for DB instances",
+		Long: `Polls the metric API and renders a refreshing dashboard of CPU, memory,
+connections, QPS, replication lag and storage for one or more instances,
+with sparklines over --window. Values over a threshold are shown as WARN or
+CRIT (colored on a terminal). Press Ctrl-C to quit.
+
+Default thresholds (warn:crit): cpu=70:90, memory=80:95, storage=80:90,
+replication-lag=10:60. Override with --threshold <panel>=<warn>:<crit>.
+
+Examples:
+  nhncloud rds --engine mysql top --db-instance-identifier db-a,db-b
+  nhncloud rds-postgresql top --db-instance-identifier mydb --window 1h --refresh 30s
+  nhncloud rds-mysql top --db-instance-identifier mydb --threshold cpu=50:80
+  nhncloud rds-mariadb top --db-instance-identifier mydb --once -o json`,
+		Run: func(cmd *cobra.Command, args []string) {
+			e := engineFor()
+
+			identifiers, _ := cmd.Flags().GetStringSlice("db-instance-identifier")
+			if len(identifiers) == 0 {
+				exitWithError("--db-instance-identifier is required", nil)
+			}
+			window := getDurationFlag(cmd, "window")
+			refresh := getDurationFlag(cmd, "refresh")
+			interval, _ := cmd.Flags().GetInt("interval")
+			width, _ := cmd.Flags().GetInt("width")
+			once, _ := cmd.Flags().GetBool("once")
+			thresholds, _ := cmd.Flags().GetStringArray("threshold")
+
+			panels, err := rdsTopApplyThresholds(rdsTopPanels, thresholds)
+			if err != nil {
+				exitWithError("invalid --threshold", err)
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			ids := make([]string, 0, len(identifiers))
+			for _, identifier := range identifiers {
+				id, err := rdsengine.ResolveInstanceID(ctx, e, strings.TrimSpace(identifier))
+				if err != nil {
+					exitWithError("failed to resolve instance ID", err)
+				}
+				ids = append(ids, id)
+			}
+
+			if once {
+				snapshot := rdsTopCollect(ctx, e, ids, panels, window, interval)
+				if output == "json" {
+					printJSON(snapshot)
+					return
+				}
+				rdsTopRender(os.Stdout, snapshot, panels, width, false)
+				return
+			}
+
+			color := term.IsTerminal(int(os.Stdout.Fd()))
+			fmt.Print("\033[?25l")
+			defer fmt.Print("\033[?25h")
+			for {
+				snapshot := rdsTopCollect(ctx, e, ids, panels, window, interval)
+				if ctx.Err() != nil {
+					return
+				}
+				fmt.Print("\033[H\033[2J")
+				fmt.Printf("%s top — %s (window %s, refresh %s, Ctrl-C to quit)\n\n",
+					e.DisplayName(), time.Now().Format("2006-01-02 15:04:05"), window, refresh)
+				rdsTopRender(os.Stdout, snapshot, panels, width, color)
+
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(refresh):
+				}
+			}
+		},
+	}
+	c.Flags().StringSlice("db-instance-identifier", nil, "DB instance identifiers (names or IDs, comma-separated)")
+	c.Flags().String("window", "30m", "History shown in sparklines (Go duration)")
+	c.Flags().String("refresh", "30s", "Refresh interval (Go duration)")
+	c.Flags().Int("interval", 60, "Metric interval in seconds")
+	c.Flags().Int("width", 30, "Sparkline width in points")
+	c.Flags().Bool("once", false, "Print a single snapshot and exit (use with -o json for scripting)")
+	c.Flags().StringArray("threshold", nil, "Override a threshold: <panel>=<warn>:<crit> (repeatable)")
+	return c
+}
+
+// rdsTopApplyThresholds returns a copy of panels with --threshold overrides
+func rdsTopApplyThresholds(panels []rdsTopPanel, overrides []string) ([]rdsTopPanel, error) {
+	out := make([]rdsTopPanel, len(panels))
+	copy(out, panels)
+	for _, o := range overrides {
+		key, value, ok := strings.Cut(o, "=")
+		warnStr, critStr, ok2 := strings.Cut(value, ":")
+		if !ok || !ok2 {
+			return nil, fmt.Errorf("'%s' is not <panel>=<warn>:<crit>", o)
+		}
+		warn, err := strconv.ParseFloat(warnStr, 64)
+		if err != nil {
+			return nil, fmt.Errorf("'%s': invalid warn value", o)
+		}
+		crit, err := strconv.ParseFloat(critStr, 64)
+		if err != nil {
+			return nil, fmt.Errorf("'%s': invalid crit value", o)
+		}
+		found := false
+		for i := range out {
+			if out[i].Key == key {
+				out[i].Warn, out[i].Crit = warn, crit
+				found = true
+			}
+		}
+		if !found {
+			keys := make([]string, 0, len(out))
+			for _, p := range out {
+				keys = append(keys, p.Key)
+			}
+			return nil, fmt.Errorf("unknown panel '%s' (%s)", key, strings.Join(keys, ", "))
+		}
+	}
+	return out, nil
+}
+
+// rdsTopCollect fetches status and metrics of every instance concurrently
+func rdsTopCollect(ctx context.Context, e rdsengine.Engine, ids []string, panels []rdsTopPanel, window time.Duration, interval int) []rdsTopInstance {
+	to := time.Now().UTC()
+	from := to.Add(-window)
+
+	result := make([]rdsTopInstance, len(ids))
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		wg.Add(1)
+		go func(i int, id string) {
+			defer wg.Done()
+			out := rdsTopInstance{ID: id}
+			if inst, err := e.GetInstance(ctx, id); err == nil {
+				out.Name = inst.Name
+				out.Status = inst.Status
+			}
+			series, err := e.GetMetricStatistics(ctx, id, from.Format(time.RFC3339), to.Format(time.RFC3339), interval)
+			if err != nil {
+				out.Error = err.Error()
+			}
+			for _, p := range panels {
+				out.Metrics = append(out.Metrics, rdsTopSummarize(p, rdsTopMatch(p, series)))
+			}
+			result[i] = out
+		}(i, id)
+	}
+	wg.Wait()
+	return result
+}
+
+// rdsTopMatch returns the series a panel displays, or nil
+func rdsTopMatch(p rdsTopPanel, series []rdsengine.MetricSeries) *rdsengine.MetricSeries {
+	for _, pattern := range p.Patterns {
+		for i := range series {
+			name := strings.ToUpper(series[i].Name)
+			matched := true
+			for _, keyword := range pattern {
+				if !strings.Contains(name, keyword) {
+					matched = false
+					break
+				}
+			}
+			if matched {
+				return &series[i]
+			}
+		}
+	}
+	return nil
+}
+
+func rdsTopSummarize(p rdsTopPanel, s *rdsengine.MetricSeries) rdsTopMetric {
+	m := rdsTopMetric{Panel: p.Key, Level: "n/a", Values: []float64{}}
+	if s == nil {
+		return m
+	}
+	m.Measure = s.Name
+	m.Unit = s.Unit
+
+	points := make([]rdsengine.MetricPoint, len(s.Points))
+	copy(points, s.Points)
+	sort.SliceStable(points, func(i, j int) bool { return points[i].Timestamp < points[j].Timestamp })
+	if len(points) == 0 {
+		return m
+	}
+
+	m.Min, m.Max = math.Inf(1), math.Inf(-1)
+	sum := 0.0
+	for _, pt := range points {
+		m.Values = append(m.Values, pt.Value)
+		m.Min = math.Min(m.Min, pt.Value)
+		m.Max = math.Max(m.Max, pt.Value)
+		sum += pt.Value
+	}
+	m.Avg = sum / float64(len(points))
+	latest := points[len(points)-1].Value
+	m.Latest = &latest
+	m.Level = rdsTopLevel(p, latest)
+	return m
+}
+
+// rdsTopLevel classifies a value against the panel thresholds
+func rdsTopLevel(p rdsTopPanel, v float64) string {
+	switch {
+	case p.Crit > 0 && v >= p.Crit:
+		return "CRIT"
+	case p.Warn > 0 && v >= p.Warn:
+		return "WARN"
+	}
+	return "OK"
+}
+
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// sparkline renders the last width values. Percent panels are scaled to
+// 0..100 so that lines of different instances are comparable.
+func sparkline(values []float64, width int, percent bool) string {
+	if len(values) > width {
+		values = values[len(values)-width:]
+	}
+	if len(values) == 0 {
+		return ""
+	}
+
+	lo, hi := 0.0, 100.0
+	if !percent {
+		lo, hi = values[0], values[0]
+		for _, v := range values {
+			lo = math.Min(lo, v)
+			hi = math.Max(hi, v)
+		}
+	}
+
+	var b strings.Builder
+	for _, v := range values {
+		idx := 0
+		if hi > lo {
+			idx = int((v - lo) / (hi - lo) * float64(len(sparkBlocks)-1))
+		}
+		idx = max(0, min(idx, len(sparkBlocks)-1))
+		b.WriteRune(sparkBlocks[idx])
+	}
+	return b.String()
+}
+
+func rdsTopRender(w *os.File, snapshot []rdsTopInstance, panels []rdsTopPanel, width int, color bool) {
+	colorize := func(level, s string) string {
+		if !color {
+			return s
+		}
+		switch level {
+		case "CRIT":
+			return "\033[31m" + s + "\033[0m"
+		case "WARN":
+			return "\033[33m" + s + "\033[0m"
+		}
+		return s
+	}
+
+	for _, inst := range snapshot {
+		fmt.Fprintf(w, "%s (%s)  %s\n", firstNonEmpty(inst.Name, inst.ID), inst.ID, inst.Status)
+		if inst.Error != "" {
+			fmt.Fprintf(w, "  error: %s\n\n", inst.Error)
+			continue
+		}
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		for i, m := range inst.Metrics {
+			if m.Latest == nil {
+				fmt.Fprintf(tw, "  %s\t-\t\t\n", panels[i].Title)
+				continue
+			}
+			value := strconv.FormatFloat(*m.Latest, 'f', 2, 64)
+			if m.Unit != "" {
+				value += " " + m.Unit
+			}
+			level := m.Level
+			if level == "OK" {
+				level = ""
+			}
+			fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\n", panels[i].Title, value,
+				sparkline(m.Values, width, panels[i].Percent), colorize(m.Level, level))
+		}
+		tw.Flush()
+		fmt.Fprintln(w)
+	}
+}
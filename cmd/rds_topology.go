@@ -0,0 +1,270 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/haung921209/nhn-cloud-cli/internal/rdsengine"
+	"github.com/haung921209/nhn-cloud-sdk-go/nhncloud/core"
+	"github.com/spf13/cobra"
+)
+
+// ============================================================================
+// Replication topology
+// ============================================================================
+
+// A master, its high availability standby and its read replicas share an
+// instance group; the SDK instance types do not carry the group ID, so it is
+// read from the raw instance list.
+
+type rdsGroupMember struct {
+	ID      string `json:"dbInstanceId"`
+	GroupID string `json:"dbInstanceGroupId"`
+	Type    string `json:"dbInstanceType"`
+}
+
+type rdsGroupMemberList struct {
+	Header      core.ResponseHeader `json:"header"`
+	DBInstances []rdsGroupMember    `json:"dbInstances"`
+}
+
+// GetHeader implements core.WithHeader
+func (r *rdsGroupMemberList) GetHeader() *core.ResponseHeader {
+	return &r.Header
+}
+
+// rdsTopologyNode is one instance of the topology; replicas are nested under
+// their source
+type rdsTopologyNode struct {
+	ID               string             `json:"dbInstanceId" yaml:"dbInstanceId"`
+	Name             string             `json:"dbInstanceName" yaml:"dbInstanceName"`
+	Role             string             `json:"role" yaml:"role"`
+	Type             string             `json:"dbInstanceType,omitempty" yaml:"dbInstanceType,omitempty"`
+	Status           string             `json:"dbInstanceStatus" yaml:"dbInstanceStatus"`
+	AvailabilityZone string             `json:"availabilityZone,omitempty" yaml:"availabilityZone,omitempty"`
+	GroupID          string             `json:"dbInstanceGroupId,omitempty" yaml:"dbInstanceGroupId,omitempty"`
+	ReplicationState string             `json:"replicationState,omitempty" yaml:"replicationState,omitempty"`
+	ReplicationLag   *float64           `json:"replicationLag,omitempty" yaml:"replicationLag,omitempty"`
+	LagUnit          string             `json:"replicationLagUnit,omitempty" yaml:"replicationLagUnit,omitempty"`
+	Replicas         []*rdsTopologyNode `json:"replicas,omitempty" yaml:"replicas,omitempty"`
+}
+
+// rdsTopologyRole maps an instance type to its role in the group
+func rdsTopologyRole(instanceType string) string {
+	switch strings.ToUpper(instanceType) {
+	case "MASTER":
+		return "master"
+	case "CANDIDATE_MASTER":
+		return "ha-standby"
+	case "READ_ONLY_SLAVE":
+		return "read-replica"
+	case "FAILED_MASTER":
+		return "failed-master"
+	case "":
+		return "standalone"
+	}
+	return strings.ToLower(instanceType)
+}
+
+// rdsReplicationState reads the replication state of a standby or replica
+// from its instance status: the service reports stopped replication as
+// REPLICATION_STOP, and an AVAILABLE replica is replicating
+func rdsReplicationState(status string) string {
+	switch strings.ToUpper(status) {
+	case "REPLICATION_STOP":
+		return "stopped"
+	case "AVAILABLE":
+		return "running"
+	}
+	return ""
+}
+
+// buildRDSTopology groups instances under the master of their instance
+// group. Groups without a master are rooted at their first member.
+func buildRDSTopology(instances []rdsengine.Instance, members map[string]rdsGroupMember) []*rdsTopologyNode {
+	groups := map[string][]*rdsTopologyNode{}
+	var roots []*rdsTopologyNode
+	for _, inst := range instances {
+		m := members[inst.ID]
+		node := &rdsTopologyNode{
+			ID:               inst.ID,
+			Name:             inst.Name,
+			Type:             firstNonEmpty(m.Type, inst.Type),
+			Status:           inst.Status,
+			AvailabilityZone: inst.AvailabilityZone,
+			GroupID:          m.GroupID,
+		}
+		node.Role = rdsTopologyRole(node.Type)
+		if m.GroupID == "" {
+			node.Role = "standalone"
+			roots = append(roots, node)
+			continue
+		}
+		groups[m.GroupID] = append(groups[m.GroupID], node)
+	}
+
+	for _, nodes := range groups {
+		sort.SliceStable(nodes, func(i, j int) bool {
+			return rdsTopologyRank(nodes[i].Role) < rdsTopologyRank(nodes[j].Role) ||
+				(rdsTopologyRank(nodes[i].Role) == rdsTopologyRank(nodes[j].Role) && nodes[i].Name < nodes[j].Name)
+		})
+		root := nodes[0]
+		if len(nodes) == 1 && root.Role == "master" {
+			root.Role = "standalone"
+		}
+		root.Replicas = nodes[1:]
+		for _, r := range root.Replicas {
+			r.ReplicationState = rdsReplicationState(r.Status)
+		}
+		roots = append(roots, root)
+	}
+	sort.SliceStable(roots, func(i, j int) bool { return roots[i].Name < roots[j].Name })
+	return roots
+}
+
+func rdsTopologyRank(role string) int {
+	switch role {
+	case "master":
+		return 0
+	case "failed-master":
+		return 1
+	case "ha-standby":
+		return 2
+	}
+	return 3
+}
+
+func renderRDSTopology(nodes []*rdsTopologyNode) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tROLE\tAZ\tSTATUS\tREPLICATION\tREPL_LAG\tID")
+	var walk func(n *rdsTopologyNode, prefix, branch string)
+	walk = func(n *rdsTopologyNode, prefix, branch string) {
+		lag := "-"
+		if n.ReplicationLag != nil {
+			lag = strings.TrimSpace(fmt.Sprintf("%.1f %s", *n.ReplicationLag, n.LagUnit))
+		}
+		fmt.Fprintf(w, "%s%s%s\t%s\t%s\t%s\t%s\t%s\t%s\n", prefix, branch, n.Name, n.Role,
+			firstNonEmpty(n.AvailabilityZone, "-"), n.Status, firstNonEmpty(n.ReplicationState, "-"), lag, n.ID)
+		childPrefix := prefix
+		switch branch {
+		case "├─ ":
+			childPrefix += "│  "
+		case "└─ ":
+			childPrefix += "   "
+		}
+		for i, c := range n.Replicas {
+			b := "├─ "
+			if i == len(n.Replicas)-1 {
+				b = "└─ "
+			}
+			walk(c, childPrefix, b)
+		}
+	}
+	for _, n := range nodes {
+		walk(n, "", "")
+	}
+	w.Flush()
+}
+
+func newRDSTopologyCmd(engineFor func() rdsengine.Engine) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "describe-replication-topology",
+		Short: "Show masters with their HA standbys and read replicas",
+		Long: `Groups DB instances by replication source: each master is shown with its
+high availability standby and read replicas, their availability zones and
+status. Standbys and replicas also show their replication state (stopped
+when the service reports REPLICATION_STOP, running when AVAILABLE) and the
+latest replication lag from the metric API.
+
+Table output is a tree; json/yaml output nests replicas under their
+source.
+
+Examples:
+  nhncloud rds-mysql describe-replication-topology
+  nhncloud rds --engine postgresql describe-replication-topology --db-instance-identifier my-pg -o json`,
+		Run: func(cmd *cobra.Command, args []string) {
+			e := engineFor()
+			ctx := context.Background()
+			noLag, _ := cmd.Flags().GetBool("no-lag")
+
+			instances, err := e.ListInstances(ctx)
+			if err != nil {
+				exitWithError("failed to list instances", err)
+			}
+			var list rdsGroupMemberList
+			if err := newRDSAPI(e.Name()).Do(ctx, "GET", "/db-instances", nil, &list); err != nil {
+				exitWithError("failed to list instance groups", err)
+			}
+			members := map[string]rdsGroupMember{}
+			for _, m := range list.DBInstances {
+				members[m.ID] = m
+			}
+			for i := range instances {
+				if instances[i].AvailabilityZone != "" {
+					continue
+				}
+				if inst, err := e.GetInstance(ctx, instances[i].ID); err == nil {
+					instances[i].AvailabilityZone = inst.AvailabilityZone
+				}
+			}
+
+			roots := buildRDSTopology(instances, members)
+			if identifier, _ := cmd.Flags().GetString("db-instance-identifier"); identifier != "" {
+				id := resolveRDSInstanceID(cmd, e)
+				var selected []*rdsTopologyNode
+				for _, r := range roots {
+					if r.ID == id {
+						selected = append(selected, r)
+						continue
+					}
+					for _, c := range r.Replicas {
+						if c.ID == id {
+							selected = append(selected, r)
+							break
+						}
+					}
+				}
+				roots = selected
+			}
+
+			if !noLag {
+				var replicas []*rdsTopologyNode
+				for _, r := range roots {
+					replicas = append(replicas, r.Replicas...)
+				}
+				ids := make([]string, len(replicas))
+				for i, r := range replicas {
+					ids[i] = r.ID
+				}
+				var lagPanel rdsTopPanel
+				for _, p := range rdsTopPanels {
+					if p.Key == "replication-lag" {
+						lagPanel = p
+					}
+				}
+				for i, inst := range rdsTopCollect(ctx, e, ids, []rdsTopPanel{lagPanel}, 10*time.Minute, 60) {
+					if len(inst.Metrics) > 0 && inst.Metrics[0].Latest != nil {
+						replicas[i].ReplicationLag = inst.Metrics[0].Latest
+						replicas[i].LagUnit = inst.Metrics[0].Unit
+					}
+				}
+			}
+
+			if output == "json" || output == "yaml" {
+				if err := printOutput(roots); err != nil {
+					exitWithError("failed to print topology", err)
+				}
+				return
+			}
+			renderRDSTopology(roots)
+		},
+	}
+	c.Flags().String("db-instance-identifier", "", "Only show the group of this instance (name or ID)")
+	c.Flags().Bool("no-lag", false, "Skip the replication lag lookup")
+	return c
+}
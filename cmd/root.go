@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -14,6 +15,7 @@ var (
 	debug    bool
 	output   string
 	query    string
+	filters  []string
 	username string
 	password string
 	tenantID string
@@ -52,6 +54,7 @@ func init() {
 	rootCmd.PersistentFlags().BoolVar(&debug, "debug", false, "Enable debug output")
 	rootCmd.PersistentFlags().StringVarP(&output, "output", "o", "table", "Output format (table, json, yaml)")
 	rootCmd.PersistentFlags().StringVar(&query, "query", "", "JMESPath query to filter output")
+	rootCmd.PersistentFlags().StringArrayVar(&filters, "filter", nil, "Filter list results: field=value, field~regex, field>value (repeatable; see 'help' of list commands)")
 
 	rootCmd.PersistentFlags().StringVar(&username, "username", os.Getenv("NHN_CLOUD_USERNAME"), "API username (for Compute/Network)")
 	rootCmd.PersistentFlags().StringVar(&password, "password", os.Getenv("NHN_CLOUD_PASSWORD"), "API password (for Compute/Network)")
@@ -59,6 +62,17 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&profile, "profile", os.Getenv("NHN_CLOUD_PROFILE"), "Use a specific profile from your credential file")
 }
 
+// getDurationFlag parses a Go duration flag (e.g. 30s, 10m, 1h), exiting on
+// invalid input
+func getDurationFlag(cmd *cobra.Command, name string) time.Duration {
+	value, _ := cmd.Flags().GetString(name)
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		exitWithError(fmt.Sprintf("invalid --%s %q", name, value), err)
+	}
+	return d
+}
+
 func getRegion() string {
 	cfg := LoadConfig()
 	if region != "" {
@@ -1,13 +1,17 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/haung921209/nhn-cloud-cli/internal/sshkeys"
+	"github.com/haung921209/nhn-cloud-sdk-go/nhncloud/compute"
 	"github.com/spf13/cobra"
 )
 
@@ -18,6 +22,11 @@ func init() {
 	sshKeysCmd.AddCommand(sshDeleteKeyCmd)
 	sshKeysCmd.AddCommand(sshExportKeyCmd)
 	sshKeysCmd.AddCommand(sshUseKeyCmd)
+	sshKeysCmd.AddCommand(sshRotateKeyCmd)
+
+	sshRotateKeyCmd.Flags().String("type", "", "Type of the new key: ed25519 or rsa (default: same as the current key)")
+	sshRotateKeyCmd.Flags().String("new-name", "", "Name of the new key and keypair (default: <key-name>-<timestamp>)")
+	sshRotateKeyCmd.Flags().String("key-pair", "", "Compute keypair name of the current key (default: <key-name>)")
 }
 
 var sshDescribeKeysCmd = &cobra.Command{
@@ -197,3 +206,105 @@ Examples:
 		}
 	},
 }
+
+var sshRotateKeyCmd = &cobra.Command{
+	Use:   "rotate <key-name>",
+	Short: "Replace a stored key with a new one and register it as a keypair",
+	Long: `Generates a new key in the store, registers its public key as a compute
+keypair, and lists the instances that were launched with the old keypair.
+
+Instances keep the key they were launched with. Add the new public key to
+their authorized_keys (for example with 'compute ssh-exec') before deleting the
+old keypair; the old key is left in place.
+
+Examples:
+  nhncloud ssh-keys rotate deploy
+  nhncloud ssh-keys rotate deploy --type rsa --new-name deploy-2026`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		oldName := args[0]
+		keyType, _ := cmd.Flags().GetString("type")
+		newName, _ := cmd.Flags().GetString("new-name")
+		oldKeyPair, _ := cmd.Flags().GetString("key-pair")
+		if oldKeyPair == "" {
+			oldKeyPair = oldName
+		}
+		if newName == "" {
+			newName = oldName + "-" + time.Now().Format("20060102-150405")
+		}
+
+		manager := sshkeys.NewManager()
+		oldPublicKey, err := manager.PublicKey(oldName)
+		if err != nil {
+			exitWithError("Failed to read current SSH key", err)
+		}
+		if keyType == "" {
+			keyType = "ed25519"
+			if strings.HasPrefix(oldPublicKey, "ssh-rsa ") {
+				keyType = "rsa"
+			}
+		}
+
+		newKey, err := manager.Generate(newName, keyType)
+		if err != nil {
+			exitWithError("Failed to generate SSH key", err)
+		}
+
+		client := getComputeClient()
+		ctx := context.Background()
+		keyPair, err := client.CreateKeyPair(ctx, &compute.CreateKeyPairInput{
+			Name:      newName,
+			PublicKey: newKey.PublicKey,
+		})
+		if err != nil {
+			manager.Remove(newName)
+			exitWithError("Failed to register keypair", err)
+		}
+
+		servers, err := client.ListServers(ctx)
+		if err != nil {
+			exitWithError("Keypair registered, but failed to list instances", err)
+		}
+		var stale []compute.Server
+		for _, s := range servers.Servers {
+			if s.KeyName == oldKeyPair {
+				stale = append(stale, s)
+			}
+		}
+
+		if output == "json" {
+			type staleInstance struct {
+				ID     string `json:"id"`
+				Name   string `json:"name"`
+				Status string `json:"status"`
+			}
+			instances := []staleInstance{}
+			for _, s := range stale {
+				instances = append(instances, staleInstance{ID: s.ID, Name: s.Name, Status: s.Status})
+			}
+			printJSON(map[string]interface{}{
+				"oldKey":               oldName,
+				"oldKeyPair":           oldKeyPair,
+				"newKey":               newKey,
+				"keyPair":              keyPair.KeyPair,
+				"instancesUsingOldKey": instances,
+			})
+			return
+		}
+
+		fmt.Printf("New %s key '%s' saved to %s\n", keyType, newKey.Name, newKey.Path)
+		fmt.Printf("Keypair registered: %s (%s)\n", keyPair.KeyPair.Name, keyPair.KeyPair.Fingerprint)
+
+		if len(stale) == 0 {
+			fmt.Printf("\nNo instances use keypair '%s'.\n", oldKeyPair)
+			return
+		}
+		fmt.Printf("\nInstances still using keypair '%s':\n", oldKeyPair)
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tNAME\tSTATUS")
+		for _, s := range stale {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", s.ID, s.Name, s.Status)
+		}
+		w.Flush()
+	},
+}
@@ -2,6 +2,8 @@ package cert
 
 import (
 	"fmt"
+	"net"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
@@ -90,10 +92,11 @@ func (h *Helper) validateCertificateFile(certPath string) error {
 	return nil
 }
 
-// GenerateConnectionString builds a connection string with SSL/TLS certificate if available
-// This is a helper for database CLI tools that need certificate configuration
+// GenerateConnectionString builds the standard connection URI (MySQL Shell,
+// psql) for a database. TLS is always required; with a CA certificate the
+// server is verified, including its host name unless host is an IP address.
+// The returned params are the TLS query parameters of the URI.
 func (h *Helper) GenerateConnectionString(serviceType, host, port, database, username, password, region, instanceID, version string, autoFind bool, explicitPath string) (string, map[string]string, error) {
-	var connectionString string
 	params := make(map[string]string)
 
 	// Get certificate path if available
@@ -101,41 +104,49 @@ func (h *Helper) GenerateConnectionString(serviceType, host, port, database, use
 	if err != nil {
 		return "", nil, fmt.Errorf("certificate error: %w", err)
 	}
+	verifyHost := certPath != "" && net.ParseIP(host) == nil
 
 	// Build connection string based on service type
+	var scheme string
 	switch serviceType {
-	case "mysql":
-		connectionString = fmt.Sprintf("mysql://%s:%s@%s:%s/%s", username, password, host, port, database)
+	case "mysql", "mariadb", "rds-mysql", "rds-mariadb":
+		scheme = "mysql"
+		params["ssl-mode"] = "REQUIRED"
 		if certPath != "" {
-			params["sslmode"] = "required"
-			params["sslcert"] = certPath
-			params["sslrootcert"] = certPath
-			connectionString += "?sslmode=required"
+			params["ssl-mode"] = "VERIFY_CA"
+			if verifyHost {
+				params["ssl-mode"] = "VERIFY_IDENTITY"
+			}
+			params["ssl-ca"] = certPath
 		}
 
-	case "mariadb":
-		connectionString = fmt.Sprintf("mysql://%s:%s@%s:%s/%s", username, password, host, port, database)
-		if certPath != "" {
-			params["sslmode"] = "required"
-			params["sslcert"] = certPath
-			params["sslrootcert"] = certPath
-			connectionString += "?sslmode=required"
-		}
-
-	case "postgresql":
-		connectionString = fmt.Sprintf("postgresql://%s:%s@%s:%s/%s", username, password, host, port, database)
+	case "postgresql", "rds-postgresql":
+		scheme = "postgresql"
+		params["sslmode"] = "require"
 		if certPath != "" {
-			params["sslmode"] = "require"
-			params["sslcert"] = certPath
+			params["sslmode"] = "verify-ca"
+			if verifyHost {
+				params["sslmode"] = "verify-full"
+			}
 			params["sslrootcert"] = certPath
-			connectionString += "?sslmode=require"
 		}
 
 	default:
 		return "", nil, fmt.Errorf("unsupported service type: %s", serviceType)
 	}
 
-	return connectionString, params, nil
+	query := url.Values{}
+	for k, v := range params {
+		query.Set(k, v)
+	}
+	u := url.URL{
+		Scheme:   scheme,
+		User:     url.UserPassword(username, password),
+		Host:     net.JoinHostPort(host, port),
+		Path:     "/" + database,
+		RawQuery: query.Encode(),
+	}
+	return u.String(), params, nil
 }
 
 // GetConnectionCommand generates the appropriate CLI command for connecting to a database
@@ -0,0 +1,318 @@
+package launchtemplates
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	templatesDir = ".nhncloud/launch-templates"
+
+	// highWaterFile records the highest version ever created per template,
+	// so numbers freed by deletes are never handed out again
+	highWaterFile = "versions.yaml"
+)
+
+var (
+	validName   = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+	versionFile = regexp.MustCompile(`^v([0-9]+)\.yaml$`)
+)
+
+// Spec holds the create-instance settings captured by a launch template.
+// Keys match the create-instance flag names.
+type Spec struct {
+	ImageID          string            `yaml:"image-id,omitempty" json:"imageId,omitempty"`
+	FlavorID         string            `yaml:"flavor-id,omitempty" json:"flavorId,omitempty"`
+	SubnetID         string            `yaml:"subnet-id,omitempty" json:"subnetId,omitempty"`
+	KeyName          string            `yaml:"key-name,omitempty" json:"keyName,omitempty"`
+	SecurityGroupIDs []string          `yaml:"security-group-ids,omitempty" json:"securityGroupIds,omitempty"`
+	AvailabilityZone string            `yaml:"availability-zone,omitempty" json:"availabilityZone,omitempty"`
+	BootVolumeSize   int               `yaml:"boot-volume-size,omitempty" json:"bootVolumeSize,omitempty"`
+	UserData         string            `yaml:"user-data,omitempty" json:"userData,omitempty"`
+	Metadata         map[string]string `yaml:"metadata,omitempty" json:"metadata,omitempty"`
+	BlockDevices     []string          `yaml:"block-devices,omitempty" json:"blockDevices,omitempty"`
+}
+
+// Version is one immutable revision of a launch template
+type Version struct {
+	Name        string    `yaml:"name" json:"name"`
+	Version     int       `yaml:"version" json:"version"`
+	Description string    `yaml:"description,omitempty" json:"description,omitempty"`
+	CreatedAt   time.Time `yaml:"created_at" json:"createdAt"`
+	Spec        Spec      `yaml:"spec" json:"spec"`
+}
+
+// Summary describes a template and its latest version
+type Summary struct {
+	Name          string    `json:"name"`
+	LatestVersion int       `json:"latestVersion"`
+	Versions      int       `json:"versions"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
+
+// Store keeps launch templates under ~/.nhncloud/launch-templates, one
+// directory per template and one YAML file per version
+type Store struct {
+	baseDir string
+}
+
+func NewStore() *Store {
+	home, _ := os.UserHomeDir()
+	return &Store{
+		baseDir: filepath.Join(home, templatesDir),
+	}
+}
+
+// LoadSpecFile reads a template spec from YAML. A user-data value of the
+// form file://path is read relative to the spec file and embedded, so that
+// stored versions do not depend on files that may later change.
+func LoadSpecFile(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template file: %w", err)
+	}
+
+	var spec Spec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse template file: %w", err)
+	}
+
+	if strings.HasPrefix(spec.UserData, "file://") {
+		userDataPath := strings.TrimPrefix(spec.UserData, "file://")
+		if !filepath.IsAbs(userDataPath) {
+			userDataPath = filepath.Join(filepath.Dir(path), userDataPath)
+		}
+		content, err := os.ReadFile(userDataPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read user data: %w", err)
+		}
+		spec.UserData = string(content)
+	}
+
+	return &spec, nil
+}
+
+// Create stores spec as the next version of the named template
+func (s *Store) Create(name, description string, spec Spec) (*Version, error) {
+	if !validName.MatchString(name) {
+		return nil, fmt.Errorf("invalid template name '%s' (letters, digits, '.', '_' and '-' only)", name)
+	}
+
+	dir := filepath.Join(s.baseDir, name)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	versions, err := s.versionNumbers(name)
+	if err != nil {
+		return nil, err
+	}
+	highWater, err := s.loadHighWater()
+	if err != nil {
+		return nil, err
+	}
+	next := highWater[name] + 1
+	if len(versions) > 0 && versions[len(versions)-1] >= next {
+		next = versions[len(versions)-1] + 1
+	}
+
+	v := &Version{
+		Name:        name,
+		Version:     next,
+		Description: description,
+		CreatedAt:   time.Now(),
+		Spec:        spec,
+	}
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("v%d.yaml", next)), data, 0600); err != nil {
+		return nil, fmt.Errorf("failed to save template: %w", err)
+	}
+
+	highWater[name] = next
+	if err := s.saveHighWater(highWater); err != nil {
+		return nil, fmt.Errorf("failed to save template: %w", err)
+	}
+	return v, nil
+}
+
+// Get loads a template by reference "name", "name:3" or "name:v3". Without
+// a version the latest one is returned.
+func (s *Store) Get(ref string) (*Version, error) {
+	name, versionStr, hasVersion := strings.Cut(ref, ":")
+
+	versions, err := s.versionNumbers(name)
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("launch template '%s' not found", name)
+	}
+
+	version := versions[len(versions)-1]
+	if hasVersion {
+		version, err = strconv.Atoi(strings.TrimPrefix(versionStr, "v"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid template version '%s'", versionStr)
+		}
+	}
+
+	return s.load(name, version)
+}
+
+// List returns a summary of every stored template
+func (s *Store) List() ([]Summary, error) {
+	entries, err := os.ReadDir(s.baseDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []Summary{}, nil
+		}
+		return nil, err
+	}
+
+	summaries := []Summary{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		versions, err := s.versionNumbers(entry.Name())
+		if err != nil || len(versions) == 0 {
+			continue
+		}
+		latest, err := s.load(entry.Name(), versions[len(versions)-1])
+		if err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, Summary{
+			Name:          entry.Name(),
+			LatestVersion: latest.Version,
+			Versions:      len(versions),
+			UpdatedAt:     latest.CreatedAt,
+		})
+	}
+	return summaries, nil
+}
+
+// Versions returns every version of a template, oldest first
+func (s *Store) Versions(name string) ([]Version, error) {
+	numbers, err := s.versionNumbers(name)
+	if err != nil {
+		return nil, err
+	}
+	if len(numbers) == 0 {
+		return nil, fmt.Errorf("launch template '%s' not found", name)
+	}
+
+	var versions []Version
+	for _, n := range numbers {
+		v, err := s.load(name, n)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, *v)
+	}
+	return versions, nil
+}
+
+// Delete removes one version, or the whole template when version is 0.
+// Deleted version numbers are not reused by later creates.
+func (s *Store) Delete(name string, version int) error {
+	if !validName.MatchString(name) {
+		return fmt.Errorf("invalid template name '%s'", name)
+	}
+
+	dir := filepath.Join(s.baseDir, name)
+	if version == 0 {
+		if _, err := os.Stat(dir); os.IsNotExist(err) {
+			return fmt.Errorf("launch template '%s' not found", name)
+		}
+		return os.RemoveAll(dir)
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("v%d.yaml", version))
+	if err := os.Remove(path); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("launch template '%s' version %d not found", name, version)
+		}
+		return err
+	}
+
+	// Drop the directory once the last version is gone
+	if versions, _ := s.versionNumbers(name); len(versions) == 0 {
+		os.Remove(dir)
+	}
+	return nil
+}
+
+func (s *Store) load(name string, version int) (*Version, error) {
+	data, err := os.ReadFile(filepath.Join(s.baseDir, name, fmt.Sprintf("v%d.yaml", version)))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("launch template '%s' version %d not found", name, version)
+		}
+		return nil, err
+	}
+
+	var v Version
+	if err := yaml.Unmarshal(data, &v); err != nil {
+		return nil, fmt.Errorf("failed to parse launch template '%s' version %d: %w", name, version, err)
+	}
+	return &v, nil
+}
+
+func (s *Store) loadHighWater() (map[string]int, error) {
+	highWater := map[string]int{}
+	data, err := os.ReadFile(filepath.Join(s.baseDir, highWaterFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return highWater, nil
+		}
+		return nil, err
+	}
+	if err := yaml.Unmarshal(data, &highWater); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", highWaterFile, err)
+	}
+	return highWater, nil
+}
+
+func (s *Store) saveHighWater(highWater map[string]int) error {
+	data, err := yaml.Marshal(highWater)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(s.baseDir, highWaterFile), data, 0600)
+}
+
+func (s *Store) versionNumbers(name string) ([]int, error) {
+	if !validName.MatchString(name) {
+		return nil, fmt.Errorf("invalid template name '%s'", name)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(s.baseDir, name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var versions []int
+	for _, entry := range entries {
+		if m := versionFile.FindStringSubmatch(entry.Name()); m != nil {
+			n, _ := strconv.Atoi(m[1])
+			versions = append(versions, n)
+		}
+	}
+	sort.Ints(versions)
+	return versions, nil
+}
@@ -0,0 +1,168 @@
+// Package pghba models RDS for PostgreSQL HBA rules by database and user
+// name, matches them against a connection the way PostgreSQL reads
+// pg_hba.conf (top to bottom, first match wins), and loads rule files.
+package pghba
+
+import (
+	"fmt"
+	"net/netip"
+	"os"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Connection types of an HBA rule
+const (
+	Host      = "HOST"
+	HostSSL   = "HOSTSSL"
+	HostNoSSL = "HOSTNOSSL"
+)
+
+// Spec is an HBA rule file; rules are listed in match order.
+//
+//	rules:
+//	  - connectionType: HOSTSSL
+//	    databases: [app]
+//	    users: [app]
+//	    address: 10.0.0.0/16
+//	    authMethod: SCRAM_SHA_256
+type Spec struct {
+	Rules []Rule `yaml:"rules" json:"rules"`
+}
+
+// Rule is one HBA rule. Empty Databases or Users match every database or
+// user.
+type Rule struct {
+	ID             string   `yaml:"-" json:"hbaRuleId,omitempty"`
+	ConnectionType string   `yaml:"connectionType,omitempty" json:"connectionType"`
+	Databases      []string `yaml:"databases,omitempty" json:"databases,omitempty"`
+	Users          []string `yaml:"users,omitempty" json:"users,omitempty"`
+	Address        string   `yaml:"address" json:"address"`
+	AuthMethod     string   `yaml:"authMethod" json:"authMethod"`
+}
+
+// Connection is a client connection attempt
+type Connection struct {
+	User     string
+	Database string
+	Address  netip.Addr
+	SSL      bool
+}
+
+// Load reads and normalizes a rule file
+func Load(file string) (*Spec, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var spec Spec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", file, err)
+	}
+	if len(spec.Rules) == 0 {
+		return nil, fmt.Errorf("%s: no rules", file)
+	}
+	for i := range spec.Rules {
+		r := &spec.Rules[i]
+		r.Normalize()
+		if r.AuthMethod == "" {
+			return nil, fmt.Errorf("%s: rules[%d] needs authMethod", file, i)
+		}
+		switch r.ConnectionType {
+		case Host, HostSSL, HostNoSSL:
+		default:
+			return nil, fmt.Errorf("%s: rules[%d]: unknown connectionType %s", file, i, r.ConnectionType)
+		}
+		if _, err := ParsePrefix(r.Address); err != nil {
+			return nil, fmt.Errorf("%s: rules[%d]: %w", file, i, err)
+		}
+	}
+	return &spec, nil
+}
+
+// Normalize upper-cases the enums, defaults the connection type to HOST,
+// writes the address in CIDR form and sorts the database and user lists
+func (r *Rule) Normalize() {
+	if prefix, err := ParsePrefix(r.Address); err == nil {
+		r.Address = prefix.String()
+	}
+	r.ConnectionType = strings.ToUpper(r.ConnectionType)
+	if r.ConnectionType == "" {
+		r.ConnectionType = Host
+	}
+	r.AuthMethod = strings.ToUpper(r.AuthMethod)
+	r.Databases = slices.Sorted(slices.Values(r.Databases))
+	r.Users = slices.Sorted(slices.Values(r.Users))
+}
+
+// Equal reports whether two normalized rules are the same rule, ignoring IDs
+func (r Rule) Equal(o Rule) bool {
+	return r.ConnectionType == o.ConnectionType &&
+		r.Address == o.Address &&
+		r.AuthMethod == o.AuthMethod &&
+		slices.Equal(r.Databases, o.Databases) &&
+		slices.Equal(r.Users, o.Users)
+}
+
+// String renders the rule as a pg_hba.conf line
+func (r Rule) String() string {
+	list := func(names []string) string {
+		if len(names) == 0 {
+			return "all"
+		}
+		return strings.Join(names, ",")
+	}
+	return fmt.Sprintf("%s %s %s %s %s", strings.ToLower(r.ConnectionType), list(r.Databases), list(r.Users),
+		r.Address, strings.ToLower(strings.ReplaceAll(r.AuthMethod, "_", "-")))
+}
+
+// Matches reports whether the rule applies to c
+func (r Rule) Matches(c Connection) bool {
+	switch r.ConnectionType {
+	case HostSSL:
+		if !c.SSL {
+			return false
+		}
+	case HostNoSSL:
+		if c.SSL {
+			return false
+		}
+	}
+	if len(r.Databases) > 0 && !slices.Contains(r.Databases, c.Database) {
+		return false
+	}
+	if len(r.Users) > 0 && !slices.Contains(r.Users, c.User) {
+		return false
+	}
+	prefix, err := ParsePrefix(r.Address)
+	return err == nil && prefix.Contains(c.Address.Unmap())
+}
+
+// FirstMatch returns the index of the first rule that applies to c, or -1
+// when the connection is rejected
+func FirstMatch(rules []Rule, c Connection) int {
+	for i, r := range rules {
+		if r.Matches(c) {
+			return i
+		}
+	}
+	return -1
+}
+
+// ParsePrefix parses a CIDR address; a bare IP address is a single host
+func ParsePrefix(address string) (netip.Prefix, error) {
+	if !strings.Contains(address, "/") {
+		addr, err := netip.ParseAddr(address)
+		if err != nil {
+			return netip.Prefix{}, fmt.Errorf("invalid address %q", address)
+		}
+		return netip.PrefixFrom(addr, addr.BitLen()), nil
+	}
+	prefix, err := netip.ParsePrefix(address)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid address %q", address)
+	}
+	return prefix.Masked(), nil
+}
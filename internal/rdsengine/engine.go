@@ -0,0 +1,250 @@
+// Package rdsengine adapts the RDS for MySQL, MariaDB and PostgreSQL SDK
+// clients to a single Engine interface, so that commands common to every
+// engine are implemented once.
+package rdsengine
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Engine names accepted by New
+const (
+	MySQL      = "mysql"
+	MariaDB    = "mariadb"
+	PostgreSQL = "postgresql"
+)
+
+// Names lists the supported engines
+var Names = []string{MySQL, MariaDB, PostgreSQL}
+
+// Engine is the engine-independent view of an RDS service
+type Engine interface {
+	// Name returns the engine name (mysql, mariadb or postgresql)
+	Name() string
+	// DisplayName returns the product name (MySQL, MariaDB, PostgreSQL)
+	DisplayName() string
+
+	ListInstances(ctx context.Context) ([]Instance, error)
+	GetInstance(ctx context.Context, instanceID string) (*Instance, error)
+	GetEndpoints(ctx context.Context, instanceID string) ([]Endpoint, error)
+	StartInstance(ctx context.Context, instanceID string) error
+	StopInstance(ctx context.Context, instanceID string) error
+	RestartInstance(ctx context.Context, instanceID string, opts RestartOptions) error
+	DeleteInstance(ctx context.Context, instanceID string) error
+
+	ListFlavors(ctx context.Context) ([]Flavor, error)
+	ListVersions(ctx context.Context) ([]Version, error)
+	ListStorageTypes(ctx context.Context) ([]string, error)
+
+	ListBackups(ctx context.Context, instanceID string) ([]Backup, error)
+	CreateBackup(ctx context.Context, instanceID, name string) (jobID string, err error)
+	DeleteBackup(ctx context.Context, backupID string) error
+	// BackupToObjectStorage backs an instance up directly into an Object
+	// Storage container
+	BackupToObjectStorage(ctx context.Context, instanceID string, target ObjectStorageTarget) (jobID string, err error)
+
+	ListParameterGroups(ctx context.Context) ([]ParameterGroup, error)
+	// GetParameterGroup returns a parameter group including its parameters
+	GetParameterGroup(ctx context.Context, groupID string) (*ParameterGroup, error)
+	CreateParameterGroup(ctx context.Context, name, description, version string) (groupID string, err error)
+	// ModifyParameters sets parameter values, keyed by parameter ID
+	ModifyParameters(ctx context.Context, groupID string, values map[string]string) error
+	ListSecurityGroups(ctx context.Context) ([]SecurityGroup, error)
+	ListUsers(ctx context.Context, instanceID string) ([]User, error)
+
+	ListMetrics(ctx context.Context) ([]Metric, error)
+	GetMetricStatistics(ctx context.Context, instanceID, from, to string, interval int) ([]MetricSeries, error)
+	ListLogFiles(ctx context.Context, instanceID string) ([]LogFile, error)
+}
+
+// Instance is a DB instance in engine-independent form
+type Instance struct {
+	ID               string   `json:"dbInstanceId"`
+	Name             string   `json:"dbInstanceName"`
+	Description      string   `json:"description,omitempty"`
+	Type             string   `json:"dbInstanceType,omitempty"`
+	Status           string   `json:"dbInstanceStatus"`
+	ProgressStatus   string   `json:"progressStatus,omitempty"`
+	Version          string   `json:"dbVersion"`
+	Port             int      `json:"dbPort"`
+	FlavorID         string   `json:"dbFlavorId"`
+	FlavorName       string   `json:"dbFlavorName,omitempty"`
+	ParameterGroupID string   `json:"parameterGroupId,omitempty"`
+	SecurityGroupIDs []string `json:"dbSecurityGroupIds,omitempty"`
+	SubnetID         string   `json:"subnetId,omitempty"`
+	AvailabilityZone string   `json:"availabilityZone,omitempty"`
+	PublicAccess     bool     `json:"usePublicAccess"`
+	Domain           string   `json:"domain,omitempty"`
+	FloatingIP       string   `json:"floatingIp,omitempty"`
+	PublicIP         string   `json:"publicIp,omitempty"`
+	IPAddress        string   `json:"ipAddress,omitempty"`
+	StorageType      string   `json:"storageType,omitempty"`
+	StorageSize      int      `json:"storageSize,omitempty"`
+	CreatedAt        string   `json:"createdAt,omitempty"`
+}
+
+// Endpoint is a network endpoint of an instance (INTERNAL, EXTERNAL, ...)
+type Endpoint struct {
+	Type      string `json:"endPointType"`
+	Domain    string `json:"domain,omitempty"`
+	IPAddress string `json:"ipAddress,omitempty"`
+}
+
+// Host returns the domain of the endpoint, or its IP address
+func (e Endpoint) Host() string {
+	if e.Domain != "" {
+		return e.Domain
+	}
+	return e.IPAddress
+}
+
+type Flavor struct {
+	ID    string `json:"dbFlavorId"`
+	Name  string `json:"dbFlavorName"`
+	VCPUs int    `json:"vcpus"`
+	RAM   int    `json:"ram"`
+}
+
+type Version struct {
+	Version string `json:"dbVersion"`
+	Name    string `json:"dbVersionName"`
+}
+
+type Backup struct {
+	ID           string `json:"backupId"`
+	Name         string `json:"backupName"`
+	Type         string `json:"backupType,omitempty"`
+	Status       string `json:"backupStatus"`
+	InstanceID   string `json:"dbInstanceId"`
+	InstanceName string `json:"dbInstanceName,omitempty"`
+	Size         int64  `json:"backupSize,omitempty"`
+	StartedAt    string `json:"backupStartedAt,omitempty"`
+	CompletedAt  string `json:"backupCompletedAt,omitempty"`
+	CreatedAt    string `json:"createdAt"`
+}
+
+// RestartOptions are the optional settings of RestartInstance
+type RestartOptions struct {
+	// UseOnlineFailover restarts a high availability instance by failing
+	// over to its candidate master
+	UseOnlineFailover bool
+	// ExecuteBackup takes a backup before the restart
+	ExecuteBackup bool
+}
+
+// ObjectStorageTarget is the destination of BackupToObjectStorage. The
+// credentials are those of the Object Storage API (tenant, NHN Cloud ID and
+// API password).
+type ObjectStorageTarget struct {
+	TenantID   string
+	Username   string
+	Password   string
+	Container  string
+	ObjectPath string
+}
+
+type ParameterGroup struct {
+	ID          string      `json:"parameterGroupId"`
+	Name        string      `json:"parameterGroupName"`
+	Description string      `json:"description,omitempty"`
+	Version     string      `json:"dbVersion"`
+	Parameters  []Parameter `json:"parameters,omitempty"`
+	CreatedAt   string      `json:"createdAt,omitempty"`
+}
+
+type Parameter struct {
+	ID            string `json:"parameterId"`
+	Name          string `json:"parameterName"`
+	Value         string `json:"value"`
+	DefaultValue  string `json:"defaultValue,omitempty"`
+	AllowedValues string `json:"allowedValues,omitempty"`
+	DataType      string `json:"dataType,omitempty"`
+	Modifiable    bool   `json:"isModifiable"`
+	ApplyType     string `json:"applyType,omitempty"`
+}
+
+type SecurityGroup struct {
+	ID          string `json:"dbSecurityGroupId"`
+	Name        string `json:"dbSecurityGroupName"`
+	Description string `json:"description,omitempty"`
+	Rules       int    `json:"rules"`
+	CreatedAt   string `json:"createdAt,omitempty"`
+}
+
+type User struct {
+	ID            string `json:"dbUserId,omitempty"`
+	Name          string `json:"dbUserName"`
+	Host          string `json:"host,omitempty"`
+	AuthorityType string `json:"authorityType,omitempty"`
+}
+
+type Metric struct {
+	Name string `json:"measureName"`
+	Unit string `json:"unit,omitempty"`
+}
+
+type MetricSeries struct {
+	Name   string        `json:"measureName"`
+	Unit   string        `json:"unit,omitempty"`
+	Points []MetricPoint `json:"values"`
+}
+
+type MetricPoint struct {
+	Timestamp string  `json:"timestamp"`
+	Value     float64 `json:"value"`
+}
+
+type LogFile struct {
+	Name       string `json:"logFileName"`
+	Size       int64  `json:"logFileSize"`
+	ModifiedAt string `json:"modifiedAt,omitempty"`
+}
+
+// NormalizeName maps engine aliases (pg, postgres, maria) to an engine name
+func NormalizeName(name string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "mysql":
+		return MySQL, nil
+	case "mariadb", "maria":
+		return MariaDB, nil
+	case "postgresql", "postgres", "pg":
+		return PostgreSQL, nil
+	case "":
+		return "", fmt.Errorf("engine is required (%s)", strings.Join(Names, ", "))
+	}
+	return "", fmt.Errorf("unknown engine '%s' (%s)", name, strings.Join(Names, ", "))
+}
+
+// ResolveInstanceID resolves an instance name or ID to an ID
+func ResolveInstanceID(ctx context.Context, e Engine, identifier string) (string, error) {
+	if identifier == "" {
+		return "", fmt.Errorf("--db-instance-identifier is required")
+	}
+	// UUIDs are passed through without a lookup
+	if len(identifier) == 36 && identifier[8] == '-' && identifier[13] == '-' {
+		return identifier, nil
+	}
+
+	instances, err := e.ListInstances(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list instances: %w", err)
+	}
+	for _, inst := range instances {
+		if inst.Name == identifier {
+			return inst.ID, nil
+		}
+	}
+	return "", fmt.Errorf("instance not found: %s", identifier)
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,326 @@
+package rdsengine
+
+import (
+	"context"
+
+	"github.com/haung921209/nhn-cloud-sdk-go/nhncloud/database/mariadb"
+)
+
+type mariadbEngine struct {
+	client *mariadb.Client
+}
+
+// NewMariaDB adapts an RDS for MariaDB client
+func NewMariaDB(client *mariadb.Client) Engine {
+	return &mariadbEngine{client: client}
+}
+
+func (e *mariadbEngine) Name() string        { return MariaDB }
+func (e *mariadbEngine) DisplayName() string { return "MariaDB" }
+
+func (e *mariadbEngine) ListInstances(ctx context.Context) ([]Instance, error) {
+	resp, err := e.client.ListInstances(ctx)
+	if err != nil {
+		return nil, err
+	}
+	instances := make([]Instance, 0, len(resp.DBInstances))
+	for _, inst := range resp.DBInstances {
+		instances = append(instances, mariadbInstance(inst))
+	}
+	return instances, nil
+}
+
+func (e *mariadbEngine) GetInstance(ctx context.Context, instanceID string) (*Instance, error) {
+	resp, err := e.client.GetInstance(ctx, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	inst := mariadbInstance(resp.DatabaseInstance)
+	return &inst, nil
+}
+
+func mariadbInstance(inst mariadb.DatabaseInstance) Instance {
+	return Instance{
+		ID:               inst.DBInstanceID,
+		Name:             inst.DBInstanceName,
+		Description:      inst.DBInstanceDescription,
+		Status:           string(inst.DBInstanceStatus),
+		ProgressStatus:   inst.ProgressStatus,
+		Version:          inst.DBVersion,
+		Port:             inst.DBPort,
+		FlavorID:         inst.DBFlavorID,
+		FlavorName:       inst.DBFlavorName,
+		ParameterGroupID: inst.ParameterGroupID,
+		SecurityGroupIDs: inst.DBSecurityGroupIDs,
+		SubnetID:         inst.Network.SubnetID,
+		AvailabilityZone: inst.Network.AvailabilityZone,
+		PublicAccess:     inst.Network.UsePublicAccess,
+		Domain:           inst.Network.DomainName,
+		IPAddress:        inst.Network.IPAddress,
+		StorageType:      inst.Storage.StorageType,
+		StorageSize:      inst.Storage.StorageSize,
+		CreatedAt:        inst.CreatedAt,
+	}
+}
+
+func (e *mariadbEngine) GetEndpoints(ctx context.Context, instanceID string) ([]Endpoint, error) {
+	resp, err := e.client.GetNetworkInfo(ctx, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	endpoints := make([]Endpoint, 0, len(resp.EndPoints))
+	for _, ep := range resp.EndPoints {
+		endpoints = append(endpoints, Endpoint{Type: ep.EndPointType, Domain: ep.Domain, IPAddress: ep.IPAddress})
+	}
+	return endpoints, nil
+}
+
+func (e *mariadbEngine) StartInstance(ctx context.Context, instanceID string) error {
+	_, err := e.client.StartInstance(ctx, instanceID)
+	return err
+}
+
+func (e *mariadbEngine) StopInstance(ctx context.Context, instanceID string) error {
+	_, err := e.client.StopInstance(ctx, instanceID)
+	return err
+}
+
+func (e *mariadbEngine) RestartInstance(ctx context.Context, instanceID string, opts RestartOptions) error {
+	req := &mariadb.RestartInstanceRequest{}
+	if opts.UseOnlineFailover {
+		req.UseOnlineFailover = &opts.UseOnlineFailover
+	}
+	if opts.ExecuteBackup {
+		req.ExecuteBackup = &opts.ExecuteBackup
+	}
+	_, err := e.client.RestartInstance(ctx, instanceID, req)
+	return err
+}
+
+func (e *mariadbEngine) DeleteInstance(ctx context.Context, instanceID string) error {
+	_, err := e.client.DeleteInstance(ctx, instanceID)
+	return err
+}
+
+func (e *mariadbEngine) ListFlavors(ctx context.Context) ([]Flavor, error) {
+	resp, err := e.client.ListFlavors(ctx)
+	if err != nil {
+		return nil, err
+	}
+	flavors := make([]Flavor, 0, len(resp.DBFlavors))
+	for _, f := range resp.DBFlavors {
+		flavors = append(flavors, Flavor{ID: f.DBFlavorID, Name: f.DBFlavorName, VCPUs: f.Vcpus, RAM: f.Ram})
+	}
+	return flavors, nil
+}
+
+func (e *mariadbEngine) ListVersions(ctx context.Context) ([]Version, error) {
+	resp, err := e.client.ListVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	versions := make([]Version, 0, len(resp.DBVersions))
+	for _, v := range resp.DBVersions {
+		versions = append(versions, Version{Version: v.DBVersion, Name: v.DBVersionName})
+	}
+	return versions, nil
+}
+
+func (e *mariadbEngine) ListStorageTypes(ctx context.Context) ([]string, error) {
+	resp, err := e.client.ListStorageTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	types := make([]string, 0, len(resp.StorageTypes))
+	for _, t := range resp.StorageTypes {
+		types = append(types, t.StorageType)
+	}
+	return types, nil
+}
+
+func (e *mariadbEngine) ListBackups(ctx context.Context, instanceID string) ([]Backup, error) {
+	resp, err := e.client.ListBackups(ctx, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	backups := make([]Backup, 0, len(resp.Backups))
+	for _, b := range resp.Backups {
+		backups = append(backups, Backup{
+			ID:           b.BackupID,
+			Name:         b.BackupName,
+			Type:         b.BackupType,
+			Status:       b.BackupStatus,
+			InstanceID:   b.DBInstanceID,
+			InstanceName: b.DBInstanceName,
+			Size:         b.BackupSize,
+			StartedAt:    b.BackupStartedAt,
+			CompletedAt:  b.BackupCompletedAt,
+			CreatedAt:    b.CreatedAt,
+		})
+	}
+	return backups, nil
+}
+
+func (e *mariadbEngine) CreateBackup(ctx context.Context, instanceID, name string) (string, error) {
+	resp, err := e.client.CreateBackup(ctx, instanceID, &mariadb.CreateBackupRequest{BackupName: name})
+	if err != nil {
+		return "", err
+	}
+	return resp.JobID, nil
+}
+
+func (e *mariadbEngine) DeleteBackup(ctx context.Context, backupID string) error {
+	_, err := e.client.DeleteBackup(ctx, backupID)
+	return err
+}
+
+func (e *mariadbEngine) BackupToObjectStorage(ctx context.Context, instanceID string, target ObjectStorageTarget) (string, error) {
+	resp, err := e.client.BackupToObjectStorage(ctx, instanceID, &mariadb.BackupToObjectStorageRequest{
+		TenantID:        target.TenantID,
+		Username:        target.Username,
+		Password:        target.Password,
+		TargetContainer: target.Container,
+		ObjectPath:      target.ObjectPath,
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.JobID, nil
+}
+
+func (e *mariadbEngine) ListParameterGroups(ctx context.Context) ([]ParameterGroup, error) {
+	resp, err := e.client.ListParameterGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+	groups := make([]ParameterGroup, 0, len(resp.ParameterGroups))
+	for _, g := range resp.ParameterGroups {
+		groups = append(groups, ParameterGroup{
+			ID:          g.ParameterGroupID,
+			Name:        g.ParameterGroupName,
+			Description: g.Description,
+			Version:     g.DBVersion,
+			CreatedAt:   g.CreatedAt,
+		})
+	}
+	return groups, nil
+}
+
+func (e *mariadbEngine) GetParameterGroup(ctx context.Context, groupID string) (*ParameterGroup, error) {
+	resp, err := e.client.GetParameterGroup(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	g := resp.ParameterGroup
+	out := &ParameterGroup{
+		ID:          g.ParameterGroupID,
+		Name:        g.ParameterGroupName,
+		Description: g.Description,
+		Version:     g.DBVersion,
+		CreatedAt:   g.CreatedAt,
+	}
+	for _, p := range g.Parameters {
+		out.Parameters = append(out.Parameters, Parameter{
+			ID:            p.ParameterID,
+			Name:          p.ParameterName,
+			Value:         p.Value,
+			DefaultValue:  p.DefaultValue,
+			AllowedValues: p.AllowedValues,
+			DataType:      p.DataType,
+			Modifiable:    p.IsModifiable,
+			ApplyType:     p.ApplyType,
+		})
+	}
+	return out, nil
+}
+
+func (e *mariadbEngine) CreateParameterGroup(ctx context.Context, name, description, version string) (string, error) {
+	resp, err := e.client.CreateParameterGroup(ctx, &mariadb.CreateParameterGroupRequest{
+		ParameterGroupName: name,
+		Description:        description,
+		DBVersion:          version,
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.ParameterGroupID, nil
+}
+
+func (e *mariadbEngine) ModifyParameters(ctx context.Context, groupID string, values map[string]string) error {
+	req := &mariadb.ModifyParametersRequest{}
+	for _, id := range sortedKeys(values) {
+		req.ModifiedParameters = append(req.ModifiedParameters, mariadb.ModifiedParameter{ParameterID: id, Value: values[id]})
+	}
+	_, err := e.client.ModifyParameters(ctx, groupID, req)
+	return err
+}
+
+func (e *mariadbEngine) ListSecurityGroups(ctx context.Context) ([]SecurityGroup, error) {
+	resp, err := e.client.ListSecurityGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+	groups := make([]SecurityGroup, 0, len(resp.DBSecurityGroups))
+	for _, g := range resp.DBSecurityGroups {
+		groups = append(groups, SecurityGroup{
+			ID:          g.DBSecurityGroupID,
+			Name:        g.DBSecurityGroupName,
+			Description: g.Description,
+			Rules:       len(g.Rules),
+			CreatedAt:   g.CreatedAt,
+		})
+	}
+	return groups, nil
+}
+
+func (e *mariadbEngine) ListUsers(ctx context.Context, instanceID string) ([]User, error) {
+	resp, err := e.client.ListDBUsers(ctx, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	users := make([]User, 0, len(resp.DBUsers))
+	for _, u := range resp.DBUsers {
+		users = append(users, User{ID: u.DBUserID, Name: u.DBUserName, Host: u.Host, AuthorityType: u.AuthorityType})
+	}
+	return users, nil
+}
+
+func (e *mariadbEngine) ListMetrics(ctx context.Context) ([]Metric, error) {
+	resp, err := e.client.ListMetrics(ctx)
+	if err != nil {
+		return nil, err
+	}
+	metrics := make([]Metric, 0, len(resp.Metrics))
+	for _, m := range resp.Metrics {
+		metrics = append(metrics, Metric{Name: m.MetricName, Unit: m.Unit})
+	}
+	return metrics, nil
+}
+
+func (e *mariadbEngine) GetMetricStatistics(ctx context.Context, instanceID, from, to string, interval int) ([]MetricSeries, error) {
+	resp, err := e.client.GetMetricStatistics(ctx, instanceID, from, to, interval)
+	if err != nil {
+		return nil, err
+	}
+	series := make([]MetricSeries, 0, len(resp.MetricStatistics))
+	for _, s := range resp.MetricStatistics {
+		points := make([]MetricPoint, 0, len(s.Values))
+		for _, v := range s.Values {
+			points = append(points, MetricPoint{Timestamp: v.Timestamp, Value: v.Value})
+		}
+		series = append(series, MetricSeries{Name: s.MetricName, Unit: s.Unit, Points: points})
+	}
+	return series, nil
+}
+
+func (e *mariadbEngine) ListLogFiles(ctx context.Context, instanceID string) ([]LogFile, error) {
+	resp, err := e.client.ListLogFiles(ctx, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]LogFile, 0, len(resp.LogFiles))
+	for _, f := range resp.LogFiles {
+		files = append(files, LogFile{Name: f.LogFileName, Size: f.LogFileSize, ModifiedAt: f.ModifiedAt})
+	}
+	return files, nil
+}
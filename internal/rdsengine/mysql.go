@@ -0,0 +1,330 @@
+package rdsengine
+
+import (
+	"context"
+
+	"github.com/haung921209/nhn-cloud-sdk-go/nhncloud/database/mysql"
+)
+
+type mysqlEngine struct {
+	client *mysql.Client
+}
+
+// NewMySQL adapts an RDS for MySQL client
+func NewMySQL(client *mysql.Client) Engine {
+	return &mysqlEngine{client: client}
+}
+
+func (e *mysqlEngine) Name() string        { return MySQL }
+func (e *mysqlEngine) DisplayName() string { return "MySQL" }
+
+func (e *mysqlEngine) ListInstances(ctx context.Context) ([]Instance, error) {
+	resp, err := e.client.ListInstances(ctx)
+	if err != nil {
+		return nil, err
+	}
+	instances := make([]Instance, 0, len(resp.DBInstances))
+	for _, inst := range resp.DBInstances {
+		instances = append(instances, mysqlInstance(inst))
+	}
+	return instances, nil
+}
+
+func (e *mysqlEngine) GetInstance(ctx context.Context, instanceID string) (*Instance, error) {
+	resp, err := e.client.GetInstance(ctx, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	inst := mysqlInstance(resp.DatabaseInstance)
+	return &inst, nil
+}
+
+func mysqlInstance(inst mysql.DatabaseInstance) Instance {
+	out := Instance{
+		ID:               inst.DBInstanceID,
+		Name:             inst.DBInstanceName,
+		Description:      inst.Description,
+		Type:             inst.DBInstanceType,
+		Status:           string(inst.DBInstanceStatus),
+		ProgressStatus:   inst.ProgressStatus,
+		Version:          inst.DBVersion,
+		Port:             inst.DBPort,
+		FlavorID:         inst.DBFlavorID,
+		FlavorName:       inst.DBFlavorName,
+		ParameterGroupID: inst.ParameterGroupID,
+		SecurityGroupIDs: inst.DBSecurityGroupIDs,
+		CreatedAt:        inst.CreatedYmdt,
+	}
+	if inst.Network != nil {
+		out.SubnetID = inst.Network.SubnetID
+		out.AvailabilityZone = inst.Network.AvailabilityZone
+		out.PublicAccess = inst.Network.UsePublicAccess
+		out.Domain = inst.Network.DomainName
+		out.FloatingIP = inst.Network.FloatingIP
+		out.PublicIP = inst.Network.PublicIP
+		out.IPAddress = inst.Network.IPAddress
+	}
+	if inst.Storage != nil {
+		out.StorageType = inst.Storage.StorageType
+		out.StorageSize = inst.Storage.StorageSize
+	}
+	return out
+}
+
+func (e *mysqlEngine) GetEndpoints(ctx context.Context, instanceID string) ([]Endpoint, error) {
+	resp, err := e.client.GetNetworkInfo(ctx, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	endpoints := make([]Endpoint, 0, len(resp.EndPoints))
+	for _, ep := range resp.EndPoints {
+		endpoints = append(endpoints, Endpoint{Type: ep.EndPointType, Domain: ep.Domain, IPAddress: ep.IPAddress})
+	}
+	return endpoints, nil
+}
+
+func (e *mysqlEngine) StartInstance(ctx context.Context, instanceID string) error {
+	_, err := e.client.StartInstance(ctx, instanceID)
+	return err
+}
+
+func (e *mysqlEngine) StopInstance(ctx context.Context, instanceID string) error {
+	_, err := e.client.StopInstance(ctx, instanceID)
+	return err
+}
+
+func (e *mysqlEngine) RestartInstance(ctx context.Context, instanceID string, opts RestartOptions) error {
+	req := &mysql.RestartInstanceRequest{}
+	if opts.UseOnlineFailover {
+		req.UseOnlineFailover = &opts.UseOnlineFailover
+	}
+	if opts.ExecuteBackup {
+		req.ExecuteBackup = &opts.ExecuteBackup
+	}
+	_, err := e.client.RestartInstance(ctx, instanceID, req)
+	return err
+}
+
+func (e *mysqlEngine) DeleteInstance(ctx context.Context, instanceID string) error {
+	_, err := e.client.DeleteInstance(ctx, instanceID, nil)
+	return err
+}
+
+func (e *mysqlEngine) ListFlavors(ctx context.Context) ([]Flavor, error) {
+	resp, err := e.client.ListFlavors(ctx)
+	if err != nil {
+		return nil, err
+	}
+	flavors := make([]Flavor, 0, len(resp.DBFlavors))
+	for _, f := range resp.DBFlavors {
+		flavors = append(flavors, Flavor{ID: f.DBFlavorID, Name: f.DBFlavorName, VCPUs: f.Vcpus, RAM: f.Ram})
+	}
+	return flavors, nil
+}
+
+func (e *mysqlEngine) ListVersions(ctx context.Context) ([]Version, error) {
+	resp, err := e.client.ListVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	versions := make([]Version, 0, len(resp.DBVersions))
+	for _, v := range resp.DBVersions {
+		versions = append(versions, Version{Version: v.DBVersion, Name: v.DBVersionName})
+	}
+	return versions, nil
+}
+
+func (e *mysqlEngine) ListStorageTypes(ctx context.Context) ([]string, error) {
+	resp, err := e.client.ListStorageTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.StorageTypes, nil
+}
+
+func (e *mysqlEngine) ListBackups(ctx context.Context, instanceID string) ([]Backup, error) {
+	resp, err := e.client.ListBackups(ctx, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	backups := make([]Backup, 0, len(resp.Backups))
+	for _, b := range resp.Backups {
+		backups = append(backups, Backup{
+			ID:           b.BackupID,
+			Name:         b.BackupName,
+			Type:         b.BackupType,
+			Status:       b.BackupStatus,
+			InstanceID:   b.DBInstanceID,
+			InstanceName: b.DBInstanceName,
+			Size:         b.BackupSize,
+			StartedAt:    b.BackupStartedAt,
+			CompletedAt:  b.BackupCompletedAt,
+			CreatedAt:    b.CreatedAt,
+		})
+	}
+	return backups, nil
+}
+
+func (e *mysqlEngine) CreateBackup(ctx context.Context, instanceID, name string) (string, error) {
+	resp, err := e.client.CreateBackup(ctx, instanceID, &mysql.CreateBackupRequest{BackupName: name})
+	if err != nil {
+		return "", err
+	}
+	return resp.JobID, nil
+}
+
+func (e *mysqlEngine) DeleteBackup(ctx context.Context, backupID string) error {
+	_, err := e.client.DeleteBackup(ctx, backupID)
+	return err
+}
+
+func (e *mysqlEngine) BackupToObjectStorage(ctx context.Context, instanceID string, target ObjectStorageTarget) (string, error) {
+	resp, err := e.client.BackupToObjectStorage(ctx, instanceID, &mysql.BackupToObjectStorageRequest{
+		TenantID:        target.TenantID,
+		Username:        target.Username,
+		Password:        target.Password,
+		TargetContainer: target.Container,
+		ObjectPath:      target.ObjectPath,
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.JobID, nil
+}
+
+func (e *mysqlEngine) ListParameterGroups(ctx context.Context) ([]ParameterGroup, error) {
+	resp, err := e.client.ListParameterGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+	groups := make([]ParameterGroup, 0, len(resp.ParameterGroups))
+	for _, g := range resp.ParameterGroups {
+		groups = append(groups, ParameterGroup{
+			ID:          g.ParameterGroupID,
+			Name:        g.ParameterGroupName,
+			Description: g.Description,
+			Version:     g.DBVersion,
+			CreatedAt:   g.CreatedAt,
+		})
+	}
+	return groups, nil
+}
+
+func (e *mysqlEngine) GetParameterGroup(ctx context.Context, groupID string) (*ParameterGroup, error) {
+	resp, err := e.client.GetParameterGroup(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	g := resp.ParameterGroup
+	out := &ParameterGroup{
+		ID:          g.ParameterGroupID,
+		Name:        g.ParameterGroupName,
+		Description: g.Description,
+		Version:     g.DBVersion,
+		CreatedAt:   g.CreatedAt,
+	}
+	for _, p := range g.Parameters {
+		out.Parameters = append(out.Parameters, Parameter{
+			ID:            p.ParameterID,
+			Name:          p.ParameterName,
+			Value:         p.Value,
+			DefaultValue:  p.DefaultValue,
+			AllowedValues: p.AllowedValues,
+			DataType:      p.DataType,
+			Modifiable:    p.IsModifiable,
+			ApplyType:     p.ApplyType,
+		})
+	}
+	return out, nil
+}
+
+func (e *mysqlEngine) CreateParameterGroup(ctx context.Context, name, description, version string) (string, error) {
+	resp, err := e.client.CreateParameterGroup(ctx, &mysql.CreateParameterGroupRequest{
+		ParameterGroupName: name,
+		Description:        description,
+		DBVersion:          version,
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.ParameterGroupID, nil
+}
+
+func (e *mysqlEngine) ModifyParameters(ctx context.Context, groupID string, values map[string]string) error {
+	req := &mysql.ModifyParametersRequest{}
+	for _, id := range sortedKeys(values) {
+		req.ModifiedParameters = append(req.ModifiedParameters, mysql.ModifiedParameter{ParameterID: id, Value: values[id]})
+	}
+	_, err := e.client.ModifyParameters(ctx, groupID, req)
+	return err
+}
+
+func (e *mysqlEngine) ListSecurityGroups(ctx context.Context) ([]SecurityGroup, error) {
+	resp, err := e.client.ListSecurityGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+	groups := make([]SecurityGroup, 0, len(resp.DBSecurityGroups))
+	for _, g := range resp.DBSecurityGroups {
+		groups = append(groups, SecurityGroup{
+			ID:          g.DBSecurityGroupID,
+			Name:        g.DBSecurityGroupName,
+			Description: g.Description,
+			Rules:       len(g.Rules),
+			CreatedAt:   g.CreatedAt,
+		})
+	}
+	return groups, nil
+}
+
+func (e *mysqlEngine) ListUsers(ctx context.Context, instanceID string) ([]User, error) {
+	resp, err := e.client.ListDBUsers(ctx, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	users := make([]User, 0, len(resp.DBUsers))
+	for _, u := range resp.DBUsers {
+		users = append(users, User{ID: u.DBUserID, Name: u.DBUserName, Host: u.Host, AuthorityType: u.AuthorityType})
+	}
+	return users, nil
+}
+
+func (e *mysqlEngine) ListMetrics(ctx context.Context) ([]Metric, error) {
+	resp, err := e.client.ListMetrics(ctx)
+	if err != nil {
+		return nil, err
+	}
+	metrics := make([]Metric, 0, len(resp.Metrics))
+	for _, m := range resp.Metrics {
+		metrics = append(metrics, Metric{Name: m.MetricName, Unit: m.Unit})
+	}
+	return metrics, nil
+}
+
+func (e *mysqlEngine) GetMetricStatistics(ctx context.Context, instanceID, from, to string, interval int) ([]MetricSeries, error) {
+	resp, err := e.client.GetMetricStatistics(ctx, instanceID, from, to, interval)
+	if err != nil {
+		return nil, err
+	}
+	series := make([]MetricSeries, 0, len(resp.MetricStatistics))
+	for _, s := range resp.MetricStatistics {
+		points := make([]MetricPoint, 0, len(s.Values))
+		for _, v := range s.Values {
+			points = append(points, MetricPoint{Timestamp: v.Timestamp, Value: v.Value})
+		}
+		series = append(series, MetricSeries{Name: s.MetricName, Unit: s.Unit, Points: points})
+	}
+	return series, nil
+}
+
+func (e *mysqlEngine) ListLogFiles(ctx context.Context, instanceID string) ([]LogFile, error) {
+	resp, err := e.client.ListLogFiles(ctx, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]LogFile, 0, len(resp.LogFiles))
+	for _, f := range resp.LogFiles {
+		files = append(files, LogFile{Name: f.LogFileName, Size: f.LogFileSize, ModifiedAt: f.ModifiedAt})
+	}
+	return files, nil
+}
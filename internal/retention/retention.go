@@ -0,0 +1,215 @@
+// Package retention decides which snapshots to keep under a retention policy
+// (keep the last N, one per day for D days, one per week for W weeks), in
+// the style of backup tools such as restic forget.
+package retention
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Policy is a retention policy file: an ordered list of rules. Each snapshot
+// is governed by the first rule that matches it; snapshots matching no rule
+// are always kept.
+//
+//	rules:
+//	  - name: nightly
+//	    name-pattern: "nightly-*"
+//	    keep-last: 7
+//	    keep-daily: 14
+//	    keep-weekly: 8
+type Policy struct {
+	Rules []Rule `yaml:"rules" json:"rules"`
+}
+
+// Rule selects snapshots by name and tags and says which of them to keep.
+// The keep counts apply per resource (DB instance or volume).
+type Rule struct {
+	Name string `yaml:"name,omitempty" json:"name,omitempty"`
+	// NamePattern is a glob (path.Match syntax); empty matches every name
+	NamePattern string `yaml:"name-pattern,omitempty" json:"namePattern,omitempty"`
+	// Tags must all be present with these values (metadata of the snapshot)
+	Tags map[string]string `yaml:"tags,omitempty" json:"tags,omitempty"`
+
+	KeepLast   int `yaml:"keep-last,omitempty" json:"keepLast,omitempty"`
+	KeepDaily  int `yaml:"keep-daily,omitempty" json:"keepDaily,omitempty"`
+	KeepWeekly int `yaml:"keep-weekly,omitempty" json:"keepWeekly,omitempty"`
+}
+
+// Snapshot is the engine-independent view of a snapshot or backup
+type Snapshot struct {
+	ID        string
+	Name      string
+	Resource  string
+	CreatedAt time.Time
+	Tags      map[string]string
+	// Protected snapshots (e.g. preserved NAS snapshots) are never deleted
+	Protected bool
+}
+
+// Decision is the outcome for one snapshot
+type Decision struct {
+	Snapshot Snapshot
+	Keep     bool
+	Rule     string
+	Reason   string
+}
+
+// Load reads and validates a policy file
+func Load(file string) (*Policy, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var p Policy
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", file, err)
+	}
+	if err := p.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	return &p, nil
+}
+
+// Validate checks that every rule keeps something, so that a rule never
+// deletes every snapshot it matches
+func (p *Policy) Validate() error {
+	if len(p.Rules) == 0 {
+		return fmt.Errorf("no rules")
+	}
+	for i, r := range p.Rules {
+		label := r.label(i)
+		if r.KeepLast < 0 || r.KeepDaily < 0 || r.KeepWeekly < 0 {
+			return fmt.Errorf("rule %s: keep counts must not be negative", label)
+		}
+		if r.KeepLast == 0 && r.KeepDaily == 0 && r.KeepWeekly == 0 {
+			return fmt.Errorf("rule %s: set at least one of keep-last, keep-daily, keep-weekly", label)
+		}
+		if _, err := path.Match(r.NamePattern, ""); err != nil {
+			return fmt.Errorf("rule %s: invalid name-pattern: %w", label, err)
+		}
+	}
+	return nil
+}
+
+func (r *Rule) label(i int) string {
+	if r.Name != "" {
+		return r.Name
+	}
+	return fmt.Sprintf("#%d", i+1)
+}
+
+func (r *Rule) matches(s Snapshot) bool {
+	if r.NamePattern != "" {
+		if ok, _ := path.Match(r.NamePattern, s.Name); !ok {
+			return false
+		}
+	}
+	for k, v := range r.Tags {
+		if got, ok := s.Tags[k]; !ok || got != v {
+			return false
+		}
+	}
+	return true
+}
+
+// Evaluate decides for every snapshot whether to keep it. Days and weeks are
+// counted back from now in now's location; within a day or week the newest
+// snapshot is kept. Decisions are returned grouped by resource, newest
+// first.
+func (p *Policy) Evaluate(snapshots []Snapshot, now time.Time) []Decision {
+	sorted := append([]Snapshot(nil), snapshots...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Resource != sorted[j].Resource {
+			return sorted[i].Resource < sorted[j].Resource
+		}
+		return sorted[i].CreatedAt.After(sorted[j].CreatedAt)
+	})
+
+	type bucketKey struct {
+		resource, rule, bucket string
+	}
+	seen := make(map[bucketKey]bool)
+	count := make(map[bucketKey]int)
+
+	loc := now.Location()
+	today := startOfDay(now)
+	decisions := make([]Decision, 0, len(sorted))
+	for _, s := range sorted {
+		d := Decision{Snapshot: s}
+		rule := -1
+		for i := range p.Rules {
+			if p.Rules[i].matches(s) {
+				rule = i
+				break
+			}
+		}
+		if rule < 0 {
+			d.Keep, d.Reason = true, "no matching rule"
+			decisions = append(decisions, d)
+			continue
+		}
+		r := p.Rules[rule]
+		d.Rule = r.label(rule)
+		if s.Protected {
+			d.Keep, d.Reason = true, "protected"
+			decisions = append(decisions, d)
+			continue
+		}
+		created := s.CreatedAt.In(loc)
+		last := bucketKey{s.Resource, d.Rule, "last"}
+
+		var reasons []string
+		if count[last] < r.KeepLast {
+			reasons = append(reasons, fmt.Sprintf("last %d", r.KeepLast))
+		}
+		count[last]++
+		if day := startOfDay(created); r.KeepDaily > 0 && today.Sub(day) < time.Duration(r.KeepDaily)*24*time.Hour {
+			key := bucketKey{s.Resource, d.Rule, "day " + day.Format("2006-01-02")}
+			if !seen[key] {
+				seen[key] = true
+				reasons = append(reasons, "daily "+day.Format("2006-01-02"))
+			}
+		}
+		if r.KeepWeekly > 0 {
+			year, week := created.ISOWeek()
+			nowYear, nowWeek := now.ISOWeek()
+			if weeksBetween(year, week, nowYear, nowWeek) < r.KeepWeekly {
+				key := bucketKey{s.Resource, d.Rule, fmt.Sprintf("week %d-W%02d", year, week)}
+				if !seen[key] {
+					seen[key] = true
+					reasons = append(reasons, fmt.Sprintf("weekly %d-W%02d", year, week))
+				}
+			}
+		}
+
+		if len(reasons) > 0 {
+			d.Keep, d.Reason = true, strings.Join(reasons, ", ")
+		} else {
+			d.Reason = "outside retention"
+		}
+		decisions = append(decisions, d)
+	}
+	return decisions
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// weeksBetween counts ISO weeks from (y1, w1) to (y2, w2)
+func weeksBetween(y1, w1, y2, w2 int) int {
+	monday := func(year, week int) time.Time {
+		// January 4th is always in week 1
+		jan4 := time.Date(year, 1, 4, 0, 0, 0, 0, time.UTC)
+		offset := (int(jan4.Weekday()) + 6) % 7
+		return jan4.AddDate(0, 0, -offset+(week-1)*7)
+	}
+	return int(monday(y2, w2).Sub(monday(y1, w1)).Hours() / (24 * 7))
+}
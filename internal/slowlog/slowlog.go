@@ -0,0 +1,421 @@
+// Package slowlog parses MySQL/MariaDB slow query logs and PostgreSQL
+// log_min_duration_statement output, groups the queries by fingerprint (the
+// statement with its literals removed) and summarizes each group, in the
+// style of pt-query-digest.
+package slowlog
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"math"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Log formats
+const (
+	MySQL      = "mysql"
+	PostgreSQL = "postgresql"
+)
+
+// Query is one logged statement
+type Query struct {
+	SQL          string
+	Database     string
+	User         string
+	Time         time.Time
+	Duration     time.Duration
+	LockTime     time.Duration
+	RowsSent     int64
+	RowsExamined int64
+}
+
+// Class is the summary of all queries sharing a fingerprint
+type Class struct {
+	ID           string        `json:"queryId" yaml:"queryId"`
+	Fingerprint  string        `json:"fingerprint" yaml:"fingerprint"`
+	Count        int           `json:"count" yaml:"count"`
+	Total        time.Duration `json:"-" yaml:"-"`
+	Avg          time.Duration `json:"-" yaml:"-"`
+	P95          time.Duration `json:"-" yaml:"-"`
+	Max          time.Duration `json:"-" yaml:"-"`
+	TotalSeconds float64       `json:"totalTimeSeconds" yaml:"totalTimeSeconds"`
+	AvgSeconds   float64       `json:"avgTimeSeconds" yaml:"avgTimeSeconds"`
+	P95Seconds   float64       `json:"p95TimeSeconds" yaml:"p95TimeSeconds"`
+	MaxSeconds   float64       `json:"maxTimeSeconds" yaml:"maxTimeSeconds"`
+	LockSeconds  float64       `json:"lockTimeSeconds,omitempty" yaml:"lockTimeSeconds,omitempty"`
+	RowsSent     int64         `json:"rowsSent" yaml:"rowsSent"`
+	RowsExamined int64         `json:"rowsExamined" yaml:"rowsExamined"`
+	Databases    []string      `json:"databases,omitempty" yaml:"databases,omitempty"`
+	FirstSeen    *time.Time    `json:"firstSeen,omitempty" yaml:"firstSeen,omitempty"`
+	LastSeen     *time.Time    `json:"lastSeen,omitempty" yaml:"lastSeen,omitempty"`
+	Example      string        `json:"example" yaml:"example"`
+
+	durations []time.Duration
+}
+
+// Report is the digest of a log
+type Report struct {
+	Queries      int           `json:"queries" yaml:"queries"`
+	Fingerprints int           `json:"fingerprints" yaml:"fingerprints"`
+	Total        time.Duration `json:"-" yaml:"-"`
+	TotalSeconds float64       `json:"totalTimeSeconds" yaml:"totalTimeSeconds"`
+	Classes      []*Class      `json:"classes" yaml:"classes"`
+}
+
+// Parse reads a log in format (MySQL or PostgreSQL)
+func Parse(r io.Reader, format string) ([]Query, error) {
+	if format == PostgreSQL {
+		return parsePostgreSQL(r)
+	}
+	return parseMySQL(r)
+}
+
+// Detect guesses the format from the start of a log; it returns "" when
+// the text has neither slow log headers nor duration lines
+func Detect(head []byte) string {
+	text := string(head)
+	switch {
+	case strings.Contains(text, "# Query_time:"):
+		return MySQL
+	case pgDuration.MatchString(text) || strings.Contains(text, "duration: "):
+		return PostgreSQL
+	}
+	return ""
+}
+
+// ============================================================================
+// MySQL / MariaDB slow query log
+// ============================================================================
+
+var mysqlHeaderField = regexp.MustCompile(`([A-Za-z_]+):\s+(\S+)`)
+
+func parseMySQL(r io.Reader) ([]Query, error) {
+	var queries []Query
+	var cur Query
+	var sql strings.Builder
+	database := ""
+	inHeader := false
+
+	flush := func() {
+		if text := strings.TrimSpace(sql.String()); text != "" {
+			cur.SQL = text
+			if cur.Database == "" {
+				cur.Database = database
+			}
+			queries = append(queries, cur)
+		}
+		sql.Reset()
+		cur = Query{}
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "# "):
+			if !inHeader {
+				flush()
+				inHeader = true
+			}
+			parseMySQLHeader(line[2:], &cur)
+			continue
+		case isMySQLBanner(line):
+			flush()
+			inHeader = false
+			continue
+		}
+		inHeader = false
+		trimmed := strings.TrimSpace(line)
+		lower := strings.ToLower(trimmed)
+		switch {
+		case strings.HasPrefix(lower, "use ") && strings.HasSuffix(lower, ";") && sql.Len() == 0:
+			database = strings.Trim(strings.TrimSuffix(trimmed[4:], ";"), "` ")
+			cur.Database = database
+		case strings.HasPrefix(lower, "set timestamp=") && sql.Len() == 0:
+			if ts, err := strconv.ParseInt(strings.TrimSuffix(trimmed[len("set timestamp="):], ";"), 10, 64); err == nil && cur.Time.IsZero() {
+				cur.Time = time.Unix(ts, 0).UTC()
+			}
+		default:
+			if sql.Len() > 0 {
+				sql.WriteByte('\n')
+			}
+			sql.WriteString(line)
+		}
+	}
+	flush()
+	return queries, scanner.Err()
+}
+
+func parseMySQLHeader(line string, q *Query) {
+	switch {
+	case strings.HasPrefix(line, "Time: "):
+		value := strings.TrimSpace(line[len("Time: "):])
+		for _, layout := range []string{time.RFC3339Nano, "060102 15:04:05", "060102  15:04:05"} {
+			if t, err := time.Parse(layout, value); err == nil {
+				q.Time = t
+				break
+			}
+		}
+		return
+	case strings.HasPrefix(line, "User@Host: "):
+		user := strings.TrimSpace(line[len("User@Host: "):])
+		if i := strings.IndexByte(user, '['); i > 0 {
+			user = user[:i]
+		}
+		q.User = user
+		return
+	}
+	for _, m := range mysqlHeaderField.FindAllStringSubmatch(line, -1) {
+		switch m[1] {
+		case "Query_time":
+			q.Duration = parseSeconds(m[2])
+		case "Lock_time":
+			q.LockTime = parseSeconds(m[2])
+		case "Rows_sent":
+			q.RowsSent, _ = strconv.ParseInt(m[2], 10, 64)
+		case "Rows_examined":
+			q.RowsExamined, _ = strconv.ParseInt(m[2], 10, 64)
+		case "Schema":
+			q.Database = m[2]
+		}
+	}
+}
+
+// isMySQLBanner reports the lines mysqld writes when it (re)opens the log
+func isMySQLBanner(line string) bool {
+	return strings.Contains(line, ", Version: ") && strings.Contains(line, "started with:") ||
+		strings.HasPrefix(line, "Tcp port: ") ||
+		strings.HasPrefix(line, "Time ") && strings.Contains(line, "Command") && strings.Contains(line, "Argument")
+}
+
+func parseSeconds(s string) time.Duration {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0
+	}
+	return time.Duration(f * float64(time.Second))
+}
+
+// ============================================================================
+// PostgreSQL log_min_duration_statement output
+// ============================================================================
+
+var (
+	pgDuration = regexp.MustCompile(`duration: ([0-9.]+) ms\s+(statement|execute [^:]*|parse [^:]*|bind [^:]*):\s?(.*)$`)
+	pgDatabase = regexp.MustCompile(`\bdb=([^\s,@\]]+)`)
+	pgUser     = regexp.MustCompile(`\buser=([^\s,@\]]+)`)
+	pgAtPrefix = regexp.MustCompile(`\b([A-Za-z0-9_\-]+)@([A-Za-z0-9_\-]+)\b`)
+	pgTime     = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d+)?)`)
+)
+
+func parsePostgreSQL(r io.Reader) ([]Query, error) {
+	var queries []Query
+	var cur *Query
+	var sql strings.Builder
+
+	flush := func() {
+		if cur != nil {
+			if text := strings.TrimSpace(sql.String()); text != "" {
+				cur.SQL = text
+				queries = append(queries, *cur)
+			}
+		}
+		cur = nil
+		sql.Reset()
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		// Statements spanning several lines continue with a tab
+		if cur != nil && strings.HasPrefix(line, "\t") {
+			sql.WriteByte('\n')
+			sql.WriteString(line[1:])
+			continue
+		}
+		flush()
+
+		m := pgDuration.FindStringSubmatch(line)
+		// With the extended query protocol parse and bind are logged
+		// separately; only the execution is counted
+		if m == nil || strings.HasPrefix(m[2], "parse") || strings.HasPrefix(m[2], "bind") {
+			continue
+		}
+		ms, _ := strconv.ParseFloat(m[1], 64)
+		q := &Query{Duration: time.Duration(ms * float64(time.Millisecond))}
+		prefix := line[:strings.Index(line, "duration: ")]
+		if t := pgTime.FindString(prefix); t != "" {
+			if parsed, err := time.Parse("2006-01-02 15:04:05.999999999", t); err == nil {
+				q.Time = parsed
+			}
+		}
+		if d := pgDatabase.FindStringSubmatch(prefix); d != nil {
+			q.Database = d[1]
+		}
+		if u := pgUser.FindStringSubmatch(prefix); u != nil {
+			q.User = u[1]
+		}
+		if q.Database == "" && q.User == "" {
+			// The common %u@%d prefix
+			if at := pgAtPrefix.FindStringSubmatch(prefix); at != nil {
+				q.User, q.Database = at[1], at[2]
+			}
+		}
+		cur = q
+		sql.WriteString(m[3])
+	}
+	flush()
+	return queries, scanner.Err()
+}
+
+// ============================================================================
+// Fingerprints
+// ============================================================================
+
+var (
+	fpBlockComment = regexp.MustCompile(`(?s)/\*.*?\*/`)
+	fpLineComment  = regexp.MustCompile(`(?m)(--|#)[^\n]*$`)
+	fpSingleQuoted = regexp.MustCompile(`'(?:[^'\\]|\\.|'')*'`)
+	fpDoubleQuoted = regexp.MustCompile(`"(?:[^"\\]|\\.|"")*"`)
+	fpHex          = regexp.MustCompile(`\b0x[0-9a-fA-F]+\b`)
+	fpNumber       = regexp.MustCompile(`([^\w$.]|^)[-+]?\d+(?:\.\d+)?(?:[eE][-+]?\d+)?\b`)
+	fpPlaceholder  = regexp.MustCompile(`\$\d+`)
+	fpList         = regexp.MustCompile(`\(\s*\?(?:\s*,\s*\?)*\s*\)`)
+	fpValues       = regexp.MustCompile(`(values|value)\s*\(\?\+?\)(?:\s*,\s*\(\?\+?\))*`)
+	fpOperator     = regexp.MustCompile(`\s*(<=|>=|<>|!=|:=|=|<|>)\s*`)
+	fpComma        = regexp.MustCompile(`\s*,\s*`)
+	fpSpace        = regexp.MustCompile(`\s+`)
+)
+
+// Fingerprint normalizes a statement: comments are removed, literals and
+// bind parameters become ?, lists of values collapse to (?+), and the text is
+// lower-cased with single spaces around operators and after commas. In MySQL double-quoted text is a string
+// literal; in PostgreSQL it is an identifier and is kept.
+func Fingerprint(sql, format string) string {
+	s := fpBlockComment.ReplaceAllString(sql, " ")
+	s = fpSingleQuoted.ReplaceAllString(s, "?")
+	if format != PostgreSQL {
+		s = fpDoubleQuoted.ReplaceAllString(s, "?")
+	}
+	s = fpLineComment.ReplaceAllString(s, "")
+	s = fpHex.ReplaceAllString(s, "?")
+	s = fpPlaceholder.ReplaceAllString(s, "?")
+	s = fpNumber.ReplaceAllString(s, "${1}?")
+	s = fpOperator.ReplaceAllString(s, " $1 ")
+	s = fpComma.ReplaceAllString(s, ", ")
+	s = strings.ToLower(fpSpace.ReplaceAllString(s, " "))
+	s = fpList.ReplaceAllString(s, "(?+)")
+	s = fpValues.ReplaceAllString(s, "$1 (?+)")
+	s = strings.TrimSpace(strings.TrimRight(strings.TrimSpace(s), ";"))
+	return s
+}
+
+// QueryID is a short stable identifier of a fingerprint
+func QueryID(fingerprint string) string {
+	sum := sha256.Sum256([]byte(fingerprint))
+	return strings.ToUpper(hex.EncodeToString(sum[:8]))
+}
+
+// ============================================================================
+// Digest
+// ============================================================================
+
+// Digest groups queries by fingerprint. Classes are ordered by total time.
+func Digest(queries []Query, format string) *Report {
+	classes := map[string]*Class{}
+	report := &Report{Queries: len(queries)}
+	for _, q := range queries {
+		fp := Fingerprint(q.SQL, format)
+		c, ok := classes[fp]
+		if !ok {
+			c = &Class{ID: QueryID(fp), Fingerprint: fp, Example: q.SQL}
+			classes[fp] = c
+		}
+		c.Count++
+		c.Total += q.Duration
+		c.LockSeconds += q.LockTime.Seconds()
+		c.RowsSent += q.RowsSent
+		c.RowsExamined += q.RowsExamined
+		c.durations = append(c.durations, q.Duration)
+		if q.Duration > c.Max {
+			c.Max = q.Duration
+			c.Example = q.SQL
+		}
+		if q.Database != "" && !slices.Contains(c.Databases, q.Database) {
+			c.Databases = append(c.Databases, q.Database)
+		}
+		if !q.Time.IsZero() {
+			t := q.Time
+			if c.FirstSeen == nil || t.Before(*c.FirstSeen) {
+				c.FirstSeen = &t
+			}
+			if c.LastSeen == nil || t.After(*c.LastSeen) {
+				c.LastSeen = &t
+			}
+		}
+		report.Total += q.Duration
+	}
+
+	for _, c := range classes {
+		c.Avg = c.Total / time.Duration(c.Count)
+		c.P95 = percentile(c.durations, 0.95)
+		c.TotalSeconds = c.Total.Seconds()
+		c.AvgSeconds = c.Avg.Seconds()
+		c.P95Seconds = c.P95.Seconds()
+		c.MaxSeconds = c.Max.Seconds()
+		sort.Strings(c.Databases)
+		report.Classes = append(report.Classes, c)
+	}
+	report.Fingerprints = len(report.Classes)
+	report.TotalSeconds = report.Total.Seconds()
+	Sort(report.Classes, "total")
+	return report
+}
+
+// SortKeys are the orders Sort accepts
+var SortKeys = []string{"total", "count", "avg", "p95", "max", "rows-examined"}
+
+// Sort orders classes by key, largest first; unknown keys sort by total time
+func Sort(classes []*Class, key string) {
+	value := func(c *Class) float64 {
+		switch key {
+		case "count":
+			return float64(c.Count)
+		case "avg":
+			return c.AvgSeconds
+		case "p95":
+			return c.P95Seconds
+		case "max":
+			return c.MaxSeconds
+		case "rows-examined":
+			return float64(c.RowsExamined)
+		}
+		return c.TotalSeconds
+	}
+	sort.SliceStable(classes, func(i, j int) bool {
+		vi, vj := value(classes[i]), value(classes[j])
+		if vi != vj {
+			return vi > vj
+		}
+		return classes[i].ID < classes[j].ID
+	})
+}
+
+// percentile returns the nearest-rank percentile p (0..1) of durations
+func percentile(durations []time.Duration, p float64) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	sorted := slices.Sorted(slices.Values(durations))
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	return sorted[min(max(rank, 0), len(sorted)-1)]
+}
@@ -0,0 +1,195 @@
+// Package sqldump writes portable logical dumps (plain SQL) of MySQL,
+// MariaDB and PostgreSQL databases with the built-in drivers, and restores
+// them. Dumps are read in one consistent snapshot transaction and contain
+// multi-row INSERT statements of a bounded size.
+package sqldump
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/haung921209/nhn-cloud-cli/internal/sqlshell"
+)
+
+// Options controls what a dump contains
+type Options struct {
+	// Tables limits the dump to these tables ("table" or "schema.table");
+	// all tables when empty
+	Tables     []string
+	SchemaOnly bool
+	DataOnly   bool
+	// RowsPerInsert is the number of rows per INSERT statement (default 500)
+	RowsPerInsert int
+	// Progress receives one line per table; may be nil
+	Progress io.Writer
+}
+
+// Stats summarizes a dump
+type Stats struct {
+	Tables int   `json:"tables"`
+	Views  int   `json:"views"`
+	Rows   int64 `json:"rows"`
+}
+
+// maxInsertBytes bounds the size of one INSERT statement, so that restores
+// stay below the server's max_allowed_packet
+const maxInsertBytes = 1 << 20
+
+func (o *Options) rowsPerInsert() int {
+	if o.RowsPerInsert <= 0 {
+		return 500
+	}
+	return o.RowsPerInsert
+}
+
+func (o *Options) progress(format string, args ...interface{}) {
+	if o.Progress != nil {
+		fmt.Fprintf(o.Progress, format+"\n", args...)
+	}
+}
+
+// includes reports whether a table is selected by Tables
+func (o *Options) includes(schema, table string) bool {
+	if len(o.Tables) == 0 {
+		return true
+	}
+	for _, t := range o.Tables {
+		if t == table || (schema != "" && t == schema+"."+table) {
+			return true
+		}
+	}
+	return false
+}
+
+// writer remembers the first write error so that dump code can print
+// freely and check once
+type writer struct {
+	w   *bufio.Writer
+	err error
+}
+
+func newWriter(w io.Writer) *writer {
+	return &writer{w: bufio.NewWriterSize(w, 256*1024)}
+}
+
+func (w *writer) printf(format string, args ...interface{}) {
+	if w.err == nil {
+		_, w.err = fmt.Fprintf(w.w, format, args...)
+	}
+}
+
+func (w *writer) write(s string) {
+	if w.err == nil {
+		_, w.err = w.w.WriteString(s)
+	}
+}
+
+func (w *writer) flush() error {
+	if w.err == nil {
+		w.err = w.w.Flush()
+	}
+	return w.err
+}
+
+// inserter batches rows into multi-row INSERT statements
+type inserter struct {
+	w       *writer
+	prefix  string
+	limit   int
+	rows    int
+	bytes   int
+	written int64
+}
+
+func (ins *inserter) add(tuple string) {
+	if ins.rows > 0 && (ins.rows >= ins.limit || ins.bytes+len(tuple) > maxInsertBytes) {
+		ins.end()
+	}
+	if ins.rows == 0 {
+		ins.w.write(ins.prefix)
+		ins.w.write(" VALUES\n")
+	} else {
+		ins.w.write(",\n")
+	}
+	ins.w.write(tuple)
+	ins.rows++
+	ins.bytes += len(tuple)
+	ins.written++
+}
+
+func (ins *inserter) end() {
+	if ins.rows > 0 {
+		ins.w.write(";\n")
+		ins.rows, ins.bytes = 0, 0
+	}
+}
+
+// Restore executes the statements of a dump read from r on one session and
+// stops at the first error. It returns the number of statements executed.
+func Restore(ctx context.Context, db *sql.DB, syntax sqlshell.Syntax, r io.Reader, progress io.Writer) (int, error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	executed := 0
+	lastReport := time.Now()
+	exec := func(stmt sqlshell.Statement) error {
+		if _, err := conn.ExecContext(ctx, stmt.SQL); err != nil {
+			sample := strings.Join(strings.Fields(stmt.SQL), " ")
+			if len(sample) > 80 {
+				sample = sample[:80] + "..."
+			}
+			return fmt.Errorf("statement %d (%s): %w", executed+1, sample, err)
+		}
+		executed++
+		if progress != nil && time.Since(lastReport) > 5*time.Second {
+			fmt.Fprintf(progress, "  %d statements executed...\n", executed)
+			lastReport = time.Now()
+		}
+		return nil
+	}
+
+	// Statements are split as they are read; the splitter only runs when a
+	// line could end a statement
+	reader := bufio.NewReaderSize(r, 256*1024)
+	delimiter := ";"
+	var buf strings.Builder
+	for {
+		line, readErr := reader.ReadString('\n')
+		buf.WriteString(line)
+		trimmed := strings.TrimSpace(line)
+		if readErr == nil && !strings.HasSuffix(trimmed, delimiter) && !strings.HasPrefix(strings.ToLower(trimmed), "delimiter ") {
+			continue
+		}
+
+		var stmts []sqlshell.Statement
+		var rest string
+		stmts, rest, delimiter = sqlshell.Split(buf.String(), syntax, delimiter)
+		buf.Reset()
+		buf.WriteString(rest)
+		for _, stmt := range stmts {
+			if err := exec(stmt); err != nil {
+				return executed, err
+			}
+		}
+
+		if readErr == io.EOF {
+			if !sqlshell.IsBlank(rest, syntax) {
+				if err := exec(sqlshell.Statement{SQL: strings.TrimSpace(rest)}); err != nil {
+					return executed, err
+				}
+			}
+			return executed, nil
+		}
+		if readErr != nil {
+			return executed, readErr
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package sqldump
+
+import (
+	"context"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// definerClause is removed from view definitions so that dumps restore
+// under any account
+var definerClause = regexp.MustCompile("DEFINER=(`[^`]*`|[^ ]+)@(`[^`]*`|[^ ]+) ")
+
+// DumpMySQL dumps the current database of db (MySQL or MariaDB): tables
+// with their data, then views. Like mysqldump --single-transaction, rows
+// are read in one REPEATABLE READ snapshot.
+func DumpMySQL(ctx context.Context, db *sql.DB, w io.Writer, opts Options) (*Stats, error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	for _, stmt := range []string{
+		"SET SESSION TRANSACTION ISOLATION LEVEL REPEATABLE READ",
+		"SET SESSION time_zone = '+00:00'",
+		"SET NAMES utf8mb4",
+		"START TRANSACTION WITH CONSISTENT SNAPSHOT",
+	} {
+		if _, err := conn.ExecContext(ctx, stmt); err != nil {
+			return nil, fmt.Errorf("%s: %w", stmt, err)
+		}
+	}
+	defer conn.ExecContext(context.Background(), "ROLLBACK")
+
+	var database, version string
+	if err := conn.QueryRowContext(ctx, "SELECT DATABASE(), VERSION()").Scan(&database, &version); err != nil {
+		return nil, err
+	}
+
+	out := newWriter(w)
+	out.printf("-- nhncloud logical dump\n-- Database: %s\n-- Server version: %s\n-- Created: %s\n\n",
+		database, version, time.Now().UTC().Format(time.RFC3339))
+	out.write("SET NAMES utf8mb4;\nSET TIME_ZONE = '+00:00';\nSET SQL_MODE = 'NO_AUTO_VALUE_ON_ZERO';\n" +
+		"SET FOREIGN_KEY_CHECKS = 0;\nSET UNIQUE_CHECKS = 0;\n\n")
+
+	type object struct{ name, kind string }
+	var objects []object
+	rows, err := conn.QueryContext(ctx, "SHOW FULL TABLES")
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var o object
+		if err := rows.Scan(&o.name, &o.kind); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if opts.includes(database, o.name) {
+			objects = append(objects, o)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	stats := &Stats{}
+	for _, o := range objects {
+		if o.kind != "BASE TABLE" {
+			continue
+		}
+		stats.Tables++
+		if !opts.DataOnly {
+			var name, create string
+			if err := conn.QueryRowContext(ctx, "SHOW CREATE TABLE "+mysqlIdent(o.name)).Scan(&name, &create); err != nil {
+				return nil, fmt.Errorf("table %s: %w", o.name, err)
+			}
+			out.printf("--\n-- Table %s\n--\n\nDROP TABLE IF EXISTS %s;\n%s;\n\n", mysqlIdent(o.name), mysqlIdent(o.name), create)
+		}
+		if !opts.SchemaOnly {
+			n, err := dumpMySQLRows(ctx, conn, out, o.name, opts)
+			if err != nil {
+				return nil, fmt.Errorf("table %s: %w", o.name, err)
+			}
+			stats.Rows += n
+			opts.progress("  %s: %d rows", o.name, n)
+		}
+		if out.err != nil {
+			return nil, out.err
+		}
+	}
+
+	// Views last, since they may refer to any table
+	if !opts.DataOnly {
+		for _, o := range objects {
+			if o.kind != "VIEW" {
+				continue
+			}
+			var name, create, charset, collation string
+			if err := conn.QueryRowContext(ctx, "SHOW CREATE VIEW "+mysqlIdent(o.name)).Scan(&name, &create, &charset, &collation); err != nil {
+				return nil, fmt.Errorf("view %s: %w", o.name, err)
+			}
+			out.printf("DROP VIEW IF EXISTS %s;\n%s;\n\n", mysqlIdent(o.name), definerClause.ReplaceAllString(create, ""))
+			stats.Views++
+		}
+	}
+
+	out.write("SET FOREIGN_KEY_CHECKS = 1;\nSET UNIQUE_CHECKS = 1;\n")
+	return stats, out.flush()
+}
+
+func dumpMySQLRows(ctx context.Context, conn *sql.Conn, out *writer, table string, opts Options) (int64, error) {
+	// Generated columns are computed on restore; invisible columns are only
+	// returned when named
+	var columns []string
+	rows, err := conn.QueryContext(ctx, `SELECT COLUMN_NAME, EXTRA FROM information_schema.COLUMNS
+WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? ORDER BY ORDINAL_POSITION`, table)
+	if err != nil {
+		return 0, err
+	}
+	for rows.Next() {
+		var name, extra string
+		if err := rows.Scan(&name, &extra); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		if !strings.Contains(strings.ToUpper(extra), "GENERATED") {
+			columns = append(columns, mysqlIdent(name))
+		}
+	}
+	rows.Close()
+	if len(columns) == 0 {
+		return 0, rows.Err()
+	}
+
+	list := strings.Join(columns, ", ")
+	rows, err = conn.QueryContext(ctx, "SELECT "+list+" FROM "+mysqlIdent(table))
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	types, err := rows.ColumnTypes()
+	if err != nil {
+		return 0, err
+	}
+	kinds := make([]byte, len(types))
+	for i, t := range types {
+		kinds[i] = mysqlValueKind(t.DatabaseTypeName())
+	}
+
+	ins := &inserter{w: out, prefix: "INSERT INTO " + mysqlIdent(table) + " (" + list + ")", limit: opts.rowsPerInsert()}
+	values := make([]sql.RawBytes, len(columns))
+	pointers := make([]interface{}, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	var tuple strings.Builder
+	for rows.Next() {
+		if err := rows.Scan(pointers...); err != nil {
+			return ins.written, err
+		}
+		tuple.Reset()
+		tuple.WriteByte('(')
+		for i, v := range values {
+			if i > 0 {
+				tuple.WriteByte(',')
+			}
+			tuple.WriteString(mysqlLiteral(v, kinds[i]))
+		}
+		tuple.WriteByte(')')
+		ins.add(tuple.String())
+		if out.err != nil {
+			return ins.written, out.err
+		}
+	}
+	ins.end()
+	if ins.written > 0 {
+		out.write("\n")
+	}
+	return ins.written, rows.Err()
+}
+
+// mysqlValueKind classifies a column type: 'n' numbers (unquoted), 'b'
+// binary data (hex literal), 's' everything else (quoted string)
+func mysqlValueKind(typeName string) byte {
+	t := strings.ToUpper(typeName)
+	switch {
+	case strings.Contains(t, "BLOB"), strings.Contains(t, "BINARY"), t == "BIT", t == "GEOMETRY":
+		return 'b'
+	case strings.Contains(t, "INT"), t == "DECIMAL", t == "FLOAT", t == "DOUBLE", t == "YEAR":
+		return 'n'
+	}
+	return 's'
+}
+
+var mysqlEscaper = strings.NewReplacer(`\`, `\\`, `'`, `\'`, "\x00", `\0`, "\n", `\n`, "\r", `\r`, "\x1a", `\Z`)
+
+func mysqlLiteral(v sql.RawBytes, kind byte) string {
+	switch {
+	case v == nil:
+		return "NULL"
+	case kind == 'n':
+		return string(v)
+	case kind == 'b':
+		if len(v) == 0 {
+			return "''"
+		}
+		return "0x" + hex.EncodeToString(v)
+	}
+	return "'" + mysqlEscaper.Replace(string(v)) + "'"
+}
+
+func mysqlIdent(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
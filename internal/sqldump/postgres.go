@@ -0,0 +1,490 @@
+package sqldump
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Filters for user objects in the catalog queries below
+const (
+	pgUserSchema    = `n.nspname NOT IN ('pg_catalog', 'information_schema') AND n.nspname NOT LIKE 'pg\_toast%' AND n.nspname NOT LIKE 'pg\_temp%'`
+	pgNotExtMember  = `NOT EXISTS (SELECT 1 FROM pg_catalog.pg_depend d WHERE d.classid = 'pg_catalog.pg_class'::pg_catalog.regclass AND d.objid = c.oid AND d.deptype = 'e')`
+	pgNotExtMemberT = `NOT EXISTS (SELECT 1 FROM pg_catalog.pg_depend d WHERE d.classid = 'pg_catalog.pg_type'::pg_catalog.regclass AND d.objid = t.oid AND d.deptype = 'e')`
+)
+
+type pgTable struct {
+	oid, schema, name string
+	columns           []pgColumn
+}
+
+type pgColumn struct {
+	name, typ, collation, def, identity, generated string
+	notNull                                        bool
+}
+
+type pgSequence struct {
+	oid, schema, name string
+	definition        string
+	// Owning column, for identity and serial sequences
+	identity                     bool
+	ownerOID, ownerTable, column string
+}
+
+// DumpPostgreSQL dumps the current database of db: schemas, extensions, enum
+// types, sequences, tables with their data, indexes, foreign keys and views.
+// Everything is read in one REPEATABLE READ, READ ONLY transaction and all
+// names in the dump are schema-qualified. Partitioned tables, functions and
+// triggers are not dumped.
+func DumpPostgreSQL(ctx context.Context, db *sql.DB, w io.Writer, opts Options) (*Stats, error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	for _, stmt := range []string{
+		"BEGIN ISOLATION LEVEL REPEATABLE READ, READ ONLY",
+		"SET extra_float_digits = 3",
+		"SET DateStyle = ISO",
+		"SET IntervalStyle = postgres",
+		"SELECT pg_catalog.set_config('search_path', '', true)",
+	} {
+		if _, err := conn.ExecContext(ctx, stmt); err != nil {
+			return nil, fmt.Errorf("%s: %w", stmt, err)
+		}
+	}
+	defer conn.ExecContext(context.Background(), "ROLLBACK")
+
+	var database, version string
+	var versionNum int
+	if err := conn.QueryRowContext(ctx, "SELECT pg_catalog.current_database(), pg_catalog.current_setting('server_version'), pg_catalog.current_setting('server_version_num')::int").
+		Scan(&database, &version, &versionNum); err != nil {
+		return nil, err
+	}
+	d := &pgDumper{conn: conn, opts: opts, versionNum: versionNum}
+
+	tables, err := d.tables(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sequences, err := d.sequences(ctx, tables)
+	if err != nil {
+		return nil, err
+	}
+
+	out := newWriter(w)
+	out.printf("-- nhncloud logical dump\n-- Database: %s\n-- Server version: %s\n-- Created: %s\n\n",
+		database, version, time.Now().UTC().Format(time.RFC3339))
+	out.write("SET statement_timeout = 0;\nSET client_encoding = 'UTF8';\nSET standard_conforming_strings = on;\n" +
+		"SET check_function_bodies = false;\nSELECT pg_catalog.set_config('search_path', '', false);\n\n")
+
+	stats := &Stats{Tables: len(tables)}
+	var views [][]string
+	if !opts.DataOnly {
+		if views, err = d.rows(ctx, `SELECT n.nspname, c.relname, c.relkind::text, pg_catalog.pg_get_viewdef(c.oid)
+FROM pg_catalog.pg_class c JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+WHERE c.relkind IN ('v', 'm') AND `+pgUserSchema+` AND `+pgNotExtMember+` ORDER BY c.oid`); err != nil {
+			return nil, err
+		}
+		selected := views[:0]
+		for _, v := range views {
+			if opts.includes(v[0], v[1]) {
+				selected = append(selected, v)
+			}
+		}
+		views = selected
+		stats.Views = len(views)
+
+		if err := d.writeSchema(ctx, out, tables, sequences, views); err != nil {
+			return nil, err
+		}
+	}
+
+	if !opts.SchemaOnly {
+		for i := range tables {
+			n, err := d.writeRows(ctx, out, &tables[i])
+			if err != nil {
+				return nil, fmt.Errorf("table %s.%s: %w", tables[i].schema, tables[i].name, err)
+			}
+			stats.Rows += n
+			opts.progress("  %s.%s: %d rows", tables[i].schema, tables[i].name, n)
+			if out.err != nil {
+				return nil, out.err
+			}
+		}
+		for _, s := range sequences {
+			var last string
+			var called bool
+			if err := conn.QueryRowContext(ctx, "SELECT last_value::text, is_called FROM "+pgQualified(s.schema, s.name)).Scan(&last, &called); err != nil {
+				return nil, fmt.Errorf("sequence %s.%s: %w", s.schema, s.name, err)
+			}
+			target := pgLiteral(pgQualified(s.schema, s.name))
+			if s.identity {
+				target = fmt.Sprintf("pg_catalog.pg_get_serial_sequence(%s, %s)", pgLiteral(s.ownerTable), pgLiteral(s.column))
+			}
+			out.printf("SELECT pg_catalog.setval(%s, %s, %t);\n", target, last, called)
+		}
+		if len(sequences) > 0 {
+			out.write("\n")
+		}
+	}
+
+	if !opts.DataOnly {
+		if err := d.writeConstraints(ctx, out, tables, sequences, views); err != nil {
+			return nil, err
+		}
+	}
+	return stats, out.flush()
+}
+
+type pgDumper struct {
+	conn       *sql.Conn
+	opts       Options
+	versionNum int
+}
+
+// rows runs a catalog query and returns its values as strings (NULL as "")
+func (d *pgDumper) rows(ctx context.Context, query string, args ...interface{}) ([][]string, error) {
+	rows, err := d.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	var result [][]string
+	values := make([]sql.NullString, len(columns))
+	pointers := make([]interface{}, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, err
+		}
+		row := make([]string, len(values))
+		for i, v := range values {
+			row[i] = v.String
+		}
+		result = append(result, row)
+	}
+	return result, rows.Err()
+}
+
+func (d *pgDumper) tables(ctx context.Context) ([]pgTable, error) {
+	rows, err := d.rows(ctx, `SELECT c.oid::text, n.nspname, c.relname, c.relkind::text
+FROM pg_catalog.pg_class c JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+WHERE c.relkind IN ('r', 'p') AND NOT c.relispartition AND `+pgUserSchema+` AND `+pgNotExtMember+`
+ORDER BY n.nspname, c.relname`)
+	if err != nil {
+		return nil, err
+	}
+
+	generated := "a.attgenerated::text"
+	if d.versionNum < 120000 {
+		generated = "''"
+	}
+	var tables []pgTable
+	for _, row := range rows {
+		if !d.opts.includes(row[1], row[2]) {
+			continue
+		}
+		if row[3] == "p" {
+			d.opts.progress("  warning: skipping partitioned table %s.%s and its partitions (not supported)", row[1], row[2])
+			continue
+		}
+		t := pgTable{oid: row[0], schema: row[1], name: row[2]}
+		columns, err := d.rows(ctx, `SELECT a.attname, pg_catalog.format_type(a.atttypid, a.atttypmod),
+  CASE WHEN a.attcollation <> ty.typcollation THEN pg_catalog.quote_ident(cn.nspname) || '.' || pg_catalog.quote_ident(co.collname) END,
+  pg_catalog.pg_get_expr(ad.adbin, ad.adrelid), a.attnotnull::text, a.attidentity::text, `+generated+`
+FROM pg_catalog.pg_attribute a
+JOIN pg_catalog.pg_type ty ON ty.oid = a.atttypid
+LEFT JOIN pg_catalog.pg_attrdef ad ON ad.adrelid = a.attrelid AND ad.adnum = a.attnum
+LEFT JOIN pg_catalog.pg_collation co ON co.oid = a.attcollation
+LEFT JOIN pg_catalog.pg_namespace cn ON cn.oid = co.collnamespace
+WHERE a.attrelid = $1::oid AND a.attnum > 0 AND NOT a.attisdropped ORDER BY a.attnum`, t.oid)
+		if err != nil {
+			return nil, fmt.Errorf("table %s.%s: %w", t.schema, t.name, err)
+		}
+		for _, c := range columns {
+			t.columns = append(t.columns, pgColumn{
+				name: c[0], typ: c[1], collation: c[2], def: c[3],
+				notNull: c[4] == "true", identity: c[5], generated: c[6],
+			})
+		}
+		tables = append(tables, t)
+	}
+	return tables, nil
+}
+
+// sequences returns the sequences to dump: all of them, or with Options.Tables
+// only those owned by a dumped table
+func (d *pgDumper) sequences(ctx context.Context, tables []pgTable) ([]pgSequence, error) {
+	rows, err := d.rows(ctx, `SELECT c.oid::text, n.nspname, c.relname,
+  'AS ' || pg_catalog.format_type(s.seqtypid, NULL) || ' START WITH ' || s.seqstart || ' INCREMENT BY ' || s.seqincrement ||
+  ' MINVALUE ' || s.seqmin || ' MAXVALUE ' || s.seqmax || ' CACHE ' || s.seqcache || CASE WHEN s.seqcycle THEN ' CYCLE' ELSE ' NO CYCLE' END,
+  dep.deptype::text, dep.refobjid::text, pg_catalog.quote_ident(tn.nspname) || '.' || pg_catalog.quote_ident(t.relname), a.attname
+FROM pg_catalog.pg_sequence s
+JOIN pg_catalog.pg_class c ON c.oid = s.seqrelid
+JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+LEFT JOIN pg_catalog.pg_depend dep ON dep.classid = 'pg_catalog.pg_class'::pg_catalog.regclass AND dep.objid = c.oid
+  AND dep.refclassid = 'pg_catalog.pg_class'::pg_catalog.regclass AND dep.deptype IN ('a', 'i')
+LEFT JOIN pg_catalog.pg_class t ON t.oid = dep.refobjid
+LEFT JOIN pg_catalog.pg_namespace tn ON tn.oid = t.relnamespace
+LEFT JOIN pg_catalog.pg_attribute a ON a.attrelid = dep.refobjid AND a.attnum = dep.refobjsubid
+WHERE `+pgUserSchema+` AND `+pgNotExtMember+` ORDER BY n.nspname, c.relname`)
+	if err != nil {
+		return nil, err
+	}
+
+	dumped := make(map[string]bool, len(tables))
+	for _, t := range tables {
+		dumped[t.oid] = true
+	}
+	var sequences []pgSequence
+	for _, row := range rows {
+		s := pgSequence{oid: row[0], schema: row[1], name: row[2], definition: row[3],
+			identity: row[4] == "i", ownerOID: row[5], ownerTable: row[6], column: row[7]}
+		if s.ownerOID != "" && !dumped[s.ownerOID] {
+			continue
+		}
+		if s.ownerOID == "" && len(d.opts.Tables) > 0 {
+			continue
+		}
+		sequences = append(sequences, s)
+	}
+	return sequences, nil
+}
+
+func (d *pgDumper) writeSchema(ctx context.Context, out *writer, tables []pgTable, sequences []pgSequence, views [][]string) error {
+	filtered := len(d.opts.Tables) > 0
+
+	// Drop in dependency order so that the dump can be restored over an
+	// existing copy
+	for i := len(views) - 1; i >= 0; i-- {
+		kind := "VIEW"
+		if views[i][2] == "m" {
+			kind = "MATERIALIZED VIEW"
+		}
+		out.printf("DROP %s IF EXISTS %s CASCADE;\n", kind, pgQualified(views[i][0], views[i][1]))
+	}
+	for _, t := range tables {
+		out.printf("DROP TABLE IF EXISTS %s CASCADE;\n", pgQualified(t.schema, t.name))
+	}
+	for _, s := range sequences {
+		if !s.identity {
+			out.printf("DROP SEQUENCE IF EXISTS %s CASCADE;\n", pgQualified(s.schema, s.name))
+		}
+	}
+
+	// Enum types are only recreated in full dumps: dropping one would drop
+	// the columns of tables outside a --tables selection
+	var enums [][]string
+	if !filtered {
+		var err error
+		if enums, err = d.rows(ctx, `SELECT n.nspname, t.typname,
+  pg_catalog.array_to_string(ARRAY(SELECT pg_catalog.quote_literal(e.enumlabel) FROM pg_catalog.pg_enum e WHERE e.enumtypid = t.oid ORDER BY e.enumsortorder), ', ')
+FROM pg_catalog.pg_type t JOIN pg_catalog.pg_namespace n ON n.oid = t.typnamespace
+WHERE t.typtype = 'e' AND `+pgUserSchema+` AND `+pgNotExtMemberT+` ORDER BY n.nspname, t.typname`); err != nil {
+			return err
+		}
+		for _, e := range enums {
+			out.printf("DROP TYPE IF EXISTS %s CASCADE;\n", pgQualified(e[0], e[1]))
+		}
+	}
+	out.write("\n")
+
+	schemas, err := d.rows(ctx, `SELECT n.nspname FROM pg_catalog.pg_namespace n
+WHERE n.nspname <> 'public' AND `+pgUserSchema+` AND NOT EXISTS (SELECT 1 FROM pg_catalog.pg_depend d
+  WHERE d.classid = 'pg_catalog.pg_namespace'::pg_catalog.regclass AND d.objid = n.oid AND d.deptype = 'e')
+ORDER BY n.nspname`)
+	if err != nil {
+		return err
+	}
+	for _, s := range schemas {
+		out.printf("CREATE SCHEMA IF NOT EXISTS %s;\n", pgIdent(s[0]))
+	}
+	extensions, err := d.rows(ctx, `SELECT e.extname, n.nspname FROM pg_catalog.pg_extension e
+JOIN pg_catalog.pg_namespace n ON n.oid = e.extnamespace WHERE e.extname <> 'plpgsql' ORDER BY e.extname`)
+	if err != nil {
+		return err
+	}
+	for _, e := range extensions {
+		out.printf("CREATE EXTENSION IF NOT EXISTS %s WITH SCHEMA %s;\n", pgIdent(e[0]), pgIdent(e[1]))
+	}
+	for _, e := range enums {
+		out.printf("CREATE TYPE %s AS ENUM (%s);\n", pgQualified(e[0], e[1]), e[2])
+	}
+	for _, s := range sequences {
+		if !s.identity {
+			out.printf("CREATE SEQUENCE %s %s;\n", pgQualified(s.schema, s.name), s.definition)
+		}
+	}
+	out.write("\n")
+
+	for _, t := range tables {
+		var lines []string
+		for _, c := range t.columns {
+			def := pgIdent(c.name) + " " + c.typ
+			if c.collation != "" {
+				def += " COLLATE " + c.collation
+			}
+			switch {
+			case c.generated == "s":
+				def += " GENERATED ALWAYS AS (" + c.def + ") STORED"
+			case c.identity == "a":
+				def += " GENERATED ALWAYS AS IDENTITY"
+			case c.identity == "d":
+				def += " GENERATED BY DEFAULT AS IDENTITY"
+			case c.def != "":
+				def += " DEFAULT " + c.def
+			}
+			if c.notNull {
+				def += " NOT NULL"
+			}
+			lines = append(lines, def)
+		}
+		constraints, err := d.rows(ctx, `SELECT conname, pg_catalog.pg_get_constraintdef(oid) FROM pg_catalog.pg_constraint
+WHERE conrelid = $1::oid AND contype IN ('p', 'u', 'c', 'x') ORDER BY contype, conname`, t.oid)
+		if err != nil {
+			return fmt.Errorf("table %s.%s: %w", t.schema, t.name, err)
+		}
+		for _, c := range constraints {
+			lines = append(lines, "CONSTRAINT "+pgIdent(c[0])+" "+c[1])
+		}
+		out.printf("--\n-- Table %s\n--\n\nCREATE TABLE %s (\n    %s\n);\n\n",
+			pgQualified(t.schema, t.name), pgQualified(t.schema, t.name), strings.Join(lines, ",\n    "))
+	}
+	return out.err
+}
+
+func (d *pgDumper) writeRows(ctx context.Context, out *writer, t *pgTable) (int64, error) {
+	var names, selects []string
+	overriding := ""
+	for _, c := range t.columns {
+		if c.generated == "s" {
+			continue
+		}
+		if c.identity == "a" {
+			overriding = " OVERRIDING SYSTEM VALUE"
+		}
+		names = append(names, pgIdent(c.name))
+		selects = append(selects, pgIdent(c.name)+"::text")
+	}
+	if len(names) == 0 {
+		return 0, nil
+	}
+
+	rows, err := d.conn.QueryContext(ctx, "SELECT "+strings.Join(selects, ", ")+" FROM ONLY "+pgQualified(t.schema, t.name))
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	ins := &inserter{w: out, limit: d.opts.rowsPerInsert(),
+		prefix: "INSERT INTO " + pgQualified(t.schema, t.name) + " (" + strings.Join(names, ", ") + ")" + overriding}
+	values := make([]sql.NullString, len(names))
+	pointers := make([]interface{}, len(names))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	var tuple strings.Builder
+	for rows.Next() {
+		if err := rows.Scan(pointers...); err != nil {
+			return ins.written, err
+		}
+		tuple.Reset()
+		tuple.WriteByte('(')
+		for i, v := range values {
+			if i > 0 {
+				tuple.WriteByte(',')
+			}
+			if v.Valid {
+				tuple.WriteString(pgLiteral(v.String))
+			} else {
+				tuple.WriteString("NULL")
+			}
+		}
+		tuple.WriteByte(')')
+		ins.add(tuple.String())
+		if out.err != nil {
+			return ins.written, out.err
+		}
+	}
+	ins.end()
+	if ins.written > 0 {
+		out.write("\n")
+	}
+	return ins.written, rows.Err()
+}
+
+// writeConstraints writes what is created after the data: sequence
+// ownership, indexes, foreign keys and views
+func (d *pgDumper) writeConstraints(ctx context.Context, out *writer, tables []pgTable, sequences []pgSequence, views [][]string) error {
+	dumped := make(map[string]bool, len(tables))
+	for _, t := range tables {
+		dumped[t.oid] = true
+	}
+
+	for _, s := range sequences {
+		if !s.identity && s.ownerTable != "" {
+			out.printf("ALTER SEQUENCE %s OWNED BY %s.%s;\n", pgQualified(s.schema, s.name), s.ownerTable, pgIdent(s.column))
+		}
+	}
+	for _, t := range tables {
+		indexes, err := d.rows(ctx, `SELECT pg_catalog.pg_get_indexdef(i.indexrelid) FROM pg_catalog.pg_index i
+WHERE i.indrelid = $1::oid AND NOT EXISTS (SELECT 1 FROM pg_catalog.pg_constraint con
+  WHERE con.conindid = i.indexrelid AND con.conrelid = i.indrelid AND con.contype IN ('p', 'u', 'x'))
+ORDER BY i.indexrelid`, t.oid)
+		if err != nil {
+			return fmt.Errorf("table %s.%s: %w", t.schema, t.name, err)
+		}
+		for _, idx := range indexes {
+			out.printf("%s;\n", idx[0])
+		}
+	}
+	for _, t := range tables {
+		fks, err := d.rows(ctx, `SELECT conname, pg_catalog.pg_get_constraintdef(oid), confrelid::text FROM pg_catalog.pg_constraint
+WHERE conrelid = $1::oid AND contype = 'f' ORDER BY conname`, t.oid)
+		if err != nil {
+			return fmt.Errorf("table %s.%s: %w", t.schema, t.name, err)
+		}
+		for _, fk := range fks {
+			if !dumped[fk[2]] {
+				d.opts.progress("  warning: skipping foreign key %s of %s.%s (referenced table not dumped)", fk[0], t.schema, t.name)
+				continue
+			}
+			out.printf("ALTER TABLE ONLY %s ADD CONSTRAINT %s %s;\n", pgQualified(t.schema, t.name), pgIdent(fk[0]), fk[1])
+		}
+	}
+	out.write("\n")
+
+	for _, v := range views {
+		body := strings.TrimSuffix(strings.TrimSpace(v[3]), ";")
+		if v[2] == "m" {
+			out.printf("CREATE MATERIALIZED VIEW %s AS\n%s\nWITH DATA;\n\n", pgQualified(v[0], v[1]), body)
+		} else {
+			out.printf("CREATE VIEW %s AS\n%s;\n\n", pgQualified(v[0], v[1]), body)
+		}
+	}
+	return out.err
+}
+
+func pgIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func pgQualified(schema, name string) string {
+	return pgIdent(schema) + "." + pgIdent(name)
+}
+
+// pgLiteral quotes a string for standard_conforming_strings = on
+func pgLiteral(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
@@ -0,0 +1,384 @@
+package sqlshell
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// copySpec is a parsed psql \copy command:
+//
+//	\copy table [(columns)] from|to 'file' [options]
+//	\copy (query) to 'file' [options]
+type copySpec struct {
+	Table   string
+	Columns string
+	Query   string
+	From    bool
+	File    string
+
+	CSV       bool
+	Header    bool
+	Delimiter string
+	Null      string
+}
+
+func parseCopy(arg string) (*copySpec, error) {
+	spec := &copySpec{}
+	rest := strings.TrimSpace(arg)
+
+	if strings.HasPrefix(rest, "(") {
+		end := closingParen(rest)
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated query in \\copy")
+		}
+		spec.Query, rest = strings.TrimSpace(rest[1:end]), strings.TrimSpace(rest[end+1:])
+	} else {
+		spec.Table, rest = nextToken(rest, "(")
+		if strings.HasPrefix(rest, "(") {
+			end := closingParen(rest)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated column list in \\copy")
+			}
+			spec.Columns, rest = strings.TrimSpace(rest[1:end]), strings.TrimSpace(rest[end+1:])
+		}
+	}
+
+	direction, rest := nextToken(rest, "")
+	switch strings.ToLower(direction) {
+	case "from":
+		spec.From = true
+	case "to":
+	default:
+		return nil, fmt.Errorf("usage: \\copy table [(columns)] from|to 'file' [csv] [header] [delimiter 'c'] [null 'str']")
+	}
+	if spec.Table == "" && spec.From {
+		return nil, fmt.Errorf("\\copy from needs a table, not a query")
+	}
+
+	spec.File, rest = nextToken(rest, "")
+	spec.File = strings.Trim(spec.File, `'"`)
+	if spec.File == "" {
+		return nil, fmt.Errorf("\\copy needs a file name")
+	}
+
+	// Options, in the old (csv header) or the new (with (format csv,
+	// header true)) syntax
+	var options []string
+	for rest != "" {
+		var token string
+		token, rest = nextToken(strings.TrimLeft(rest, "(), "), "(),")
+		if token != "" {
+			options = append(options, token)
+		}
+	}
+	value := func(i int) string {
+		if i+1 < len(options) {
+			return strings.Trim(options[i+1], `'`)
+		}
+		return ""
+	}
+	nullSet := false
+	for i := 0; i < len(options); i++ {
+		switch strings.ToLower(options[i]) {
+		case "with":
+		case "csv":
+			spec.CSV = true
+		case "text":
+			spec.CSV = false
+		case "binary":
+			return nil, fmt.Errorf("binary format is not supported by \\copy")
+		case "format":
+			spec.CSV = strings.EqualFold(value(i), "csv")
+			if strings.EqualFold(value(i), "binary") {
+				return nil, fmt.Errorf("binary format is not supported by \\copy")
+			}
+			i++
+		case "header":
+			spec.Header = true
+			switch strings.ToLower(value(i)) {
+			case "true", "on", "1":
+				i++
+			case "false", "off", "0":
+				spec.Header = false
+				i++
+			}
+		case "delimiter":
+			spec.Delimiter = value(i)
+			i++
+		case "null":
+			spec.Null, nullSet = value(i), true
+			i++
+		default:
+			return nil, fmt.Errorf("unsupported \\copy option '%s'", options[i])
+		}
+	}
+
+	if spec.Delimiter == "" {
+		spec.Delimiter = "\t"
+		if spec.CSV {
+			spec.Delimiter = ","
+		}
+	}
+	if len(spec.Delimiter) != 1 {
+		return nil, fmt.Errorf("\\copy delimiter must be a single character")
+	}
+	if !spec.CSV && !nullSet {
+		spec.Null = `\N`
+	}
+	return spec, nil
+}
+
+// nextToken returns the next word of s (a quoted string counts as one word)
+// and the remainder. A word also ends before any of stops.
+func nextToken(s, stops string) (string, string) {
+	s = strings.TrimSpace(s)
+	i := 0
+	for i < len(s) && s[i] != ' ' && s[i] != '\t' && !strings.ContainsRune(stops, rune(s[i])) {
+		if s[i] == '\'' || s[i] == '"' {
+			if end := closeQuote(s, i, s[i], false); end > 0 {
+				i = end + 1
+				continue
+			}
+		}
+		i++
+	}
+	return s[:i], strings.TrimSpace(s[i:])
+}
+
+// closingParen returns the index of the parenthesis closing s[0], or -1
+func closingParen(s string) int {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\'', '"':
+			end := closeQuote(s, i, s[i], false)
+			if end < 0 {
+				return -1
+			}
+			i = end
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func quoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// copy runs a \copy command. Data moves through the client, so the files are
+// local to the machine running the shell.
+func (s *Shell) copy(ctx context.Context, arg string) error {
+	spec, err := parseCopy(arg)
+	if err != nil {
+		return err
+	}
+	var n int64
+	if spec.From {
+		n, err = s.copyFrom(ctx, spec)
+	} else {
+		n, err = s.copyTo(ctx, spec)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("COPY %d\n", n)
+	return nil
+}
+
+func (s *Shell) copyTo(ctx context.Context, spec *copySpec) (int64, error) {
+	query := spec.Query
+	if query == "" {
+		columns := "*"
+		if spec.Columns != "" {
+			columns = spec.Columns
+		}
+		query = "SELECT " + columns + " FROM " + spec.Table
+	}
+	r, err := s.Query(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+
+	var w io.Writer = os.Stdout
+	if spec.File != "stdout" && spec.File != "pstdout" {
+		f, err := os.Create(spec.File)
+		if err != nil {
+			return 0, err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if spec.CSV {
+		cw := csv.NewWriter(w)
+		cw.Comma = rune(spec.Delimiter[0])
+		if spec.Header {
+			cw.Write(r.Columns)
+		}
+		for _, row := range r.Rows {
+			record := make([]string, len(row))
+			for i, v := range row {
+				record[i] = cellText(v, spec.Null)
+			}
+			cw.Write(record)
+		}
+		cw.Flush()
+		return int64(len(r.Rows)), cw.Error()
+	}
+
+	bw := bufio.NewWriter(w)
+	if spec.Header {
+		fmt.Fprintln(bw, strings.Join(r.Columns, spec.Delimiter))
+	}
+	for _, row := range r.Rows {
+		fields := make([]string, len(row))
+		for i, v := range row {
+			fields[i] = spec.Null
+			if v != nil {
+				fields[i] = textEscaper.Replace(*v)
+			}
+		}
+		fmt.Fprintln(bw, strings.Join(fields, spec.Delimiter))
+	}
+	return int64(len(r.Rows)), bw.Flush()
+}
+
+// The escapes of COPY's text format
+var (
+	textEscaper   = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+	textUnescaper = strings.NewReplacer(`\\`, `\`, `\t`, "\t", `\n`, "\n", `\r`, "\r")
+)
+
+func (s *Shell) copyFrom(ctx context.Context, spec *copySpec) (int64, error) {
+	if spec.File == "stdin" || spec.File == "pstdin" {
+		return 0, fmt.Errorf("\\copy from stdin is not supported; use a file")
+	}
+	f, err := os.Open(spec.File)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	columns := spec.Columns
+	count := len(strings.Split(columns, ","))
+	if columns == "" {
+		names, err := s.Query(ctx, `SELECT attname FROM pg_catalog.pg_attribute
+WHERE attrelid = $1::regclass AND attnum > 0 AND NOT attisdropped ORDER BY attnum`, spec.Table)
+		if err != nil {
+			return 0, err
+		}
+		quoted := make([]string, len(names.Rows))
+		for i, row := range names.Rows {
+			quoted[i] = quoteIdent(*row[0])
+		}
+		columns, count = strings.Join(quoted, ", "), len(quoted)
+	}
+
+	// The driver only copies inside a transaction block
+	if s.State == "!" {
+		return 0, fmt.Errorf("current transaction is aborted, commands ignored until end of transaction block")
+	}
+	own := s.State == ""
+	if own {
+		if _, err := s.Conn.ExecContext(ctx, "BEGIN"); err != nil {
+			return 0, err
+		}
+	}
+	n, err := s.copyRows(ctx, "COPY "+spec.Table+" ("+columns+") FROM STDIN", count, spec, f)
+	if own {
+		end := "COMMIT"
+		if err != nil {
+			end = "ROLLBACK"
+		}
+		if _, cerr := s.Conn.ExecContext(ctx, end); cerr != nil && err == nil {
+			err = cerr
+		}
+	} else if err != nil {
+		s.State = "!"
+	}
+	return n, err
+}
+
+func (s *Shell) copyRows(ctx context.Context, statement string, count int, spec *copySpec, input io.Reader) (int64, error) {
+	stmt, err := s.Conn.PrepareContext(ctx, statement)
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+
+	line := 0
+	add := func(fields []string) error {
+		line++
+		if line == 1 && spec.Header {
+			return nil
+		}
+		if len(fields) != count {
+			return fmt.Errorf("%s line %d: expected %d columns, got %d", spec.File, line, count, len(fields))
+		}
+		values := make([]interface{}, len(fields))
+		for i, field := range fields {
+			if field != spec.Null {
+				values[i] = field
+			}
+		}
+		_, err := stmt.ExecContext(ctx, values...)
+		return err
+	}
+
+	if spec.CSV {
+		cr := csv.NewReader(input)
+		cr.Comma = rune(spec.Delimiter[0])
+		cr.FieldsPerRecord = -1
+		for {
+			record, err := cr.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return 0, err
+			}
+			if err := add(record); err != nil {
+				return 0, err
+			}
+		}
+	} else {
+		scanner := bufio.NewScanner(input)
+		scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+		for scanner.Scan() {
+			text := strings.TrimSuffix(scanner.Text(), "\r")
+			if text == `\.` {
+				break
+			}
+			fields := strings.Split(text, spec.Delimiter)
+			for i, field := range fields {
+				if field != spec.Null {
+					fields[i] = textUnescaper.Replace(field)
+				}
+			}
+			if err := add(fields); err != nil {
+				return 0, err
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return 0, err
+		}
+	}
+
+	res, err := stmt.ExecContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
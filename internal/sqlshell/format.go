@@ -0,0 +1,414 @@
+package sqlshell
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Result is one result set (or the outcome of a statement without one)
+type Result struct {
+	Columns []string
+	// Numeric marks columns whose values are numbers (right-aligned in
+	// tables, unquoted in JSON/YAML)
+	Numeric []bool
+	// Rows holds the values as text; nil is SQL NULL
+	Rows         [][]*string
+	HasRows      bool
+	RowsAffected int64
+	Elapsed      time.Duration
+	// Query is the statement that produced the result
+	Query string
+}
+
+// ReadResults reads every result set of rows
+func ReadResults(rows *sql.Rows) ([]*Result, error) {
+	var results []*Result
+	for {
+		r, err := readResult(rows)
+		if err != nil {
+			return results, err
+		}
+		results = append(results, r)
+		if !rows.NextResultSet() {
+			break
+		}
+	}
+	return results, rows.Err()
+}
+
+func readResult(rows *sql.Rows) (*Result, error) {
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	r := &Result{Columns: cols, Numeric: make([]bool, len(cols)), HasRows: true}
+	if types, err := rows.ColumnTypes(); err == nil {
+		for i, t := range types {
+			r.Numeric[i] = isNumericType(t.DatabaseTypeName())
+		}
+	}
+
+	values := make([]interface{}, len(cols))
+	pointers := make([]interface{}, len(cols))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(pointers...); err != nil {
+			return r, err
+		}
+		row := make([]*string, len(cols))
+		for i, v := range values {
+			row[i] = formatValue(v)
+		}
+		r.Rows = append(r.Rows, row)
+	}
+	return r, rows.Err()
+}
+
+func isNumericType(name string) bool {
+	name = strings.TrimPrefix(strings.ToUpper(name), "UNSIGNED ")
+	switch name {
+	case "TINYINT", "SMALLINT", "MEDIUMINT", "INT", "INTEGER", "BIGINT", "DECIMAL", "NUMERIC",
+		"FLOAT", "DOUBLE", "REAL", "YEAR", "INT2", "INT4", "INT8", "FLOAT4", "FLOAT8", "OID":
+		return true
+	}
+	return false
+}
+
+func formatValue(v interface{}) *string {
+	var s string
+	switch x := v.(type) {
+	case nil:
+		return nil
+	case []byte:
+		s = string(x)
+	case string:
+		s = x
+	case time.Time:
+		s = x.Format("2006-01-02 15:04:05.999999Z07:00")
+	case bool:
+		s = strconv.FormatBool(x)
+	default:
+		s = fmt.Sprint(x)
+	}
+	return &s
+}
+
+// Records returns the rows as column name -> value maps (nil for NULL)
+func (r *Result) Records() []map[string]interface{} {
+	records := make([]map[string]interface{}, 0, len(r.Rows))
+	for _, row := range r.Rows {
+		rec := make(map[string]interface{}, len(r.Columns))
+		for i, c := range r.Columns {
+			rec[c] = r.value(i, row[i])
+		}
+		records = append(records, rec)
+	}
+	return records
+}
+
+// value returns a cell as a JSON-compatible value
+func (r *Result) value(col int, v *string) interface{} {
+	if v == nil {
+		return nil
+	}
+	if r.Numeric[col] && json.Valid([]byte(*v)) {
+		return json.Number(*v)
+	}
+	return *v
+}
+
+// ============================================================================
+// Table renderers
+// ============================================================================
+
+// displayWidth is the number of terminal cells s occupies; CJK and Hangul
+// characters are two cells wide
+func displayWidth(s string) int {
+	w := 0
+	for _, r := range s {
+		switch {
+		case r == '\t':
+			w += 8
+		case r < 0x20:
+		case r >= 0x1100 && r <= 0x115F, r >= 0x2E80 && r <= 0xA4CF, r >= 0xAC00 && r <= 0xD7A3,
+			r >= 0xF900 && r <= 0xFAFF, r >= 0xFE30 && r <= 0xFE4F, r >= 0xFF00 && r <= 0xFF60,
+			r >= 0xFFE0 && r <= 0xFFE6, r >= 0x1F300 && r <= 0x1FAFF, r >= 0x20000 && r <= 0x3FFFD:
+			w += 2
+		default:
+			w++
+		}
+	}
+	return w
+}
+
+func pad(s string, width int, right bool) string {
+	fill := strings.Repeat(" ", max(0, width-displayWidth(s)))
+	if right {
+		return fill + s
+	}
+	return s + fill
+}
+
+func cellText(v *string, null string) string {
+	if v == nil {
+		return null
+	}
+	// keep one table row per line
+	return strings.NewReplacer("\r\n", `\n`, "\n", `\n`).Replace(*v)
+}
+
+func columnWidths(r *Result, null string) []int {
+	widths := make([]int, len(r.Columns))
+	for i, c := range r.Columns {
+		widths[i] = displayWidth(c)
+	}
+	for _, row := range r.Rows {
+		for i, v := range row {
+			widths[i] = max(widths[i], displayWidth(cellText(v, null)))
+		}
+	}
+	return widths
+}
+
+// WriteBoxTable renders r the way the mysql client does
+func WriteBoxTable(w io.Writer, r *Result, null string) {
+	widths := columnWidths(r, null)
+	sep := "+"
+	for _, width := range widths {
+		sep += strings.Repeat("-", width+2) + "+"
+	}
+	fmt.Fprintln(w, sep)
+	line := "|"
+	for i, c := range r.Columns {
+		line += " " + pad(c, widths[i], false) + " |"
+	}
+	fmt.Fprintln(w, line)
+	fmt.Fprintln(w, sep)
+	for _, row := range r.Rows {
+		line := "|"
+		for i, v := range row {
+			line += " " + pad(cellText(v, null), widths[i], r.Numeric[i]) + " |"
+		}
+		fmt.Fprintln(w, line)
+	}
+	fmt.Fprintln(w, sep)
+}
+
+// WriteAlignedTable renders r the way psql does
+func WriteAlignedTable(w io.Writer, r *Result, null string) {
+	widths := columnWidths(r, null)
+	header := make([]string, len(r.Columns))
+	rules := make([]string, len(r.Columns))
+	for i, c := range r.Columns {
+		// psql centers headers
+		left := (widths[i] - displayWidth(c)) / 2
+		header[i] = strings.Repeat(" ", left) + pad(c, widths[i]-left, false)
+		rules[i] = strings.Repeat("-", widths[i]+2)
+	}
+	fmt.Fprintln(w, " "+strings.Join(header, " | "))
+	fmt.Fprintln(w, strings.Join(rules, "+"))
+	for _, row := range r.Rows {
+		cells := make([]string, len(row))
+		for i, v := range row {
+			cells[i] = pad(cellText(v, null), widths[i], r.Numeric[i])
+		}
+		fmt.Fprintln(w, strings.TrimRight(" "+strings.Join(cells, " | "), " "))
+	}
+}
+
+// WriteVertical renders one "column: value" block per row, as with \G
+func WriteVertical(w io.Writer, r *Result, null string) {
+	width := 0
+	for _, c := range r.Columns {
+		width = max(width, displayWidth(c))
+	}
+	for n, row := range r.Rows {
+		fmt.Fprintf(w, "%s %d. row %s\n", strings.Repeat("*", 27), n+1, strings.Repeat("*", 27))
+		for i, c := range r.Columns {
+			value := null
+			if row[i] != nil {
+				value = *row[i]
+			}
+			fmt.Fprintf(w, "%s: %s\n", pad(c, width, true), value)
+		}
+	}
+}
+
+// WriteExpanded renders one record block per row, as with psql's \x
+func WriteExpanded(w io.Writer, r *Result, null string) {
+	width := 0
+	for _, c := range r.Columns {
+		width = max(width, displayWidth(c))
+	}
+	for n, row := range r.Rows {
+		fmt.Fprintf(w, "-[ RECORD %d ]%s\n", n+1, strings.Repeat("-", max(1, width-8)))
+		for i, c := range r.Columns {
+			fmt.Fprintf(w, "%s | %s\n", pad(c, width, false), cellText(row[i], null))
+		}
+	}
+}
+
+// ============================================================================
+// Machine-readable renderers
+// ============================================================================
+
+// WriteCSV writes a header row and the rows; NULL is an empty field
+func WriteCSV(w io.Writer, r *Result) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(r.Columns); err != nil {
+		return err
+	}
+	for _, row := range r.Rows {
+		record := make([]string, len(row))
+		for i, v := range row {
+			if v != nil {
+				record[i] = *v
+			}
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteJSON writes the rows as an array of objects with keys in column order
+func WriteJSON(w io.Writer, r *Result) error {
+	var b strings.Builder
+	b.WriteString("[")
+	for n, row := range r.Rows {
+		if n > 0 {
+			b.WriteString(",")
+		}
+		object, err := r.rowJSON(row)
+		if err != nil {
+			return err
+		}
+		b.WriteString("\n  ")
+		b.Write(object)
+	}
+	if len(r.Rows) > 0 {
+		b.WriteString("\n")
+	}
+	b.WriteString("]\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (r *Result) rowJSON(row []*string) ([]byte, error) {
+	var b strings.Builder
+	b.WriteString("{")
+	for i, c := range r.Columns {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		key, _ := json.Marshal(c)
+		value, err := json.Marshal(r.value(i, row[i]))
+		if err != nil {
+			return nil, err
+		}
+		b.Write(key)
+		b.WriteString(": ")
+		b.Write(value)
+	}
+	b.WriteString("}")
+	return []byte(b.String()), nil
+}
+
+// YAMLNode returns the rows as a YAML sequence of mappings in column order
+func (r *Result) YAMLNode() *yaml.Node {
+	seq := &yaml.Node{Kind: yaml.SequenceNode}
+	for _, row := range r.Rows {
+		seq.Content = append(seq.Content, r.rowNode(row))
+	}
+	return seq
+}
+
+func (r *Result) rowNode(row []*string) *yaml.Node {
+	m := &yaml.Node{Kind: yaml.MappingNode}
+	for i, c := range r.Columns {
+		value := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str"}
+		switch {
+		case row[i] == nil:
+			value.Tag, value.Value = "!!null", "null"
+		case r.Numeric[i]:
+			value.Tag, value.Value = "", *row[i]
+		default:
+			value.Value = *row[i]
+			if !utf8.ValidString(value.Value) {
+				value.Value = strconv.Quote(value.Value)
+			}
+		}
+		m.Content = append(m.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: c}, value)
+	}
+	return m
+}
+
+// Row is one row of a result that marshals to a JSON or YAML object with
+// its keys in column order
+type Row struct {
+	result *Result
+	values []*string
+}
+
+// OrderedRows returns the rows for encoding with encoding/json or yaml.v3
+func (r *Result) OrderedRows() []Row {
+	rows := make([]Row, len(r.Rows))
+	for i, values := range r.Rows {
+		rows[i] = Row{result: r, values: values}
+	}
+	return rows
+}
+
+func (row Row) MarshalJSON() ([]byte, error) { return row.result.rowJSON(row.values) }
+
+func (row Row) MarshalYAML() (interface{}, error) { return row.result.rowNode(row.values), nil }
+
+// WriteYAML writes the rows as a YAML sequence
+func WriteYAML(w io.Writer, r *Result) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(r.YAMLNode()); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// WriteFormat writes r as csv, json or yaml
+func WriteFormat(w io.Writer, r *Result, format string) error {
+	switch format {
+	case "csv":
+		return WriteCSV(w, r)
+	case "json":
+		return WriteJSON(w, r)
+	case "yaml":
+		return WriteYAML(w, r)
+	}
+	return fmt.Errorf("unknown format '%s' (csv, json, yaml)", format)
+}
+
+// FormatForFile picks an output format from a file extension, "" for text
+func FormatForFile(name string) string {
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(lower, ".csv"):
+		return "csv"
+	case strings.HasSuffix(lower, ".json"):
+		return "json"
+	case strings.HasSuffix(lower, ".yaml"), strings.HasSuffix(lower, ".yml"):
+		return "yaml"
+	}
+	return ""
+}
@@ -0,0 +1,182 @@
+package sqlshell
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+
+	"golang.org/x/term"
+)
+
+// errInterrupted is returned by ReadLine when the user pressed Ctrl-C
+var errInterrupted = errors.New("interrupted")
+
+const historySize = 1000
+
+// lineReader reads input lines with a prompt
+type lineReader interface {
+	ReadLine(prompt string) (string, error)
+	// Record adds a complete statement to the history
+	Record(entry string)
+}
+
+// newLineReader returns a line editor with history when stdin is a
+// terminal, and a plain reader otherwise (piped input)
+func newLineReader(historyFile string) lineReader {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return &plainReader{scanner: bufio.NewScanner(os.Stdin)}
+	}
+
+	input := &interruptReader{r: os.Stdin}
+	t := term.NewTerminal(struct {
+		io.Reader
+		io.Writer
+	}{input, os.Stdout}, "")
+	history := loadHistory(historyFile)
+	t.History = &terminalHistory{history}
+	return &terminalReader{fd: fd, term: t, input: input, history: history}
+}
+
+type plainReader struct {
+	scanner *bufio.Scanner
+}
+
+func (r *plainReader) ReadLine(prompt string) (string, error) {
+	if !r.scanner.Scan() {
+		if err := r.scanner.Err(); err != nil {
+			return "", err
+		}
+		return "", io.EOF
+	}
+	return r.scanner.Text(), nil
+}
+
+func (r *plainReader) Record(string) {}
+
+// terminalReader edits lines with x/term (arrow keys, Ctrl-A/E/K/U/W,
+// history). The terminal is only in raw mode while a line is read, so that
+// Ctrl-C raises SIGINT while a query runs.
+type terminalReader struct {
+	fd      int
+	term    *term.Terminal
+	input   *interruptReader
+	history *fileHistory
+}
+
+func (r *terminalReader) ReadLine(prompt string) (string, error) {
+	if width, height, err := term.GetSize(r.fd); err == nil {
+		r.term.SetSize(width, height)
+	}
+	state, err := term.MakeRaw(r.fd)
+	if err != nil {
+		return "", err
+	}
+	defer term.Restore(r.fd, state)
+
+	r.term.SetPrompt(prompt)
+	line, err := r.term.ReadLine()
+	if r.input.interrupted.Swap(false) {
+		return "", errInterrupted
+	}
+	return line, err
+}
+
+func (r *terminalReader) Record(entry string) {
+	r.history.add(entry)
+}
+
+// interruptReader turns Ctrl-C into "move to end, erase line, enter" and
+// flags the interruption, since x/term reports Ctrl-C as io.EOF
+type interruptReader struct {
+	r           io.Reader
+	interrupted atomic.Bool
+	pending     []byte
+}
+
+func (r *interruptReader) Read(p []byte) (int, error) {
+	if len(r.pending) == 0 {
+		buf := make([]byte, len(p))
+		n, err := r.r.Read(buf)
+		if n == 0 {
+			return 0, err
+		}
+		for _, b := range buf[:n] {
+			if b == 3 {
+				r.interrupted.Store(true)
+				r.pending = append(r.pending, 5, 21, '\r')
+				continue
+			}
+			r.pending = append(r.pending, b)
+		}
+	}
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
+
+// fileHistory keeps complete statements, oldest first, and appends them to
+// a file (mode 0600, statements may contain passwords)
+type fileHistory struct {
+	path    string
+	entries []string
+}
+
+func loadHistory(path string) *fileHistory {
+	h := &fileHistory{path: path}
+	if path == "" {
+		return h
+	}
+	if data, err := os.ReadFile(path); err == nil {
+		for _, line := range strings.Split(string(data), "\n") {
+			if line != "" {
+				h.entries = append(h.entries, line)
+			}
+		}
+		if len(h.entries) > historySize {
+			h.entries = h.entries[len(h.entries)-historySize:]
+			h.rewrite()
+		}
+	}
+	return h
+}
+
+func (h *fileHistory) add(entry string) {
+	entry = strings.Join(strings.Fields(entry), " ")
+	if entry == "" || (len(h.entries) > 0 && h.entries[len(h.entries)-1] == entry) {
+		return
+	}
+	h.entries = append(h.entries, entry)
+	if h.path == "" {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(h.path), 0700); err != nil {
+		return
+	}
+	f, err := os.OpenFile(h.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	f.WriteString(entry + "\n")
+}
+
+func (h *fileHistory) rewrite() {
+	os.WriteFile(h.path, []byte(strings.Join(h.entries, "\n")+"\n"), 0600)
+}
+
+// terminalHistory exposes fileHistory to x/term. Lines typed are not added
+// individually; the shell records whole statements instead.
+type terminalHistory struct {
+	h *fileHistory
+}
+
+func (t *terminalHistory) Add(string) {}
+func (t *terminalHistory) Len() int   { return len(t.h.entries) }
+func (t *terminalHistory) At(idx int) string {
+	return t.h.entries[len(t.h.entries)-1-idx]
+}
@@ -0,0 +1,116 @@
+package sqlshell
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// MySQL is the dialect of the MySQL and MariaDB shells
+type MySQL struct {
+	// Label is the prompt label (mysql or MariaDB)
+	Label string
+}
+
+func (d *MySQL) Name() string { return strings.ToLower(d.Label) }
+
+func (d *MySQL) Syntax() Syntax {
+	return Syntax{HashComments: true, Backticks: true, BackslashEscapes: true, ClientTerminators: true}
+}
+
+func (d *MySQL) SessionIDQuery() string { return "SELECT CONNECTION_ID()" }
+
+func (d *MySQL) CancelStatement(id int64) string { return fmt.Sprintf("KILL QUERY %d", id) }
+
+func (d *MySQL) Prompt(s *Shell, continuation bool) string {
+	if continuation {
+		return strings.Repeat(" ", len(d.Label)+4) + "-> "
+	}
+	db := s.Database
+	if db == "" {
+		db = "(none)"
+	}
+	return fmt.Sprintf("%s [%s]> ", d.Label, db)
+}
+
+func (d *MySQL) Command(ctx context.Context, s *Shell, line string) (bool, error) {
+	word, arg, _ := strings.Cut(strings.TrimRight(line, "; \t"), " ")
+	if !strings.EqualFold(word, "use") && word != `\u` {
+		return false, nil
+	}
+	db := strings.Trim(strings.TrimSpace(arg), "`")
+	if db == "" {
+		return true, fmt.Errorf("USE must be followed by a database name")
+	}
+	if _, err := s.Conn.ExecContext(ctx, "USE `"+strings.ReplaceAll(db, "`", "``")+"`"); err != nil {
+		return true, err
+	}
+	s.Database = db
+	fmt.Println("Database changed")
+	return true, nil
+}
+
+func (d *MySQL) Executed(ctx context.Context, s *Shell, stmt string, err error) {
+	// USE inside a statement (or a reconnect) may change the database
+	if err == nil && (stmt == "" || FirstKeyword(stmt) == "USE") {
+		var db *string
+		if s.Conn.QueryRowContext(ctx, "SELECT DATABASE()").Scan(&db) == nil {
+			s.Database = ""
+			if db != nil {
+				s.Database = *db
+			}
+		}
+	}
+}
+
+func (d *MySQL) Render(w io.Writer, r *Result, vertical bool) {
+	if len(r.Rows) == 0 {
+		return
+	}
+	if vertical {
+		WriteVertical(w, r, "NULL")
+		return
+	}
+	WriteBoxTable(w, r, "NULL")
+}
+
+func (d *MySQL) Summary(r *Result, timing bool) string {
+	var summary string
+	switch {
+	case !r.HasRows:
+		summary = fmt.Sprintf("Query OK, %d %s affected", r.RowsAffected, plural(r.RowsAffected, "row", "rows"))
+	case len(r.Rows) == 0:
+		summary = "Empty set"
+	default:
+		summary = fmt.Sprintf("%d %s in set", len(r.Rows), plural(int64(len(r.Rows)), "row", "rows"))
+	}
+	if timing {
+		summary += fmt.Sprintf(" (%.3f sec)", r.Elapsed.Seconds())
+	}
+	return summary + "\n"
+}
+
+func (d *MySQL) FormatError(err error) string {
+	msg := err.Error()
+	if strings.HasPrefix(msg, "Error ") {
+		return "ERROR " + strings.TrimPrefix(msg, "Error ")
+	}
+	return "ERROR: " + msg
+}
+
+func (d *MySQL) Help() string {
+	return `MySQL commands:
+  use <db>, \u <db>     change the current database
+  ... \G                end a statement with \G for vertical output
+  delimiter <str>       change the statement delimiter (for procedures/triggers)
+  Ctrl-C                cancel the running query (KILL QUERY); clear the input line
+`
+}
+
+func plural(n int64, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
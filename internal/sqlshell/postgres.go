@@ -0,0 +1,373 @@
+package sqlshell
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// PostgreSQL is the dialect of the PostgreSQL shell. The prompt follows psql:
+// "db=>" ("db=#" for superusers), with '*' inside a transaction block and
+// '!' in a failed one.
+type PostgreSQL struct {
+	superuser bool
+}
+
+func (d *PostgreSQL) Name() string { return "postgresql" }
+
+func (d *PostgreSQL) Syntax() Syntax {
+	return Syntax{DollarQuotes: true, PsqlTerminators: true}
+}
+
+func (d *PostgreSQL) SessionIDQuery() string { return "SELECT pg_backend_pid()" }
+
+func (d *PostgreSQL) CancelStatement(id int64) string {
+	return fmt.Sprintf("SELECT pg_cancel_backend(%d)", id)
+}
+
+func (d *PostgreSQL) Prompt(s *Shell, continuation bool) string {
+	mode, suffix := "=", ">"
+	if continuation {
+		mode = "-"
+	}
+	if d.superuser {
+		suffix = "#"
+	}
+	return s.Database + mode + s.State + suffix + " "
+}
+
+func (d *PostgreSQL) Executed(ctx context.Context, s *Shell, stmt string, err error) {
+	if stmt == "" {
+		// New session: no transaction, refresh database and role
+		s.State = ""
+		var db, super string
+		if s.Conn.QueryRowContext(ctx, "SELECT current_database(), current_setting('is_superuser')").Scan(&db, &super) == nil {
+			s.Database = db
+			d.superuser = super == "on"
+		}
+		return
+	}
+
+	words := strings.Fields(strings.ToUpper(stripComments(stmt, d.Syntax())))
+	if len(words) == 0 {
+		return
+	}
+	if err != nil {
+		// Any error inside a transaction block aborts it
+		if s.State != "" {
+			s.State = "!"
+		}
+		return
+	}
+	switch words[0] {
+	case "BEGIN", "START":
+		s.State = "*"
+	case "COMMIT", "END", "ABORT":
+		s.State = ""
+	case "ROLLBACK":
+		if len(words) > 1 && words[1] == "TO" || len(words) > 2 && words[2] == "TO" {
+			s.State = "*"
+		} else {
+			s.State = ""
+		}
+	case "PREPARE":
+		if len(words) > 1 && words[1] == "TRANSACTION" {
+			s.State = ""
+		}
+	}
+}
+
+func (d *PostgreSQL) Render(w io.Writer, r *Result, vertical bool) {
+	if len(r.Columns) == 0 {
+		return
+	}
+	if vertical {
+		if len(r.Rows) == 0 {
+			fmt.Fprintln(w, "(0 rows)")
+			return
+		}
+		WriteExpanded(w, r, "")
+		return
+	}
+	WriteAlignedTable(w, r, "")
+}
+
+func (d *PostgreSQL) Summary(r *Result, timing bool) string {
+	var summary string
+	switch {
+	case !r.HasRows || len(r.Columns) == 0:
+		summary = commandTag(r)
+	default:
+		summary = rowCount(len(r.Rows)) + "\n"
+	}
+	if timing {
+		summary += fmt.Sprintf("Time: %.3f ms\n", float64(r.Elapsed.Microseconds())/1000)
+	}
+	return strings.TrimSuffix(summary, "\n")
+}
+
+// commandTag approximates the command tag psql prints after a statement
+// without results, such as "INSERT 0 1" or "CREATE TABLE"
+func commandTag(r *Result) string {
+	words := strings.Fields(strings.ToUpper(stripComments(r.Query, Syntax{DollarQuotes: true})))
+	if len(words) == 0 {
+		return ""
+	}
+	switch words[0] {
+	case "INSERT":
+		return fmt.Sprintf("INSERT 0 %d", r.RowsAffected)
+	case "UPDATE", "DELETE", "MERGE", "COPY", "MOVE", "FETCH", "SELECT":
+		return fmt.Sprintf("%s %d", words[0], r.RowsAffected)
+	case "CREATE", "DROP", "ALTER":
+		tag := []string{words[0]}
+		for _, w := range words[1:] {
+			switch w {
+			case "OR", "REPLACE", "UNIQUE", "TEMP", "TEMPORARY", "UNLOGGED", "GLOBAL", "LOCAL":
+				continue
+			}
+			tag = append(tag, strings.TrimRight(w, ";("))
+			if w != "MATERIALIZED" && w != "FOREIGN" && w != "EVENT" {
+				break
+			}
+		}
+		return strings.Join(tag, " ")
+	case "START":
+		return "START TRANSACTION"
+	}
+	return strings.TrimRight(words[0], ";")
+}
+
+func (d *PostgreSQL) FormatError(err error) string {
+	return "ERROR:  " + strings.TrimPrefix(err.Error(), "pq: ")
+}
+
+func (d *PostgreSQL) Help() string {
+	return `PostgreSQL commands:
+  \l                    list databases
+  \dt [pattern]         list tables
+  \d [table]            list relations, or describe a table
+  \du                   list roles
+  \copy ...             copy between a table (or query) and a local file
+                        \copy t from 'f.csv' csv header / \copy (select ...) to 'f.csv' csv header
+  ... \gx               end a statement with \gx for expanded output
+  Ctrl-C                cancel the running query (pg_cancel_backend); clear the input line
+`
+}
+
+func (d *PostgreSQL) Command(ctx context.Context, s *Shell, line string) (bool, error) {
+	word, arg, _ := strings.Cut(strings.TrimRight(line, "; \t"), " ")
+	arg = strings.TrimSpace(arg)
+
+	switch word {
+	case `\l`, `\l+`, `\list`:
+		return true, d.listDatabases(ctx, s)
+	case `\dt`, `\dt+`:
+		return true, d.listRelations(ctx, s, arg, "r", "p")
+	case `\d`, `\d+`:
+		if arg == "" {
+			return true, d.listRelations(ctx, s, "", "r", "p", "v", "m", "S", "f")
+		}
+		return true, d.describeRelation(ctx, s, arg)
+	case `\du`, `\du+`, `\dg`:
+		return true, d.listRoles(ctx, s)
+	case `\copy`:
+		return true, s.copy(ctx, arg)
+	}
+	return false, nil
+}
+
+// printListing prints the result of a meta-command with a centered title
+func printListing(s *Shell, title string, r *Result) {
+	if s.Expanded {
+		WriteExpanded(os.Stdout, r, "")
+	} else {
+		printTitle(r, title)
+		WriteAlignedTable(os.Stdout, r, "")
+	}
+	fmt.Println(rowCount(len(r.Rows)))
+	fmt.Println()
+}
+
+func printTitle(r *Result, title string) {
+	width := len(r.Columns)*3 - 1
+	for _, w := range columnWidths(r, "") {
+		width += w
+	}
+	fmt.Println(strings.Repeat(" ", max(0, (width-displayWidth(title))/2)) + title)
+}
+
+func rowCount(n int) string {
+	if n == 1 {
+		return "(1 row)"
+	}
+	return fmt.Sprintf("(%d rows)", n)
+}
+
+func (d *PostgreSQL) listDatabases(ctx context.Context, s *Shell) error {
+	r, err := s.Query(ctx, `SELECT d.datname AS "Name",
+       pg_catalog.pg_get_userbyid(d.datdba) AS "Owner",
+       pg_catalog.pg_encoding_to_char(d.encoding) AS "Encoding",
+       d.datcollate AS "Collate",
+       d.datctype AS "Ctype",
+       pg_catalog.array_to_string(d.datacl, E'\n') AS "Access privileges"
+FROM pg_catalog.pg_database d
+ORDER BY 1`)
+	if err != nil {
+		return err
+	}
+	printListing(s, "List of databases", r)
+	return nil
+}
+
+// psqlPattern turns a psql pattern ("public.*", "user?") into a schema and a
+// relation LIKE pattern
+func psqlPattern(pattern string) (schema, name string) {
+	like := func(p string) string {
+		p = strings.ReplaceAll(p, "_", `\_`)
+		p = strings.ReplaceAll(p, "*", "%")
+		return strings.ReplaceAll(p, "?", "_")
+	}
+	pattern = strings.Trim(pattern, `"`)
+	if i := strings.LastIndexByte(pattern, '.'); i >= 0 {
+		return like(pattern[:i]), like(pattern[i+1:])
+	}
+	return "", like(pattern)
+}
+
+func (d *PostgreSQL) listRelations(ctx context.Context, s *Shell, pattern string, kinds ...string) error {
+	query := `SELECT n.nspname AS "Schema",
+       c.relname AS "Name",
+       CASE c.relkind WHEN 'r' THEN 'table' WHEN 'p' THEN 'partitioned table' WHEN 'v' THEN 'view'
+            WHEN 'm' THEN 'materialized view' WHEN 'S' THEN 'sequence' WHEN 'f' THEN 'foreign table' END AS "Type",
+       pg_catalog.pg_get_userbyid(c.relowner) AS "Owner"
+FROM pg_catalog.pg_class c
+JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+WHERE c.relkind::text = ANY (string_to_array($1, ','))
+  AND n.nspname <> 'information_schema' AND n.nspname !~ '^pg_'`
+	args := []interface{}{strings.Join(kinds, ",")}
+	if pattern == "" {
+		query += `
+  AND pg_catalog.pg_table_is_visible(c.oid)`
+	} else {
+		schema, name := psqlPattern(pattern)
+		query += `
+  AND c.relname LIKE $2`
+		args = append(args, name)
+		if schema != "" {
+			query += ` AND n.nspname LIKE $3`
+			args = append(args, schema)
+		} else {
+			query += ` AND pg_catalog.pg_table_is_visible(c.oid)`
+		}
+	}
+	query += `
+ORDER BY 1, 2`
+
+	r, err := s.Query(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	if len(r.Rows) == 0 {
+		if pattern != "" {
+			fmt.Printf("Did not find any relation named \"%s\".\n", pattern)
+		} else {
+			fmt.Println("Did not find any relations.")
+		}
+		return nil
+	}
+	printListing(s, "List of relations", r)
+	return nil
+}
+
+func (d *PostgreSQL) describeRelation(ctx context.Context, s *Shell, name string) error {
+	var schema, relname, kind string
+	err := s.Conn.QueryRowContext(ctx, `SELECT n.nspname, c.relname, c.relkind::text
+FROM pg_catalog.pg_class c JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+WHERE c.oid = $1::regclass`, name).Scan(&schema, &relname, &kind)
+	if err != nil {
+		if strings.Contains(err.Error(), "does not exist") {
+			fmt.Printf("Did not find any relation named \"%s\".\n", name)
+			return nil
+		}
+		return err
+	}
+
+	r, err := s.Query(ctx, `SELECT a.attname AS "Column",
+       pg_catalog.format_type(a.atttypid, a.atttypmod) AS "Type",
+       CASE WHEN a.attnotnull THEN 'not null' ELSE '' END AS "Nullable",
+       COALESCE(pg_catalog.pg_get_expr(ad.adbin, ad.adrelid), '') AS "Default"
+FROM pg_catalog.pg_attribute a
+LEFT JOIN pg_catalog.pg_attrdef ad ON ad.adrelid = a.attrelid AND ad.adnum = a.attnum
+WHERE a.attrelid = $1::regclass AND a.attnum > 0 AND NOT a.attisdropped
+ORDER BY a.attnum`, name)
+	if err != nil {
+		return err
+	}
+
+	kinds := map[string]string{"r": "Table", "p": "Partitioned table", "v": "View", "m": "Materialized view",
+		"S": "Sequence", "f": "Foreign table", "i": "Index"}
+	kindName, ok := kinds[kind]
+	if !ok {
+		kindName = "Relation"
+	}
+	printTitle(r, fmt.Sprintf("%s \"%s.%s\"", kindName, schema, relname))
+	WriteAlignedTable(os.Stdout, r, "")
+
+	// Indexes and constraints, as psql lists them below the columns
+	sections := []struct {
+		title string
+		query string
+	}{
+		{"Indexes:", `SELECT '"' || c.relname || '" ' ||
+       CASE WHEN i.indisprimary THEN 'PRIMARY KEY, ' WHEN i.indisunique THEN 'UNIQUE, ' ELSE '' END ||
+       substring(pg_catalog.pg_get_indexdef(i.indexrelid) from ' USING (.*)$')
+FROM pg_catalog.pg_index i JOIN pg_catalog.pg_class c ON c.oid = i.indexrelid
+WHERE i.indrelid = $1::regclass ORDER BY i.indisprimary DESC, c.relname`},
+		{"Check constraints:", `SELECT '"' || conname || '" ' || pg_catalog.pg_get_constraintdef(oid)
+FROM pg_catalog.pg_constraint WHERE conrelid = $1::regclass AND contype = 'c' ORDER BY conname`},
+		{"Foreign-key constraints:", `SELECT '"' || conname || '" ' || pg_catalog.pg_get_constraintdef(oid)
+FROM pg_catalog.pg_constraint WHERE conrelid = $1::regclass AND contype = 'f' ORDER BY conname`},
+		{"Referenced by:", `SELECT 'TABLE ' || conrelid::regclass || ' CONSTRAINT "' || conname || '" ' || pg_catalog.pg_get_constraintdef(oid)
+FROM pg_catalog.pg_constraint WHERE confrelid = $1::regclass AND contype = 'f' ORDER BY conname`},
+	}
+	for _, section := range sections {
+		lines, err := s.Query(ctx, section.query, name)
+		if err != nil {
+			return err
+		}
+		if len(lines.Rows) == 0 {
+			continue
+		}
+		fmt.Println(section.title)
+		for _, row := range lines.Rows {
+			fmt.Printf("    %s\n", cellText(row[0], ""))
+		}
+	}
+	fmt.Println()
+	return nil
+}
+
+func (d *PostgreSQL) listRoles(ctx context.Context, s *Shell) error {
+	r, err := s.Query(ctx, `SELECT r.rolname AS "Role name",
+       concat_ws(', ',
+         CASE WHEN r.rolsuper THEN 'Superuser' END,
+         CASE WHEN r.rolcreaterole THEN 'Create role' END,
+         CASE WHEN r.rolcreatedb THEN 'Create DB' END,
+         CASE WHEN NOT r.rolcanlogin THEN 'Cannot login' END,
+         CASE WHEN r.rolreplication THEN 'Replication' END,
+         CASE WHEN r.rolbypassrls THEN 'Bypass RLS' END) AS "Attributes",
+       pg_catalog.array_to_string(ARRAY(
+         SELECT b.rolname FROM pg_catalog.pg_auth_members m
+         JOIN pg_catalog.pg_roles b ON m.roleid = b.oid
+         WHERE m.member = r.oid ORDER BY 1), ', ') AS "Member of"
+FROM pg_catalog.pg_roles r
+WHERE r.rolname !~ '^pg_'
+ORDER BY 1`)
+	if err != nil {
+		return err
+	}
+	printListing(s, "List of roles", r)
+	return nil
+}
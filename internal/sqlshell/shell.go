@@ -0,0 +1,385 @@
+// Package sqlshell implements the built-in interactive SQL shells used when
+// no mysql or psql client is installed, and the statement splitting and
+// result rendering shared with the non-interactive query commands.
+package sqlshell
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Dialect holds what differs between the MySQL and PostgreSQL shells
+type Dialect interface {
+	// Name is used for the history file (~/.nhncloud/history/<name>_history)
+	Name() string
+	Syntax() Syntax
+	// SessionIDQuery returns the server-side ID of the current session
+	SessionIDQuery() string
+	// CancelStatement is run on a second connection to cancel the running
+	// statement of session id without closing the session
+	CancelStatement(id int64) string
+	Prompt(s *Shell, continuation bool) string
+	// Command runs a client command line such as "use db" or "\dt".
+	// It returns false when line is not a command of the dialect.
+	Command(ctx context.Context, s *Shell, line string) (bool, error)
+	// Executed is called after every statement to track session state,
+	// and with an empty stmt when the session is (re)connected
+	Executed(ctx context.Context, s *Shell, stmt string, err error)
+	Render(w io.Writer, r *Result, vertical bool)
+	Summary(r *Result, timing bool) string
+	FormatError(err error) string
+	Help() string
+}
+
+// Shell is an interactive session on a single connection, so that USE,
+// SET and transactions behave as in the native clients
+type Shell struct {
+	DB      *sql.DB
+	Conn    *sql.Conn
+	Dialect Dialect
+
+	// Database is the current database, shown in the prompt
+	Database string
+	Timing   bool
+	Expanded bool
+	// State is free for the dialect (e.g. the transaction status)
+	State string
+
+	delimiter string
+	sessionID int64
+	running   atomic.Bool
+	out       *os.File
+	outFormat string
+	reader    lineReader
+}
+
+// New opens the session connection
+func New(ctx context.Context, db *sql.DB, dialect Dialect, database string) (*Shell, error) {
+	s := &Shell{DB: db, Dialect: dialect, Database: database, delimiter: ";"}
+	if err := s.connect(ctx); err != nil {
+		return nil, err
+	}
+	dialect.Executed(ctx, s, "", nil)
+	return s, nil
+}
+
+func (s *Shell) connect(ctx context.Context) error {
+	conn, err := s.DB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	s.Conn = conn
+	if err := conn.QueryRowContext(ctx, s.Dialect.SessionIDQuery()).Scan(&s.sessionID); err != nil {
+		s.sessionID = 0
+	}
+	return nil
+}
+
+// Close closes the session and any \o file
+func (s *Shell) Close() {
+	s.closeOutput()
+	s.Conn.Close()
+}
+
+// Run reads and executes input until EOF or \q
+func (s *Shell) Run(ctx context.Context) error {
+	home, _ := os.UserHomeDir()
+	s.reader = newLineReader(filepath.Join(home, ".nhncloud", "history", s.Dialect.Name()+"_history"))
+	_, interactive := s.reader.(*terminalReader)
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt)
+	defer signal.Stop(sigs)
+	go func() {
+		for range sigs {
+			if s.running.Load() {
+				go s.cancelRunning()
+			} else if !interactive {
+				os.Exit(130)
+			}
+		}
+	}()
+
+	var buf string
+	for {
+		line, err := s.reader.ReadLine(s.Dialect.Prompt(s, buf != ""))
+		if errors.Is(err, errInterrupted) {
+			buf = ""
+			continue
+		}
+		if err == io.EOF {
+			if interactive {
+				fmt.Println("Bye")
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if buf == "" {
+			trimmed := strings.TrimSpace(line)
+			if trimmed == "" {
+				continue
+			}
+			if quit, handled := s.command(ctx, trimmed); handled {
+				s.reader.Record(trimmed)
+				if quit {
+					if interactive {
+						fmt.Println("Bye")
+					}
+					return nil
+				}
+				continue
+			}
+		}
+
+		buf += line + "\n"
+		stmts, rest, delimiter := Split(buf, s.Dialect.Syntax(), s.delimiter)
+		if consumed := strings.TrimSpace(buf[:len(buf)-len(rest)]); consumed != "" {
+			s.reader.Record(consumed)
+		}
+		s.delimiter = delimiter
+		buf = rest
+		if IsBlank(buf, s.Dialect.Syntax()) {
+			buf = ""
+		}
+		for _, stmt := range stmts {
+			s.Exec(ctx, stmt)
+		}
+	}
+}
+
+// command runs a client command; it reports whether line was one and
+// whether the shell should exit
+func (s *Shell) command(ctx context.Context, line string) (quit bool, handled bool) {
+	word, arg, _ := strings.Cut(strings.TrimRight(line, "; \t"), " ")
+	arg = strings.TrimSpace(arg)
+
+	switch strings.ToLower(word) {
+	case `\q`, "quit", "exit":
+		return true, true
+	case `\?`, `\h`:
+		fmt.Print(generalHelp + s.Dialect.Help())
+		return false, true
+	case `\timing`:
+		s.Timing = toggle(s.Timing, arg)
+		fmt.Printf("Timing is %s.\n", onOff(s.Timing))
+		return false, true
+	case `\x`:
+		s.Expanded = toggle(s.Expanded, arg)
+		fmt.Printf("Expanded display is %s.\n", onOff(s.Expanded))
+		return false, true
+	case `\o`:
+		if err := s.setOutput(arg); err != nil {
+			fmt.Fprintf(os.Stderr, "ERROR: %v\n", err)
+		}
+		return false, true
+	case "source", `\.`, `\i`:
+		if arg == "" {
+			fmt.Fprintf(os.Stderr, "ERROR: usage: %s <file>\n", word)
+			return false, true
+		}
+		if err := s.Source(ctx, strings.Trim(arg, `'"`)); err != nil {
+			fmt.Fprintf(os.Stderr, "ERROR: %v\n", err)
+		}
+		return false, true
+	}
+
+	handled, err := s.Dialect.Command(ctx, s, line)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, s.Dialect.FormatError(err))
+	}
+	return false, handled
+}
+
+const generalHelp = `General commands:
+  \q, quit, exit        leave the shell
+  \?                    show this help
+  \timing [on|off]      show how long each statement takes
+  \x [on|off]           toggle vertical (expanded) output
+  \o [file [format]]    send results to file (csv, json, yaml or text by extension); \o alone resets
+  source <file>, \i     execute statements from a file
+`
+
+func toggle(current bool, arg string) bool {
+	switch strings.ToLower(arg) {
+	case "on":
+		return true
+	case "off":
+		return false
+	}
+	return !current
+}
+
+func onOff(b bool) string {
+	if b {
+		return "on"
+	}
+	return "off"
+}
+
+func (s *Shell) setOutput(arg string) error {
+	s.closeOutput()
+	if arg == "" {
+		return nil
+	}
+	name, format, _ := strings.Cut(arg, " ")
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "" {
+		format = FormatForFile(name)
+	}
+	if format != "" && format != "csv" && format != "json" && format != "yaml" {
+		return fmt.Errorf("unknown format '%s' (csv, json, yaml)", format)
+	}
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	s.out, s.outFormat = f, format
+	return nil
+}
+
+func (s *Shell) closeOutput() {
+	if s.out != nil {
+		s.out.Close()
+		s.out = nil
+	}
+}
+
+// Source executes the statements of a file, stopping at the first error
+func (s *Shell) Source(ctx context.Context, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	stmts, rest, delimiter := Split(string(data)+"\n", s.Dialect.Syntax(), s.delimiter)
+	s.delimiter = delimiter
+	if !IsBlank(rest, s.Dialect.Syntax()) {
+		stmts = append(stmts, Statement{SQL: strings.TrimSpace(rest)})
+	}
+	for _, stmt := range stmts {
+		if err := s.Exec(ctx, stmt); err != nil {
+			return fmt.Errorf("%s: stopped after error", path)
+		}
+	}
+	return nil
+}
+
+// Exec runs one statement on the session and prints its results. Ctrl-C
+// cancels the statement on the server; the session stays open.
+func (s *Shell) Exec(ctx context.Context, stmt Statement) error {
+	s.running.Store(true)
+	start := time.Now()
+	results, err := s.run(ctx, stmt.SQL)
+	s.running.Store(false)
+	elapsed := time.Since(start)
+
+	if isConnectionLost(err) {
+		fmt.Fprintln(os.Stderr, "Connection lost. Reconnecting...")
+		s.Conn.Close()
+		if cerr := s.connect(ctx); cerr != nil {
+			fmt.Fprintf(os.Stderr, "ERROR: reconnect failed: %v\n", cerr)
+			return err
+		}
+		s.Dialect.Executed(ctx, s, "", nil)
+		start = time.Now()
+		results, err = s.run(ctx, stmt.SQL)
+		elapsed = time.Since(start)
+	}
+
+	s.Dialect.Executed(ctx, s, stmt.SQL, err)
+	for _, r := range results {
+		r.Elapsed = elapsed
+		s.Print(r, stmt.Vertical)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, s.Dialect.FormatError(err))
+	}
+	return err
+}
+
+func (s *Shell) run(ctx context.Context, query string) ([]*Result, error) {
+	if !ReturnsRows(query) {
+		res, err := s.Conn.ExecContext(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		affected, _ := res.RowsAffected()
+		return []*Result{{RowsAffected: affected, Query: query}}, nil
+	}
+	rows, err := s.Conn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	results, err := ReadResults(rows)
+	for _, r := range results {
+		r.Query = query
+	}
+	return results, err
+}
+
+func isConnectionLost(err error) bool {
+	return errors.Is(err, driver.ErrBadConn) || errors.Is(err, sql.ErrConnDone)
+}
+
+// Query runs a query on the session and returns its first result set. It is
+// meant for dialect commands such as \dt.
+func (s *Shell) Query(ctx context.Context, query string, args ...interface{}) (*Result, error) {
+	rows, err := s.Conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return readResult(rows)
+}
+
+// Print renders a result to the terminal, or to the \o file
+func (s *Shell) Print(r *Result, vertical bool) {
+	vertical = vertical || s.Expanded
+	if s.out == nil || !r.HasRows {
+		if r.HasRows {
+			s.Dialect.Render(os.Stdout, r, vertical)
+		}
+		if summary := s.Dialect.Summary(r, s.Timing); summary != "" {
+			fmt.Println(summary)
+		}
+		return
+	}
+
+	var err error
+	if s.outFormat != "" {
+		err = WriteFormat(s.out, r, s.outFormat)
+	} else {
+		s.Dialect.Render(s.out, r, vertical)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ERROR: writing %s: %v\n", s.out.Name(), err)
+		return
+	}
+	fmt.Printf("%d row(s) written to %s\n", len(r.Rows), s.out.Name())
+}
+
+func (s *Shell) cancelRunning() {
+	if s.sessionID == 0 {
+		fmt.Fprintln(os.Stderr, "^C -- cannot cancel: session ID unknown")
+		return
+	}
+	fmt.Fprintln(os.Stderr, "^C -- cancelling query...")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if _, err := s.DB.ExecContext(ctx, s.Dialect.CancelStatement(s.sessionID)); err != nil {
+		fmt.Fprintf(os.Stderr, "ERROR: cancel failed: %v\n", err)
+	}
+}
@@ -0,0 +1,196 @@
+package sqlshell
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Syntax describes the lexical rules the statement splitter has to know
+// about to find statement terminators outside of quotes and comments
+type Syntax struct {
+	// HashComments treats '#' as a line comment (MySQL)
+	HashComments bool
+	// Backticks quotes identifiers with '`' (MySQL)
+	Backticks bool
+	// BackslashEscapes lets '\' escape a quote inside strings (MySQL)
+	BackslashEscapes bool
+	// DollarQuotes enables $tag$...$tag$ strings (PostgreSQL)
+	DollarQuotes bool
+	// ClientTerminators enables the \G and \g terminators and the
+	// DELIMITER command (MySQL client)
+	ClientTerminators bool
+	// PsqlTerminators enables the \g and \gx terminators (psql)
+	PsqlTerminators bool
+}
+
+// Statement is one complete statement
+type Statement struct {
+	SQL string
+	// Vertical is set when the statement was terminated with \G (or \gx)
+	Vertical bool
+}
+
+var dollarTag = regexp.MustCompile(`^\$([A-Za-z_][A-Za-z0-9_]*)?\$`)
+
+// Split extracts the complete statements from text. The incomplete tail is
+// returned as rest. delimiter is the statement terminator (";" unless changed
+// with DELIMITER, which is returned updated).
+func Split(text string, syn Syntax, delimiter string) (stmts []Statement, rest string, newDelimiter string) {
+	if delimiter == "" {
+		delimiter = ";"
+	}
+	start := 0
+	i := 0
+	n := len(text)
+
+	emit := func(end, next int, vertical bool) {
+		if sql := strings.TrimSpace(text[start:end]); sql != "" && !IsBlank(sql, syn) {
+			stmts = append(stmts, Statement{SQL: sql, Vertical: vertical})
+		}
+		start = next
+		i = next
+	}
+
+	for i < n {
+		// DELIMITER is a client command that must be on its own line at the
+		// start of a statement
+		if syn.ClientTerminators && strings.TrimSpace(text[start:i]) == "" && hasPrefixFold(text[i:], "delimiter ") {
+			eol := strings.IndexByte(text[i:], '\n')
+			if eol < 0 {
+				break
+			}
+			if d := strings.TrimSpace(text[i+len("delimiter ") : i+eol]); d != "" {
+				delimiter = d
+			}
+			start = i + eol + 1
+			i = start
+			continue
+		}
+
+		c := text[i]
+		switch {
+		case c == '\'' || c == '"' || (c == '`' && syn.Backticks):
+			end := closeQuote(text, i, c, syn.BackslashEscapes && c != '`')
+			if end < 0 {
+				return stmts, text[start:], delimiter
+			}
+			i = end + 1
+		case c == '-' && strings.HasPrefix(text[i:], "--") && (!syn.HashComments || i+2 >= n || isSpace(text[i+2])):
+			eol := strings.IndexByte(text[i:], '\n')
+			if eol < 0 {
+				i = n
+				continue
+			}
+			i += eol + 1
+		case c == '#' && syn.HashComments:
+			eol := strings.IndexByte(text[i:], '\n')
+			if eol < 0 {
+				i = n
+				continue
+			}
+			i += eol + 1
+		case c == '/' && strings.HasPrefix(text[i:], "/*"):
+			end := strings.Index(text[i+2:], "*/")
+			if end < 0 {
+				return stmts, text[start:], delimiter
+			}
+			i += end + 4
+		case c == '$' && syn.DollarQuotes && dollarTag.MatchString(text[i:]):
+			tag := dollarTag.FindString(text[i:])
+			end := strings.Index(text[i+len(tag):], tag)
+			if end < 0 {
+				return stmts, text[start:], delimiter
+			}
+			i += len(tag) + end + len(tag)
+		case strings.HasPrefix(text[i:], delimiter):
+			emit(i, i+len(delimiter), false)
+		case syn.ClientTerminators && (strings.HasPrefix(text[i:], `\G`) || strings.HasPrefix(text[i:], `\g`)):
+			emit(i, i+2, text[i+1] == 'G')
+		case syn.PsqlTerminators && strings.HasPrefix(text[i:], `\gx`):
+			emit(i, i+3, true)
+		case syn.PsqlTerminators && strings.HasPrefix(text[i:], `\g`):
+			emit(i, i+2, false)
+		default:
+			i++
+		}
+	}
+	return stmts, text[start:], delimiter
+}
+
+// closeQuote returns the index of the quote closing the one at open, or -1
+func closeQuote(text string, open int, quote byte, backslash bool) int {
+	for i := open + 1; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			if backslash {
+				i++
+			}
+		case quote:
+			return i
+		}
+	}
+	return -1
+}
+
+// IsBlank reports whether text contains nothing but whitespace and comments
+func IsBlank(text string, syn Syntax) bool {
+	return stripComments(text, syn) == ""
+}
+
+func stripComments(text string, syn Syntax) string {
+	var b strings.Builder
+	for i := 0; i < len(text); {
+		switch {
+		case strings.HasPrefix(text[i:], "--"), syn.HashComments && text[i] == '#':
+			eol := strings.IndexByte(text[i:], '\n')
+			if eol < 0 {
+				return strings.TrimSpace(b.String())
+			}
+			i += eol + 1
+		case strings.HasPrefix(text[i:], "/*"):
+			end := strings.Index(text[i+2:], "*/")
+			if end < 0 {
+				return strings.TrimSpace(b.String())
+			}
+			i += end + 4
+		default:
+			b.WriteByte(text[i])
+			i++
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// FirstKeyword returns the upper-cased first word of a statement, skipping
+// comments and opening parentheses
+func FirstKeyword(stmt string) string {
+	s := strings.TrimLeft(stripComments(stmt, Syntax{HashComments: true}), "( \t\r\n")
+	end := strings.IndexFunc(s, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r == '_')
+	})
+	if end >= 0 {
+		s = s[:end]
+	}
+	return strings.ToUpper(s)
+}
+
+var returningClause = regexp.MustCompile(`(?i)\bRETURNING\b`)
+
+// ReturnsRows reports whether a statement produces a result set and has to
+// be run with Query rather than Exec
+func ReturnsRows(stmt string) bool {
+	switch FirstKeyword(stmt) {
+	case "SELECT", "SHOW", "DESC", "DESCRIBE", "EXPLAIN", "WITH", "VALUES", "TABLE",
+		"CALL", "HELP", "CHECK", "CHECKSUM", "OPTIMIZE", "REPAIR", "ANALYZE", "FETCH":
+		return true
+	}
+	return returningClause.MatchString(stmt)
+}
+
+func hasPrefixFold(s, prefix string) bool {
+	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
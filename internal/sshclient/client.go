@@ -0,0 +1,187 @@
+package sshclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/term"
+)
+
+const defaultDialTimeout = 15 * time.Second
+
+// Config describes how to reach and authenticate against a single SSH host
+type Config struct {
+	Host         string
+	Port         int
+	User         string
+	IdentityFile string
+	Timeout      time.Duration
+}
+
+// Client wraps an established SSH connection
+type Client struct {
+	conn *ssh.Client
+	addr string
+}
+
+// Dial connects to the host described by cfg using public key authentication
+func Dial(cfg Config) (*Client, error) {
+	clientConfig, addr, err := buildClientConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := ssh.Dial("tcp", addr, clientConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", addr, err)
+	}
+
+	return &Client{conn: conn, addr: addr}, nil
+}
+
+func buildClientConfig(cfg Config) (*ssh.ClientConfig, string, error) {
+	if cfg.Host == "" {
+		return nil, "", fmt.Errorf("host is required")
+	}
+	if cfg.User == "" {
+		return nil, "", fmt.Errorf("user is required")
+	}
+
+	port := cfg.Port
+	if port == 0 {
+		port = 22
+	}
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = defaultDialTimeout
+	}
+
+	var authMethods []ssh.AuthMethod
+	if cfg.IdentityFile != "" {
+		signer, err := LoadSigner(cfg.IdentityFile)
+		if err != nil {
+			return nil, "", err
+		}
+		authMethods = append(authMethods, ssh.PublicKeys(signer))
+	}
+	if len(authMethods) == 0 {
+		return nil, "", fmt.Errorf("no identity file available for %s@%s", cfg.User, cfg.Host)
+	}
+
+	// Host keys are not verified, matching the StrictHostKeyChecking=no
+	// behaviour of 'compute connect' for ephemeral cloud IPs.
+	clientConfig := &ssh.ClientConfig{
+		User:            cfg.User,
+		Auth:            authMethods,
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+		Timeout:         timeout,
+	}
+
+	return clientConfig, net.JoinHostPort(cfg.Host, strconv.Itoa(port)), nil
+}
+
+// LoadSigner reads a private key file, prompting for a passphrase on the
+// terminal when the key is encrypted
+func LoadSigner(path string) (ssh.Signer, error) {
+	if strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get home directory: %w", err)
+		}
+		path = filepath.Join(home, path[2:])
+	}
+
+	keyData, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read identity file: %w", err)
+	}
+
+	signer, err := ssh.ParsePrivateKey(keyData)
+	if err == nil {
+		return signer, nil
+	}
+
+	var missing *ssh.PassphraseMissingError
+	if !errors.As(err, &missing) {
+		return nil, fmt.Errorf("failed to parse identity file %s: %w", path, err)
+	}
+
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return nil, fmt.Errorf("identity file %s is encrypted and no terminal is available for the passphrase", path)
+	}
+
+	fmt.Fprintf(os.Stderr, "Enter passphrase for %s: ", path)
+	passphrase, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read passphrase: %w", err)
+	}
+
+	signer, err = ssh.ParsePrivateKeyWithPassphrase(keyData, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt identity file %s: %w", path, err)
+	}
+	return signer, nil
+}
+
+// Addr returns the remote address the client is connected to
+func (c *Client) Addr() string {
+	return c.addr
+}
+
+// Conn returns the underlying SSH connection
+func (c *Client) Conn() *ssh.Client {
+	return c.conn
+}
+
+// Close closes the SSH connection
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// Run executes command in a new session, streaming its output to stdout and
+// stderr. The remote exit status is returned; err is only set when the
+// command could not be run or did not report an exit status.
+func (c *Client) Run(ctx context.Context, command string, stdout, stderr io.Writer) (int, error) {
+	session, err := c.conn.NewSession()
+	if err != nil {
+		return -1, fmt.Errorf("failed to open session: %w", err)
+	}
+	defer session.Close()
+
+	session.Stdout = stdout
+	session.Stderr = stderr
+
+	done := make(chan error, 1)
+	go func() {
+		done <- session.Run(command)
+	}()
+
+	select {
+	case <-ctx.Done():
+		session.Signal(ssh.SIGTERM)
+		session.Close()
+		// Run returns once the output copies have finished, so stdout and
+		// stderr are not written to after Run returns
+		<-done
+		return -1, ctx.Err()
+	case err := <-done:
+		if err == nil {
+			return 0, nil
+		}
+		var exitErr *ssh.ExitError
+		if errors.As(err, &exitErr) {
+			return exitErr.ExitStatus(), nil
+		}
+		return -1, err
+	}
+}
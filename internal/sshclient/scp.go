@@ -0,0 +1,294 @@
+package sshclient
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Upload copies a local file (or directory when recursive is set) to
+// remotePath using the SCP protocol. As with scp, remotePath may name an
+// existing directory, in which case the source is created inside it.
+func (c *Client) Upload(localPath, remotePath string, recursive bool) error {
+	info, err := os.Stat(localPath)
+	if err != nil {
+		return err
+	}
+	if info.IsDir() && !recursive {
+		return fmt.Errorf("%s is a directory (use recursive copy)", localPath)
+	}
+
+	session, err := c.conn.NewSession()
+	if err != nil {
+		return fmt.Errorf("failed to open session: %w", err)
+	}
+	defer session.Close()
+
+	stdin, err := session.StdinPipe()
+	if err != nil {
+		return err
+	}
+	stdout, err := session.StdoutPipe()
+	if err != nil {
+		return err
+	}
+
+	flags := "-t"
+	if recursive {
+		flags = "-r -t"
+	}
+	if err := session.Start(fmt.Sprintf("scp %s %s", flags, shellQuote(remotePath))); err != nil {
+		return fmt.Errorf("failed to start remote scp: %w", err)
+	}
+
+	r := bufio.NewReader(stdout)
+	if err := readAck(r); err != nil {
+		return err
+	}
+
+	if info.IsDir() {
+		err = sendDir(stdin, r, localPath, info)
+	} else {
+		err = sendFile(stdin, r, localPath, info)
+	}
+	if err != nil {
+		return err
+	}
+
+	stdin.Close()
+	return session.Wait()
+}
+
+func sendFile(w io.Writer, r *bufio.Reader, localPath string, info os.FileInfo) error {
+	f, err := os.Open(localPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if _, err := fmt.Fprintf(w, "C%04o %d %s\n", info.Mode().Perm(), info.Size(), info.Name()); err != nil {
+		return err
+	}
+	if err := readAck(r); err != nil {
+		return err
+	}
+	if _, err := io.Copy(w, f); err != nil {
+		return err
+	}
+	if _, err := w.Write([]byte{0}); err != nil {
+		return err
+	}
+	return readAck(r)
+}
+
+func sendDir(w io.Writer, r *bufio.Reader, localPath string, info os.FileInfo) error {
+	if _, err := fmt.Fprintf(w, "D%04o 0 %s\n", info.Mode().Perm(), info.Name()); err != nil {
+		return err
+	}
+	if err := readAck(r); err != nil {
+		return err
+	}
+
+	entries, err := os.ReadDir(localPath)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		childPath := filepath.Join(localPath, entry.Name())
+		childInfo, err := os.Stat(childPath)
+		if err != nil {
+			return err
+		}
+		if childInfo.IsDir() {
+			err = sendDir(w, r, childPath, childInfo)
+		} else if childInfo.Mode().IsRegular() {
+			err = sendFile(w, r, childPath, childInfo)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if _, err := fmt.Fprint(w, "E\n"); err != nil {
+		return err
+	}
+	return readAck(r)
+}
+
+// Download copies remotePath to localPath using the SCP protocol. If
+// localPath is an existing directory the remote file or directory is created
+// inside it, otherwise it is written to localPath itself.
+func (c *Client) Download(remotePath, localPath string, recursive bool) error {
+	session, err := c.conn.NewSession()
+	if err != nil {
+		return fmt.Errorf("failed to open session: %w", err)
+	}
+	defer session.Close()
+
+	stdin, err := session.StdinPipe()
+	if err != nil {
+		return err
+	}
+	stdout, err := session.StdoutPipe()
+	if err != nil {
+		return err
+	}
+
+	flags := "-f"
+	if recursive {
+		flags = "-r -f"
+	}
+	if err := session.Start(fmt.Sprintf("scp %s %s", flags, shellQuote(remotePath))); err != nil {
+		return fmt.Errorf("failed to start remote scp: %w", err)
+	}
+
+	if err := receive(stdin, bufio.NewReader(stdout), localPath); err != nil {
+		return err
+	}
+
+	stdin.Close()
+	return session.Wait()
+}
+
+func receive(w io.Writer, r *bufio.Reader, target string) error {
+	targetIsDir := false
+	if info, err := os.Stat(target); err == nil && info.IsDir() {
+		targetIsDir = true
+	}
+
+	var dirs []string
+	first := true
+
+	// destination returns where an entry named name should be created
+	destination := func(name string) string {
+		if len(dirs) > 0 {
+			return filepath.Join(dirs[len(dirs)-1], name)
+		}
+		if targetIsDir {
+			return filepath.Join(target, name)
+		}
+		return target
+	}
+
+	if _, err := w.Write([]byte{0}); err != nil {
+		return err
+	}
+
+	for {
+		kind, err := r.ReadByte()
+		if err == io.EOF {
+			if first {
+				return fmt.Errorf("remote scp closed the connection without sending data")
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return err
+		}
+		line = strings.TrimSuffix(line, "\n")
+
+		switch kind {
+		case 1, 2:
+			return fmt.Errorf("remote scp: %s", line)
+		case 'T':
+			// Timestamps are not preserved.
+		case 'E':
+			if len(dirs) == 0 {
+				return fmt.Errorf("unexpected end of directory")
+			}
+			dirs = dirs[:len(dirs)-1]
+		case 'C', 'D':
+			mode, size, name, err := parseSCPHeader(line)
+			if err != nil {
+				return err
+			}
+			if name == "" || name == "." || name == ".." || strings.ContainsAny(name, "/\\") {
+				return fmt.Errorf("remote scp sent an invalid file name %q", name)
+			}
+			dest := destination(name)
+
+			if kind == 'D' {
+				if err := os.MkdirAll(dest, mode|0700); err != nil {
+					return err
+				}
+				dirs = append(dirs, dest)
+			} else {
+				if _, err := w.Write([]byte{0}); err != nil {
+					return err
+				}
+				if err := receiveFile(r, dest, mode, size); err != nil {
+					return err
+				}
+				if err := readAck(r); err != nil {
+					return err
+				}
+			}
+		default:
+			return fmt.Errorf("unexpected scp message %q", string(kind)+line)
+		}
+
+		first = false
+		if _, err := w.Write([]byte{0}); err != nil {
+			return err
+		}
+	}
+}
+
+func receiveFile(r io.Reader, dest string, mode os.FileMode, size int64) error {
+	f, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	if _, err := io.CopyN(f, r, size); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to receive %s: %w", dest, err)
+	}
+	return f.Close()
+}
+
+func parseSCPHeader(line string) (os.FileMode, int64, string, error) {
+	parts := strings.SplitN(line, " ", 3)
+	if len(parts) != 3 {
+		return 0, 0, "", fmt.Errorf("malformed scp header %q", line)
+	}
+	mode, err := strconv.ParseUint(parts[0], 8, 32)
+	if err != nil {
+		return 0, 0, "", fmt.Errorf("malformed scp mode %q", parts[0])
+	}
+	size, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, 0, "", fmt.Errorf("malformed scp size %q", parts[1])
+	}
+	return os.FileMode(mode).Perm(), size, parts[2], nil
+}
+
+func readAck(r *bufio.Reader) error {
+	b, err := r.ReadByte()
+	if err != nil {
+		return fmt.Errorf("failed to read scp response: %w", err)
+	}
+	if b == 0 {
+		return nil
+	}
+	msg, _ := r.ReadString('\n')
+	return fmt.Errorf("remote scp: %s", strings.TrimSpace(msg))
+}
+
+// shellQuote quotes a remote path for the shell, leaving a leading "~/"
+// unquoted so it is still expanded to the login user's home directory
+func shellQuote(s string) string {
+	prefix := ""
+	if strings.HasPrefix(s, "~/") {
+		prefix, s = "~/", s[2:]
+	}
+	return prefix + "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,62 @@
+package sshclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+)
+
+// Forward accepts connections on listener and forwards each one to
+// remoteAddr through the SSH connection (local port forwarding, like
+// 'ssh -L'). It blocks until ctx is cancelled or the listener is closed.
+// Errors for individual connections are reported to onError when non-nil.
+func (c *Client) Forward(ctx context.Context, listener net.Listener, remoteAddr string, onError func(error)) error {
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+
+	for {
+		local, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+
+		go func() {
+			if err := c.forwardConn(local, remoteAddr); err != nil && onError != nil {
+				onError(err)
+			}
+		}()
+	}
+}
+
+func (c *Client) forwardConn(local net.Conn, remoteAddr string) error {
+	defer local.Close()
+
+	remote, err := c.conn.Dial("tcp", remoteAddr)
+	if err != nil {
+		return fmt.Errorf("failed to reach %s through %s: %w", remoteAddr, c.addr, err)
+	}
+	defer remote.Close()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		io.Copy(remote, local)
+		remote.Close()
+	}()
+	go func() {
+		defer wg.Done()
+		io.Copy(local, remote)
+		local.Close()
+	}()
+	wg.Wait()
+	return nil
+}
@@ -1,6 +1,9 @@
 package sshkeys
 
 import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/json"
@@ -77,14 +80,19 @@ func (m *Manager) saveMetadata(keys []KeyInfo) error {
 }
 
 func (m *Manager) Import(name, filePath string) (*KeyInfo, error) {
-	if err := m.ensureDir(); err != nil {
-		return nil, err
-	}
-
 	keyData, err := os.ReadFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read key file: %w", err)
 	}
+	return m.ImportData(name, keyData)
+}
+
+// ImportData stores a PEM-encoded private key under name, replacing any
+// existing key of the same name
+func (m *Manager) ImportData(name string, keyData []byte) (*KeyInfo, error) {
+	if err := m.ensureDir(); err != nil {
+		return nil, err
+	}
 
 	keyInfo, err := m.parsePrivateKey(name, keyData)
 	if err != nil {
@@ -95,6 +103,10 @@ func (m *Manager) Import(name, filePath string) (*KeyInfo, error) {
 	if err := os.WriteFile(destPath, keyData, 0600); err != nil {
 		return nil, fmt.Errorf("failed to save key: %w", err)
 	}
+	// WriteFile keeps the mode of an existing file
+	if err := os.Chmod(destPath, 0600); err != nil {
+		return nil, fmt.Errorf("failed to set key permissions: %w", err)
+	}
 
 	keyInfo.Path = destPath
 	keyInfo.CreatedAt = time.Now()
@@ -117,6 +129,74 @@ func (m *Manager) Import(name, filePath string) (*KeyInfo, error) {
 	return keyInfo, nil
 }
 
+// Generate creates a new ed25519 or RSA (4096-bit) key in the store and
+// writes its public key next to it as <name>.pub
+func (m *Manager) Generate(name, keyType string) (*KeyInfo, error) {
+	if _, err := m.Get(name); err == nil {
+		return nil, fmt.Errorf("SSH key '%s' already exists", name)
+	}
+
+	var privateKey crypto.PrivateKey
+	switch strings.ToLower(keyType) {
+	case "", "ed25519":
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		privateKey = key
+	case "rsa":
+		key, err := rsa.GenerateKey(rand.Reader, 4096)
+		if err != nil {
+			return nil, err
+		}
+		privateKey = key
+	default:
+		return nil, fmt.Errorf("unsupported key type '%s' (ed25519 or rsa)", keyType)
+	}
+
+	block, err := ssh.MarshalPrivateKey(privateKey, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode private key: %w", err)
+	}
+
+	keyInfo, err := m.ImportData(name, pem.EncodeToMemory(block))
+	if err != nil {
+		return nil, err
+	}
+
+	pubPath := filepath.Join(m.baseDir, name+".pub")
+	if err := os.WriteFile(pubPath, []byte(keyInfo.PublicKey+" "+name+"\n"), 0644); err != nil {
+		return nil, fmt.Errorf("failed to save public key: %w", err)
+	}
+
+	return keyInfo, nil
+}
+
+// PublicKey returns the authorized_keys form of a stored key's public key,
+// deriving it from the private key for entries saved without one
+func (m *Manager) PublicKey(name string) (string, error) {
+	keyInfo, err := m.Get(name)
+	if err != nil {
+		return "", err
+	}
+	if keyInfo.PublicKey != "" {
+		return keyInfo.PublicKey, nil
+	}
+
+	keyData, err := os.ReadFile(keyInfo.Path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read key: %w", err)
+	}
+	parsed, err := m.parsePrivateKey(name, keyData)
+	if err != nil {
+		return "", err
+	}
+	if parsed.PublicKey == "" {
+		return "", fmt.Errorf("cannot derive a public key from SSH key '%s'", name)
+	}
+	return parsed.PublicKey, nil
+}
+
 func (m *Manager) parsePrivateKey(name string, keyData []byte) (*KeyInfo, error) {
 	block, _ := pem.Decode(keyData)
 	if block == nil {
@@ -214,6 +294,7 @@ func (m *Manager) Remove(name string) error {
 			if err := os.Remove(key.Path); err != nil && !os.IsNotExist(err) {
 				return err
 			}
+			os.Remove(strings.TrimSuffix(key.Path, ".pem") + ".pub")
 		} else {
 			newKeys = append(newKeys, key)
 		}